@@ -1,27 +1,158 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	tea "github.com/charmbracelet/bubbletea"
 	zone "github.com/lrstanley/bubblezone"
 
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/api/fake"
 	"github.com/NotMugil/hardcover-tui/internal/app"
+	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/debug"
+	"github.com/NotMugil/hardcover-tui/internal/storage"
 )
 
 var version = "dev"
 
 func main() {
-	if len(os.Args) > 1 && (os.Args[1] == "--version" || os.Args[1] == "-v") {
+	var (
+		showVersion bool
+		recordFile  string
+		replayFile  string
+		demo        bool
+		debugLog    bool
+	)
+	flag.BoolVar(&showVersion, "version", false, "print the version and exit")
+	flag.BoolVar(&showVersion, "v", false, "print the version and exit (shorthand)")
+	flag.StringVar(&recordFile, "record", "", "record API requests and responses to `file` (auth headers are scrubbed)")
+	flag.StringVar(&replayFile, "replay", "", "serve API responses from a `file` made with --record instead of the network")
+	flag.BoolVar(&demo, "demo", false, "run against a built-in fake server with a sample library")
+	flag.BoolVar(&debugLog, "debug", false, "log API calls and app messages to debug.log in the config directory; f12 toggles a debug overlay")
+	flag.Usage = usage
+	flag.Parse()
+
+	if showVersion {
 		fmt.Printf("hardcover-tui %s\n", version)
 		os.Exit(0)
 	}
 
-	zone.NewGlobal()
-	p := tea.NewProgram(app.New(), tea.WithAltScreen(), tea.WithMouseCellMotion())
-	if _, err := p.Run(); err != nil {
+	link, err := parseArgs(flag.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		usage()
+		os.Exit(2)
+	}
+
+	if err := run(recordFile, replayFile, demo, debugLog, link); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage:\n")
+	fmt.Fprintf(out, "  hardcover-tui [flags]\n")
+	fmt.Fprintf(out, "  hardcover-tui [flags] open <url|slug|book-id>\n\n")
+	fmt.Fprintf(out, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+// parseArgs reads the optional "open" command, which starts the app on a
+// book, list or user instead of where the last session left off.
+func parseArgs(args []string) (*common.DeepLink, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	if args[0] != "open" {
+		return nil, fmt.Errorf("unknown command %q", args[0])
+	}
+	if len(args) != 2 {
+		return nil, errors.New("open takes one hardcover.app URL, book slug, book ID or @username")
+	}
+	link, err := common.ParseDeepLink(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func run(recordFile, replayFile string, demo, debugLog bool, link *common.DeepLink) error {
+	if debugLog {
+		path, err := debugLogPath()
+		if err != nil {
+			return err
+		}
+		log, err := debug.Start(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			log.Close()
+			fmt.Fprintf(os.Stderr, "Debug log written to %s\n", path)
+		}()
+	}
+
+	if demo {
+		if replayFile != "" {
+			return errors.New("--demo and --replay cannot be used together")
+		}
+		srv := fake.NewServer()
+		endpoint, err := srv.Start()
+		if err != nil {
+			return err
+		}
+		defer srv.Close()
+		os.Setenv(api.EnvEndpoint, endpoint)
+	}
+
+	if err := api.CheckConfig(); err != nil {
+		return err
+	}
+
+	switch {
+	case recordFile != "" && replayFile != "":
+		return errors.New("--record and --replay cannot be used together")
+	case recordFile != "":
+		rec, err := api.StartRecording(recordFile)
+		if err != nil {
+			return err
+		}
+		defer rec.Close()
+	case replayFile != "":
+		if err := api.StartReplay(replayFile); err != nil {
+			return err
+		}
+	}
+
+	zone.NewGlobal()
+	model := app.New()
+	if demo {
+		model = app.NewWithToken(fake.Token)
+	}
+	if link != nil {
+		model = model.Open(*link)
+	}
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	_, err := p.Run()
+	return err
+}
+
+// debugLogPath returns where --debug writes its log, creating the config
+// directory if needed.
+func debugLogPath() (string, error) {
+	dir, err := storage.Dir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "debug.log"), nil
+}
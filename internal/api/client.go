@@ -2,34 +2,48 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"net/http"
 	"sync"
 	"time"
 
 	graphql "github.com/hasura/go-graphql-client"
-	"golang.org/x/time/rate"
 )
 
 const (
-	graphqlEndpoint = "https://api.hardcover.app/v1/graphql"
+	graphqlEndpoint = "https://api.hardcover.app/v1/graphql" // default, see Endpoint
 	userAgent       = "github.com/NotMugil/hardcover-tui/1.0"
 	requestsPerMin  = 60
 	requestTimeout  = 30 * time.Second
+
+	// Retry policy for idempotent requests.
+	maxRetries   = 3
+	retryMaxWait = 30 * time.Second
 )
 
+// retryBaseWait is the backoff before the first retry, doubling after
+// each one. Tests shorten it.
+var retryBaseWait = 500 * time.Millisecond
+
 // Client wraps the GraphQL client with rate limiting and auth.
 type Client struct {
-	gql     *graphql.Client
-	limiter *rate.Limiter
-	token   string
-	mu      sync.RWMutex
+	gql   *graphql.Client
+	sched *scheduler
+	token string
+	mu    sync.RWMutex
+
+	// unauthorized receives a value when a request fails with
+	// ErrUnauthorized, so the app can ask for a new token.
+	unauthorized chan struct{}
 }
 
 // authTransport injects auth headers into every request.
 type authTransport struct {
 	wrapped   http.RoundTripper
 	tokenFunc func() string
+	sched     *scheduler
 }
 
 func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -38,15 +52,31 @@ func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req.Header.Set("User-Agent", userAgent)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	return t.wrapped.RoundTrip(req)
+	resp, err := t.wrapped.RoundTrip(req)
+	if tr := traceFrom(req.Context()); tr != nil {
+		tr.observe(req, resp)
+	}
+	if err == nil {
+		t.sched.observe(resp.Header)
+		if hint := retryHintFrom(req.Context()); hint != nil {
+			hint.after = parseRetryAfter(resp.Header.Get("Retry-After"))
+		}
+	}
+	return resp, err
 }
 
 // NewClient creates a new API client with the given auth token.
 // The token should include the "Bearer " prefix.
 func NewClient(token string) *Client {
+	interval := time.Minute / requestsPerMin
+	if Replaying() {
+		// Recorded responses need no pacing.
+		interval = 0
+	}
 	c := &Client{
-		token:   token,
-		limiter: rate.NewLimiter(rate.Every(time.Minute/requestsPerMin), 1),
+		token:        token,
+		sched:        newScheduler(interval),
+		unauthorized: make(chan struct{}, 1),
 	}
 
 	httpClient := &http.Client{
@@ -57,11 +87,12 @@ func NewClient(token string) *Client {
 				defer c.mu.RUnlock()
 				return c.token
 			},
-			wrapped: http.DefaultTransport,
+			wrapped: apiTransport(),
+			sched:   c.sched,
 		},
 	}
 
-	c.gql = graphql.NewClient(graphqlEndpoint, httpClient)
+	c.gql = graphql.NewClient(Endpoint(), httpClient)
 	return c
 }
 
@@ -72,26 +103,113 @@ func (c *Client) SetToken(token string) {
 	c.token = token
 }
 
-// Query executes a GraphQL query with rate limiting.
-func (c *Client) Query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
-	if err := c.limiter.Wait(ctx); err != nil {
-		return fmt.Errorf("rate limit: %w", err)
+// Unauthorized returns a channel that receives a value whenever a request
+// is rejected because the token is invalid, expired or revoked. Repeated
+// failures before the value is read are coalesced.
+func (c *Client) Unauthorized() <-chan struct{} {
+	return c.unauthorized
+}
+
+// QueueDepth returns how many requests are waiting for a rate limit slot.
+func (c *Client) QueueDepth() int {
+	return c.sched.Depth()
+}
+
+// QueueChanged returns a channel that receives a value whenever
+// QueueDepth changes. Changes before the value is read are coalesced.
+func (c *Client) QueueChanged() <-chan struct{} {
+	return c.sched.changed
+}
+
+// report signals Unauthorized listeners if err is an auth failure.
+func (c *Client) report(err error) error {
+	if errors.Is(err, ErrUnauthorized) {
+		select {
+		case c.unauthorized <- struct{}{}:
+		default:
+		}
 	}
-	return c.gql.Query(ctx, q, variables)
+	return err
+}
+
+// Query executes a GraphQL query with rate limiting. Rate-limited and
+// transient network failures are retried with backoff. Queries are
+// scheduled at PriorityNormal unless ctx says otherwise.
+func (c *Client) Query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	return c.retry(ctx, func(ctx context.Context) error {
+		return c.gql.Query(ctx, q, variables)
+	})
 }
 
-// Mutate executes a GraphQL mutation with rate limiting.
+// Mutate executes a GraphQL mutation with rate limiting. Mutations are not
+// idempotent, so they are never retried. They are scheduled at
+// PriorityUser, ahead of queries, unless ctx says otherwise.
 func (c *Client) Mutate(ctx context.Context, m interface{}, variables map[string]interface{}) error {
-	if err := c.limiter.Wait(ctx); err != nil {
+	waitStart := time.Now()
+	if err := c.sched.Wait(ctx, priorityFrom(ctx, PriorityUser)); err != nil {
 		return fmt.Errorf("rate limit: %w", err)
 	}
-	return c.gql.Mutate(ctx, m, variables)
+	ctx, tr := startTrace(ctx, time.Since(waitStart))
+	ctx, hint := withRetryHint(ctx)
+	err := classify(c.gql.Mutate(ctx, m, variables), hint.after)
+	tr.finish(err)
+	c.backOff(err)
+	return c.report(err)
 }
 
-// ExecRaw executes a raw GraphQL query string with rate limiting.
+// ExecRaw executes a raw GraphQL query string with rate limiting. Only
+// queries are sent through ExecRaw, so failures are retried like Query.
 func (c *Client) ExecRaw(ctx context.Context, query string, variables map[string]any) ([]byte, error) {
-	if err := c.limiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("rate limit: %w", err)
+	var data []byte
+	err := c.retry(ctx, func(ctx context.Context) error {
+		var err error
+		data, err = c.gql.ExecRaw(ctx, query, variables)
+		return err
+	})
+	return data, err
+}
+
+// retry runs do until it succeeds, fails permanently or runs out of
+// attempts. Waits grow exponentially with random jitter unless the server
+// sent a Retry-After.
+func (c *Client) retry(ctx context.Context, do func(context.Context) error) error {
+	priority := priorityFrom(ctx, PriorityNormal)
+	for attempt := 0; ; attempt++ {
+		waitStart := time.Now()
+		if err := c.sched.Wait(ctx, priority); err != nil {
+			return fmt.Errorf("rate limit: %w", err)
+		}
+		reqCtx, tr := startTrace(ctx, time.Since(waitStart))
+		reqCtx, hint := withRetryHint(reqCtx)
+		err := classify(do(reqCtx), hint.after)
+		tr.finish(err)
+		c.backOff(err)
+		if err == nil || attempt >= maxRetries || !retryable(err) {
+			return c.report(err)
+		}
+
+		wait := hint.after
+		if wait == 0 {
+			backoff := retryBaseWait << attempt
+			wait = backoff/2 + rand.N(backoff/2+1)
+		}
+		wait = min(wait, retryMaxWait)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return c.report(err)
+		case <-timer.C:
+		}
+	}
+}
+
+// backOff holds every queued request for the server's Retry-After when err
+// says the rate limit was hit.
+func (c *Client) backOff(err error) {
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.Kind == ErrRateLimited && apiErr.RetryAfter > 0 {
+		c.sched.pause(apiErr.RetryAfter)
 	}
-	return c.gql.ExecRaw(ctx, query, variables)
 }
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	graphql "github.com/hasura/go-graphql-client"
+)
+
+// scriptedServer answers the nth request with the nth status of script,
+// and with the last one once the script runs out. 200 sends data.
+func scriptedServer(t *testing.T, retryAfter string, script ...int) (*Client, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1)) - 1
+		status := script[min(n, len(script)-1)]
+		if status != http.StatusOK {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data": {"me": [{"id": 1}]}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := &Client{
+		token:        "Bearer test",
+		sched:        newScheduler(0),
+		unauthorized: make(chan struct{}, 1),
+	}
+	c.gql = graphql.NewClient(srv.URL, &http.Client{Transport: &authTransport{
+		wrapped:   http.DefaultTransport,
+		tokenFunc: func() string { return c.token },
+		sched:     c.sched,
+	}})
+	return c, &calls
+}
+
+type meQuery struct {
+	Me []struct{ ID int } `graphql:"me"`
+}
+
+func TestQueryRetries(t *testing.T) {
+	defer func(d time.Duration) { retryBaseWait = d }(retryBaseWait)
+	retryBaseWait = time.Millisecond
+
+	tests := []struct {
+		name   string
+		script []int
+		calls  int
+		kind   error // nil for success
+	}{
+		{"success", []int{200}, 1, nil},
+		{"unavailable once", []int{503, 200}, 2, nil},
+		{"rate limited twice", []int{429, 429, 200}, 3, nil},
+		{"always unavailable", []int{500}, maxRetries + 1, ErrNetwork},
+		{"always rate limited", []int{429}, maxRetries + 1, ErrRateLimited},
+		{"unauthorized", []int{401, 200}, 1, ErrUnauthorized},
+		{"forbidden", []int{403, 200}, 1, nil},
+		{"not found", []int{404, 200}, 1, nil},
+		{"bad request", []int{400, 200}, 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, calls := scriptedServer(t, "", tt.script...)
+			var q meQuery
+			err := c.Query(context.Background(), &q, nil)
+			if got := int(calls.Load()); got != tt.calls {
+				t.Errorf("%d requests, want %d", got, tt.calls)
+			}
+			switch {
+			case tt.kind != nil && !errors.Is(err, tt.kind):
+				t.Errorf("err = %v, want %v", err, tt.kind)
+			case tt.kind == nil && tt.script[tt.calls-1] == 200 && err != nil:
+				t.Errorf("err = %v, want success", err)
+			case tt.kind == nil && tt.script[tt.calls-1] != 200 && err == nil:
+				t.Error("succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestQueryReportsUnauthorized(t *testing.T) {
+	c, _ := scriptedServer(t, "", 401)
+	var q meQuery
+	_ = c.Query(context.Background(), &q, nil)
+	select {
+	case <-c.Unauthorized():
+	default:
+		t.Error("Unauthorized was not signalled")
+	}
+}
+
+func TestQueryWaitsForRetryAfter(t *testing.T) {
+	defer func(d time.Duration) { retryBaseWait = d }(retryBaseWait)
+	retryBaseWait = time.Millisecond
+
+	c, calls := scriptedServer(t, "1", 429, 200)
+	start := time.Now()
+	var q meQuery
+	if err := c.Query(context.Background(), &q, nil); err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("%d requests, want 2", calls.Load())
+	}
+	if waited := time.Since(start); waited < time.Second {
+		t.Errorf("retried after %v, want the 1s Retry-After", waited)
+	}
+}
+
+func TestQueryStopsRetryingWhenCancelled(t *testing.T) {
+	defer func(d time.Duration) { retryBaseWait = d }(retryBaseWait)
+	retryBaseWait = time.Minute
+
+	c, calls := scriptedServer(t, "", 503)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var q meQuery
+	err := c.Query(ctx, &q, nil)
+	if !errors.Is(err, ErrNetwork) {
+		t.Errorf("err = %v, want the last failure", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("%d requests, want 1", calls.Load())
+	}
+}
+
+func TestMutateIsNotRetried(t *testing.T) {
+	defer func(d time.Duration) { retryBaseWait = d }(retryBaseWait)
+	retryBaseWait = time.Millisecond
+
+	c, calls := scriptedServer(t, "", 503, 200)
+	var m struct {
+		Me []struct{ ID int } `graphql:"me"`
+	}
+	if err := c.Mutate(context.Background(), &m, nil); !errors.Is(err, ErrNetwork) {
+		t.Errorf("err = %v, want %v", err, ErrNetwork)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("%d requests, want 1", calls.Load())
+	}
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	graphql "github.com/hasura/go-graphql-client"
+)
+
+// Sentinel errors returned (wrapped) by Client. Use errors.Is to check them.
+var (
+	ErrUnauthorized = errors.New("not authorized")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrNotFound     = errors.New("not found")
+	ErrNetwork      = errors.New("network error")
+)
+
+// Error is a classified API failure. It matches its Kind with errors.Is
+// and still unwraps to the underlying go-graphql-client error.
+type Error struct {
+	Kind       error         // one of the Err* sentinels, or nil if unclassified
+	StatusCode int           // HTTP status, 0 if the request never got a response
+	RetryAfter time.Duration // server-requested wait, 0 if none
+	Err        error
+}
+
+func (e *Error) Error() string {
+	detail := errorDetail(e.Err)
+	switch e.Kind {
+	case ErrUnauthorized:
+		return "not authorized: your API token is invalid or has expired"
+	case ErrRateLimited:
+		if e.RetryAfter > 0 {
+			return fmt.Sprintf("rate limited by Hardcover, try again in %s", e.RetryAfter.Round(time.Second))
+		}
+		return "rate limited by Hardcover, try again shortly"
+	case ErrNotFound:
+		return "not found: " + detail
+	case ErrNetwork:
+		if e.StatusCode >= 500 {
+			return fmt.Sprintf("Hardcover is unavailable (%d %s)", e.StatusCode, http.StatusText(e.StatusCode))
+		}
+		return "network error: " + detail
+	}
+	return detail
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Kind == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// errorDetail extracts a readable message from a go-graphql-client error,
+// whose own Error() includes locations and extensions.
+func errorDetail(err error) string {
+	var gqlErrs graphql.Errors
+	if errors.As(err, &gqlErrs) && len(gqlErrs) > 0 {
+		msgs := make([]string, 0, len(gqlErrs))
+		for _, e := range gqlErrs {
+			msgs = append(msgs, e.Message)
+		}
+		return strings.Join(msgs, "; ")
+	}
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// classify wraps err in an *Error when it can be mapped to a sentinel.
+// Context cancellation and deadline errors are returned unchanged.
+func classify(err error, retryAfter time.Duration) error {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	e := &Error{Err: err, RetryAfter: retryAfter}
+
+	var netErr graphql.NetworkError
+	if errors.As(err, &netErr) {
+		e.StatusCode = netErr.StatusCode()
+		// 403 is a permission error on this request, not a bad token, and
+		// a 404 means the endpoint is wrong rather than a record is gone.
+		switch {
+		case e.StatusCode == http.StatusUnauthorized:
+			e.Kind = ErrUnauthorized
+		case e.StatusCode == http.StatusTooManyRequests:
+			e.Kind = ErrRateLimited
+		case e.StatusCode >= 500:
+			e.Kind = ErrNetwork
+		}
+		return e
+	}
+
+	var urlErr *url.Error
+	var opErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &opErr) {
+		e.Kind = ErrNetwork
+		return e
+	}
+
+	// GraphQL errors arrive with a 200 status; Hasura puts a code in the
+	// extensions. Only the codes for a bad token and a missing record are
+	// classified: messages are not matched, as "not found" also appears in
+	// validation errors about fields. Queries report an empty *_by_pk
+	// result as ErrNotFound themselves.
+	var gqlErrs graphql.Errors
+	if errors.As(err, &gqlErrs) {
+		for _, ge := range gqlErrs {
+			code, _ := ge.Extensions["code"].(string)
+			switch code {
+			case "invalid-jwt", "invalid-headers":
+				e.Kind = ErrUnauthorized
+			case "not-found":
+				e.Kind = ErrNotFound
+			}
+			if e.Kind != nil {
+				break
+			}
+		}
+	}
+	return e
+}
+
+// retryable reports whether a failed request may succeed if sent again.
+func retryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrNetwork)
+}
+
+// retryHint carries the Retry-After header of the last response back from
+// the transport to the retry loop.
+type retryHint struct {
+	after time.Duration
+}
+
+type retryHintKey struct{}
+
+func withRetryHint(ctx context.Context) (context.Context, *retryHint) {
+	h := &retryHint{}
+	return context.WithValue(ctx, retryHintKey{}, h), h
+}
+
+func retryHintFrom(ctx context.Context) *retryHint {
+	h, _ := ctx.Value(retryHintKey{}).(*retryHint)
+	return h
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an
+// HTTP date.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	graphql "github.com/hasura/go-graphql-client"
+)
+
+// statusError returns the error go-graphql-client reports for a response
+// with the given HTTP status.
+func statusError(t *testing.T, status int) error {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, http.StatusText(status), status)
+	}))
+	defer srv.Close()
+	var q struct {
+		Me struct{ ID int } `graphql:"me"`
+	}
+	err := graphql.NewClient(srv.URL, srv.Client()).Query(context.Background(), &q, nil)
+	if err == nil {
+		t.Fatalf("status %d: no error", status)
+	}
+	return err
+}
+
+func TestClassifyStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		kind   error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, nil},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusNotFound, nil},
+		{http.StatusInternalServerError, ErrNetwork},
+		{http.StatusBadGateway, ErrNetwork},
+		{http.StatusServiceUnavailable, ErrNetwork},
+		{http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		err := classify(statusError(t, tt.status), 0)
+		var apiErr *Error
+		if !errors.As(err, &apiErr) {
+			t.Errorf("status %d: got %T, want *Error", tt.status, err)
+			continue
+		}
+		if apiErr.Kind != tt.kind {
+			t.Errorf("status %d: kind %v, want %v", tt.status, apiErr.Kind, tt.kind)
+		}
+		if apiErr.StatusCode != tt.status {
+			t.Errorf("status %d: StatusCode %d", tt.status, apiErr.StatusCode)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	gqlErr := func(msg, code string) error {
+		e := graphql.Error{Message: msg}
+		if code != "" {
+			e.Extensions = map[string]any{"code": code}
+		}
+		return graphql.Errors{e}
+	}
+	tests := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"invalid jwt", gqlErr("Could not verify JWT: JWTExpired", "invalid-jwt"), ErrUnauthorized},
+		{"invalid headers", gqlErr("Missing Authorization header", "invalid-headers"), ErrUnauthorized},
+		{"access denied", gqlErr("no permission", "access-denied"), nil},
+		{"unauthorized message", gqlErr("Unauthorized", ""), nil},
+		{"jwt message", gqlErr("jwt claims are missing", ""), nil},
+		{"not-found code", gqlErr("no such book", "not-found"), ErrNotFound},
+		{"missing field", gqlErr("field 'x' not found in type: 'query_root'", "validation-failed"), nil},
+		{"not found message", gqlErr("book not found", ""), nil},
+		{"rate limit message", gqlErr("Rate limit exceeded", ""), nil},
+		{"later error matches", graphql.Errors{{Message: "first"}, {Message: "gone", Extensions: map[string]any{"code": "not-found"}}}, ErrNotFound},
+		{"url error", &url.Error{Op: "Post", URL: "https://api.hardcover.app", Err: errors.New("connection refused")}, ErrNetwork},
+		{"wrapped url error", fmt.Errorf("query: %w", &url.Error{Op: "Post", URL: "x", Err: errors.New("eof")}), ErrNetwork},
+		{"plain error", errors.New("boom"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classify(tt.err, 0)
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got %T, want *Error", err)
+			}
+			if apiErr.Kind != tt.kind {
+				t.Errorf("kind %v, want %v", apiErr.Kind, tt.kind)
+			}
+			if !reflect.DeepEqual(apiErr.Err, tt.err) {
+				t.Errorf("Err = %v, want the original error", apiErr.Err)
+			}
+			if tt.kind != nil && !errors.Is(err, tt.kind) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.kind)
+			}
+		})
+	}
+}
+
+func TestClassifyUnchanged(t *testing.T) {
+	deadline := fmt.Errorf("query: %w", context.DeadlineExceeded)
+	for _, err := range []error{nil, context.Canceled, deadline} {
+		if got := classify(err, 0); got != err {
+			t.Errorf("classify(%v) = %v, want it unchanged", err, got)
+		}
+	}
+}
+
+func TestClassifyRetryAfter(t *testing.T) {
+	err := classify(statusError(t, http.StatusTooManyRequests), 7*time.Second)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != 7*time.Second {
+		t.Fatalf("classify = %#v, want RetryAfter 7s", err)
+	}
+	if got, want := err.Error(), "rate limited by Hardcover, try again in 7s"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{" 120 ", 2 * time.Minute},
+		{"0", 0},
+		{"-3", 0},
+		{"soon", 0},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.in); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	date := time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(date); got < 85*time.Second || got > 90*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want about 90s", date, got)
+	}
+}
@@ -0,0 +1,345 @@
+package fake
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The parser covers the GraphQL the app sends: operations with variable
+// definitions, fields with aliases, arguments and nested selections.
+// Fragments and directives are not supported.
+
+type operation struct {
+	kind       string // "query" or "mutation"
+	selections []*field
+}
+
+type field struct {
+	alias      string
+	name       string
+	args       map[string]any // literal values, with varRef and enumValue nodes
+	selections []*field
+}
+
+// key is the response key for the field.
+func (f *field) key() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type varRef string
+
+type enumValue string
+
+type token struct {
+	kind byte // 'n' name, 'i' int, 'f' float, 's' string, 'p' punctuator, 0 EOF
+	text string
+}
+
+type parser struct {
+	src string
+	pos int
+	tok token
+	err error
+}
+
+func parse(src string) (*operation, error) {
+	p := &parser{src: src}
+	p.next()
+	op, err := p.operation()
+	if err != nil {
+		return nil, err
+	}
+	return op, p.err
+}
+
+func (p *parser) fail(format string, args ...any) {
+	if p.err == nil {
+		p.err = fmt.Errorf("parse: "+format, args...)
+	}
+	p.tok = token{}
+}
+
+func (p *parser) next() {
+	p.skipIgnored()
+	src := p.src
+	if p.pos >= len(src) {
+		p.tok = token{}
+		return
+	}
+
+	start := p.pos
+	c := src[p.pos]
+	switch {
+	case c == '_' || unicode.IsLetter(rune(c)):
+		for p.pos < len(src) && (src[p.pos] == '_' || unicode.IsLetter(rune(src[p.pos])) || unicode.IsDigit(rune(src[p.pos]))) {
+			p.pos++
+		}
+		p.tok = token{'n', src[start:p.pos]}
+	case c == '-' || unicode.IsDigit(rune(c)):
+		p.pos++
+		kind := byte('i')
+		for p.pos < len(src) {
+			d := src[p.pos]
+			if d == '.' || d == 'e' || d == 'E' || ((d == '+' || d == '-') && (src[p.pos-1] == 'e' || src[p.pos-1] == 'E')) {
+				kind = 'f'
+			} else if !unicode.IsDigit(rune(d)) {
+				break
+			}
+			p.pos++
+		}
+		p.tok = token{kind, src[start:p.pos]}
+	case c == '"':
+		p.tok = token{'s', p.lexString()}
+	case c == '.' && strings.HasPrefix(src[p.pos:], "..."):
+		p.pos += 3
+		p.tok = token{'p', "..."}
+	default:
+		p.pos++
+		p.tok = token{'p', string(c)}
+	}
+}
+
+// skipIgnored skips whitespace, commas and comments.
+func (p *parser) skipIgnored() {
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		case c == ',' || unicode.IsSpace(rune(c)):
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) lexString() string {
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		end := strings.Index(p.src[p.pos+3:], `"""`)
+		if end < 0 {
+			p.fail("unterminated block string")
+			return ""
+		}
+		s := p.src[p.pos+3 : p.pos+3+end]
+		p.pos += end + 6
+		return s
+	}
+	var b strings.Builder
+	p.pos++ // opening quote
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch c {
+		case '"':
+			p.pos++
+			return b.String()
+		case '\\':
+			p.pos++
+			if p.pos >= len(p.src) {
+				break
+			}
+			switch e := p.src[p.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'u':
+				if p.pos+4 < len(p.src) {
+					if n, err := strconv.ParseUint(p.src[p.pos+1:p.pos+5], 16, 32); err == nil {
+						b.WriteRune(rune(n))
+						p.pos += 4
+					}
+				}
+			default:
+				b.WriteByte(e)
+			}
+			p.pos++
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	p.fail("unterminated string")
+	return ""
+}
+
+func (p *parser) is(kind byte, text string) bool {
+	return p.tok.kind == kind && p.tok.text == text
+}
+
+func (p *parser) expect(text string) {
+	if !p.is('p', text) {
+		p.fail("expected %q, got %q", text, p.tok.text)
+		return
+	}
+	p.next()
+}
+
+func (p *parser) operation() (*operation, error) {
+	op := &operation{kind: "query"}
+	if p.tok.kind == 'n' {
+		switch p.tok.text {
+		case "query", "mutation":
+			op.kind = p.tok.text
+		default:
+			return nil, fmt.Errorf("parse: unsupported operation %q", p.tok.text)
+		}
+		p.next()
+		if p.tok.kind == 'n' {
+			p.next() // operation name
+		}
+		if p.is('p', "(") {
+			p.skipVariableDefinitions()
+		}
+	}
+	op.selections = p.selectionSet()
+	return op, nil
+}
+
+// skipVariableDefinitions skips "($a: Int!, $b: [String!] = ...)"; the
+// executor only needs the variable values.
+func (p *parser) skipVariableDefinitions() {
+	depth := 0
+	for p.tok.kind != 0 {
+		if p.is('p', "(") {
+			depth++
+		} else if p.is('p', ")") {
+			depth--
+			if depth == 0 {
+				p.next()
+				return
+			}
+		}
+		p.next()
+	}
+	p.fail("unterminated variable definitions")
+}
+
+func (p *parser) selectionSet() []*field {
+	p.expect("{")
+	var fields []*field
+	for p.tok.kind != 0 && !p.is('p', "}") {
+		if p.tok.kind != 'n' {
+			p.fail("expected field name, got %q", p.tok.text)
+			return nil
+		}
+		f := &field{name: p.tok.text}
+		p.next()
+		if p.is('p', ":") {
+			p.next()
+			f.alias = f.name
+			f.name = p.tok.text
+			p.next()
+		}
+		if p.is('p', "(") {
+			p.next()
+			f.args = map[string]any{}
+			for p.tok.kind == 'n' {
+				name := p.tok.text
+				p.next()
+				p.expect(":")
+				f.args[name] = p.value()
+			}
+			p.expect(")")
+		}
+		if p.is('p', "{") {
+			f.selections = p.selectionSet()
+		}
+		fields = append(fields, f)
+	}
+	p.expect("}")
+	return fields
+}
+
+func (p *parser) value() any {
+	t := p.tok
+	switch t.kind {
+	case 'i':
+		p.next()
+		n, _ := strconv.ParseInt(t.text, 10, 64)
+		return float64(n)
+	case 'f':
+		p.next()
+		n, _ := strconv.ParseFloat(t.text, 64)
+		return n
+	case 's':
+		p.next()
+		return t.text
+	case 'n':
+		p.next()
+		switch t.text {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		}
+		return enumValue(t.text)
+	case 'p':
+		switch t.text {
+		case "$":
+			p.next()
+			name := p.tok.text
+			p.next()
+			return varRef(name)
+		case "[":
+			p.next()
+			list := []any{}
+			for p.tok.kind != 0 && !p.is('p', "]") {
+				list = append(list, p.value())
+			}
+			p.expect("]")
+			return list
+		case "{":
+			p.next()
+			obj := map[string]any{}
+			for p.tok.kind == 'n' {
+				name := p.tok.text
+				p.next()
+				p.expect(":")
+				obj[name] = p.value()
+			}
+			p.expect("}")
+			return obj
+		}
+	}
+	p.fail("unexpected %q", t.text)
+	return nil
+}
+
+// resolve substitutes variables into an argument value. Enum values become
+// plain strings.
+func resolve(v any, vars map[string]any) any {
+	switch v := v.(type) {
+	case varRef:
+		return vars[string(v)]
+	case enumValue:
+		return string(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = resolve(e, vars)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = resolve(e, vars)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,103 @@
+package fake
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		kind  string
+		roots []string // response keys of the root fields
+	}{
+		{"anonymous query", `{ me { id } }`, "query", []string{"me"}},
+		{"named query", `query GetMe { me { id username } }`, "query", []string{"me"}},
+		{"variables", `query ($id: Int!, $ids: [Int!] = [1, 2]) { books_by_pk(id: $id) { title } }`, "query", []string{"books_by_pk"}},
+		{"aliases", `query { started: user_books { id } statuses: user_books { id } }`, "query", []string{"started", "statuses"}},
+		{"mutation", `mutation ($id: Int!) { delete_list(id: $id) { id } }`, "mutation", []string{"delete_list"}},
+		{"comments and commas", "{\n  # the user\n  me { id, username },\n}", "query", []string{"me"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := parse(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if op.kind != tt.kind {
+				t.Errorf("kind %q, want %q", op.kind, tt.kind)
+			}
+			var roots []string
+			for _, f := range op.selections {
+				roots = append(roots, f.key())
+			}
+			if !reflect.DeepEqual(roots, tt.roots) {
+				t.Errorf("roots %q, want %q", roots, tt.roots)
+			}
+		})
+	}
+}
+
+func TestParseArguments(t *testing.T) {
+	src := `query ($userID: Int!) {
+		user_books(
+			where: {user_id: {_eq: $userID}, status_id: {_in: [2, 3]}, rating: {_gte: 4.5}, review: {_is_null: false}},
+			order_by: {updated_at: desc_nulls_last},
+			limit: 10,
+			title: "A \"quoted\"\ttitle é",
+			blurb: """raw "text" here"""
+		) { id }
+	}`
+	op, err := parse(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := resolve(op.selections[0].args, map[string]any{"userID": float64(7)})
+	want := map[string]any{
+		"where": map[string]any{
+			"user_id":   map[string]any{"_eq": float64(7)},
+			"status_id": map[string]any{"_in": []any{float64(2), float64(3)}},
+			"rating":    map[string]any{"_gte": 4.5},
+			"review":    map[string]any{"_is_null": false},
+		},
+		"order_by": map[string]any{"updated_at": "desc_nulls_last"},
+		"limit":    float64(10),
+		"title":    "A \"quoted\"\ttitle é",
+		"blurb":    `raw "text" here`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("args\n got %#v\nwant %#v", got, want)
+	}
+}
+
+func TestParseNestedSelections(t *testing.T) {
+	op, err := parse(`{ books(limit: 1) { title contributions { author { name } } } }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	books := op.selections[0]
+	if len(books.selections) != 2 || books.selections[1].name != "contributions" {
+		t.Fatalf("books selections %+v", books.selections)
+	}
+	author := books.selections[1].selections[0]
+	if author.name != "author" || len(author.selections) != 1 || author.selections[0].name != "name" {
+		t.Errorf("author field %+v", author)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, src := range []string{
+		``,
+		`subscription { me { id } }`,
+		`{ me { id }`,
+		`{ me(id: ) { id } }`,
+		`{ books(title: "unterminated) { id } }`,
+		`query ($id: Int! { me { id } }`,
+		`{ 42 }`,
+	} {
+		if _, err := parse(src); err == nil {
+			t.Errorf("parse(%q) succeeded, want an error", src)
+		}
+	}
+}
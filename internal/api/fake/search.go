@@ -0,0 +1,103 @@
+package fake
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// search mimics Hardcover's Typesense-backed search field. It matches the
+// query case-insensitively against names and returns one page of hits in
+// the same shape as the real API.
+func (s *store) search(args map[string]any) row {
+	query := strings.ToLower(strings.TrimSpace(fmt.Sprint(args["query"])))
+	queryType, _ := args["query_type"].(string)
+	perPage := int(toFloat(args["per_page"]))
+	if perPage <= 0 {
+		perPage = 25
+	}
+	page := max(int(toFloat(args["page"])), 1)
+
+	var docs []row
+	switch strings.ToLower(queryType) {
+	case "author":
+		for _, a := range s.tables["authors"] {
+			if contains(query, a["name"]) {
+				docs = append(docs, row{"id": fmt.Sprint(a["id"]), "name": a["name"], "slug": a["slug"], "books_count": a["books_count"]})
+			}
+		}
+	case "series":
+		for _, sr := range s.tables["series"] {
+			if contains(query, sr["name"], sr["author_name"]) {
+				docs = append(docs, row{"id": fmt.Sprint(sr["id"]), "name": sr["name"], "slug": sr["slug"],
+					"author_name": sr["author_name"], "books_count": sr["books_count"]})
+			}
+		}
+	case "list":
+		for _, l := range s.tables["lists"] {
+			if !equal(l["privacy_setting_id"], 1) || !contains(query, l["name"], l["description"]) {
+				continue
+			}
+			doc := row{"id": fmt.Sprint(l["id"]), "name": l["name"], "slug": l["slug"],
+				"books_count": l["books_count"], "likes_count": l["likes_count"]}
+			if u := s.byID("users", l["user_id"]); u != nil {
+				doc["user"] = row{"username": u["username"]}
+			}
+			docs = append(docs, doc)
+		}
+	case "user":
+		for _, u := range s.tables["users"] {
+			if contains(query, u["username"], u["name"]) {
+				docs = append(docs, row{"id": fmt.Sprint(u["id"]), "username": u["username"], "name": u["name"],
+					"followers_count": u["followers_count"]})
+			}
+		}
+	default:
+		for _, b := range s.tables["books"] {
+			var authors []any
+			for _, c := range asList(b["contributions"]) {
+				if a, ok := c.(row)["author"].(row); ok {
+					authors = append(authors, a["name"])
+				}
+			}
+			if !contains(query, append([]any{b["title"]}, authors...)...) {
+				continue
+			}
+			var genres []any
+			for _, t := range asList(b["taggings"]) {
+				if tag, ok := t.(row)["tag"].(row); ok && equal(tag["tag_category_id"], 1) {
+					genres = append(genres, tag["tag"])
+				}
+			}
+			docs = append(docs, row{
+				"id": fmt.Sprint(b["id"]), "title": b["title"], "slug": b["slug"],
+				"author_names": authors, "pages": b["pages"], "rating": b["rating"],
+				"users_count": b["users_count"], "release_year": b["release_year"],
+				"genres": genres, "description": b["description"],
+				"has_audiobook": b["has_audiobook"], "has_ebook": b["has_ebook"],
+			})
+		}
+		sort.SliceStable(docs, func(i, j int) bool {
+			return toFloat(docs[i]["users_count"]) > toFloat(docs[j]["users_count"])
+		})
+	}
+
+	found := len(docs)
+	start := min((page-1)*perPage, found)
+	end := min(start+perPage, found)
+	hits := make([]any, 0, end-start)
+	for _, d := range docs[start:end] {
+		hits = append(hits, row{"document": d})
+	}
+	return row{"results": row{"found": found, "page": page, "hits": hits}}
+}
+
+// contains reports whether any of the values contains query.
+func contains(query string, values ...any) bool {
+	for _, v := range values {
+		if str, ok := v.(string); ok && strings.Contains(strings.ToLower(str), query) {
+			return true
+		}
+	}
+	return false
+}
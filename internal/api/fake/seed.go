@@ -0,0 +1,371 @@
+package fake
+
+import (
+	"fmt"
+	"time"
+)
+
+type seedBook struct {
+	title, author, series string
+	seriesPos             float64
+	year, pages           int
+	rating                float64
+	users                 int
+	fiction               bool
+	audio, ebook          bool
+	genres, moods         []string
+	description           string
+}
+
+var seedBooks = []seedBook{
+	{title: "The Left Hand of Darkness", author: "Ursula K. Le Guin", series: "Hainish Cycle", seriesPos: 6, year: 1969, pages: 304, rating: 4.12, users: 18450, fiction: true, audio: true, ebook: true,
+		genres: []string{"Science Fiction", "Classics"}, moods: []string{"reflective", "challenging"},
+		description: "Genly Ai is an envoy sent to the ice world of Gethen, whose people can choose and change their gender, to persuade its nations to join an interplanetary union."},
+	{title: "The Dispossessed", author: "Ursula K. Le Guin", series: "Hainish Cycle", seriesPos: 5, year: 1974, pages: 387, rating: 4.21, users: 15230, fiction: true, ebook: true,
+		genres: []string{"Science Fiction", "Classics"}, moods: []string{"reflective", "hopeful"},
+		description: "Shevek, a physicist from the anarchist moon Anarres, travels to its capitalist twin planet Urras in search of the freedom to finish his work."},
+	{title: "A Wizard of Earthsea", author: "Ursula K. Le Guin", series: "Earthsea Cycle", seriesPos: 1, year: 1968, pages: 183, rating: 4.01, users: 24110, fiction: true, audio: true, ebook: true,
+		genres: []string{"Fantasy", "Classics"}, moods: []string{"adventurous", "mysterious"},
+		description: "Ged, a gifted young wizard, unleashes a terrible shadow upon the world and must hunt it across the archipelago of Earthsea."},
+	{title: "The Tombs of Atuan", author: "Ursula K. Le Guin", series: "Earthsea Cycle", seriesPos: 2, year: 1970, pages: 180, rating: 3.98, users: 12840, fiction: true, ebook: true,
+		genres: []string{"Fantasy", "Classics"}, moods: []string{"dark", "mysterious"},
+		description: "Tenar, priestess of the Nameless Ones, guards the labyrinth beneath the Tombs of Atuan until a wizard comes to steal its greatest treasure."},
+	{title: "The Farthest Shore", author: "Ursula K. Le Guin", series: "Earthsea Cycle", seriesPos: 3, year: 1972, pages: 259, rating: 3.97, users: 9870, fiction: true,
+		genres: []string{"Fantasy", "Classics"}, moods: []string{"adventurous", "reflective"},
+		description: "Magic is draining out of Earthsea, and Archmage Ged sets out with a young prince to find its source at the edge of the world."},
+	{title: "Piranesi", author: "Susanna Clarke", year: 2020, pages: 245, rating: 4.25, users: 41200, fiction: true, audio: true, ebook: true,
+		genres: []string{"Fantasy", "Mystery"}, moods: []string{"mysterious", "reflective"},
+		description: "Piranesi lives in the House, a world of endless halls and tides, and keeps careful journals of everything he discovers there."},
+	{title: "Jonathan Strange & Mr Norrell", author: "Susanna Clarke", year: 2004, pages: 782, rating: 3.86, users: 28300, fiction: true, audio: true, ebook: true,
+		genres: []string{"Fantasy", "Historical Fiction"}, moods: []string{"adventurous", "funny"},
+		description: "Two magicians revive English magic during the Napoleonic Wars, and their rivalry threatens to unleash forces neither can control."},
+	{title: "The Fellowship of the Ring", author: "J.R.R. Tolkien", series: "The Lord of the Rings", seriesPos: 1, year: 1954, pages: 423, rating: 4.38, users: 98210, fiction: true, audio: true, ebook: true,
+		genres: []string{"Fantasy", "Classics", "Adventure"}, moods: []string{"adventurous"},
+		description: "Frodo Baggins inherits a ring of terrible power and sets out from the Shire to destroy it."},
+	{title: "The Two Towers", author: "J.R.R. Tolkien", series: "The Lord of the Rings", seriesPos: 2, year: 1954, pages: 352, rating: 4.45, users: 81340, fiction: true, audio: true, ebook: true,
+		genres: []string{"Fantasy", "Classics", "Adventure"}, moods: []string{"adventurous", "tense"},
+		description: "The Fellowship is broken. Frodo and Sam press on towards Mordor while the others are drawn into war."},
+	{title: "The Return of the King", author: "J.R.R. Tolkien", series: "The Lord of the Rings", seriesPos: 3, year: 1955, pages: 416, rating: 4.53, users: 79020, fiction: true, audio: true, ebook: true,
+		genres: []string{"Fantasy", "Classics", "Adventure"}, moods: []string{"adventurous", "emotional"},
+		description: "The armies of the Dark Lord gather as Frodo and Sam make their final approach to Mount Doom."},
+	{title: "Project Hail Mary", author: "Andy Weir", year: 2021, pages: 476, rating: 4.52, users: 102400, fiction: true, audio: true, ebook: true,
+		genres: []string{"Science Fiction", "Thriller"}, moods: []string{"funny", "hopeful", "tense"},
+		description: "Ryland Grace wakes up alone on a spaceship with no memory of how he got there, and the fate of humanity depends on him."},
+	{title: "Klara and the Sun", author: "Kazuo Ishiguro", year: 2021, pages: 303, rating: 3.74, users: 38900, fiction: true, audio: true, ebook: true,
+		genres: []string{"Science Fiction", "Literary Fiction"}, moods: []string{"emotional", "reflective"},
+		description: "Klara, an Artificial Friend, watches the world from a store window and hopes to be chosen by a child."},
+	{title: "The Remains of the Day", author: "Kazuo Ishiguro", year: 1989, pages: 245, rating: 4.13, users: 27600, fiction: true, ebook: true,
+		genres: []string{"Literary Fiction", "Classics"}, moods: []string{"reflective", "sad"},
+		description: "Stevens, an aging English butler, takes a motoring trip and reflects on a lifetime of service."},
+	{title: "Braiding Sweetgrass", author: "Robin Wall Kimmerer", year: 2013, pages: 391, rating: 4.45, users: 33100, audio: true, ebook: true,
+		genres: []string{"Nature", "Science", "Memoir"}, moods: []string{"hopeful", "reflective"},
+		description: "A botanist and member of the Citizen Potawatomi Nation braids together Indigenous wisdom and scientific knowledge."},
+	{title: "The Body Keeps the Score", author: "Bessel van der Kolk", year: 2014, pages: 464, rating: 4.27, users: 45800, audio: true, ebook: true,
+		genres: []string{"Psychology", "Science"}, moods: []string{"informative", "challenging"},
+		description: "A psychiatrist explores how trauma reshapes the body and brain, and the paths to recovery."},
+	{title: "Sapiens", author: "Yuval Noah Harari", year: 2011, pages: 443, rating: 4.16, users: 87500, audio: true, ebook: true,
+		genres: []string{"History", "Anthropology"}, moods: []string{"informative"},
+		description: "A brief history of humankind, from the Stone Age to the twenty-first century."},
+	{title: "The Name of the Wind", author: "Patrick Rothfuss", series: "The Kingkiller Chronicle", seriesPos: 1, year: 2007, pages: 662, rating: 4.43, users: 71200, fiction: true, audio: true, ebook: true,
+		genres: []string{"Fantasy"}, moods: []string{"adventurous", "emotional"},
+		description: "Kvothe, a legendary hero now hiding as an innkeeper, tells the true story of his life."},
+	{title: "The Wise Man's Fear", author: "Patrick Rothfuss", series: "The Kingkiller Chronicle", seriesPos: 2, year: 2011, pages: 994, rating: 4.47, users: 52300, fiction: true, audio: true, ebook: true,
+		genres: []string{"Fantasy"}, moods: []string{"adventurous"},
+		description: "Kvothe continues his tale, leaving the University in search of answers about the Chandrian."},
+	{title: "Middlemarch", author: "George Eliot", year: 1871, pages: 880, rating: 3.98, users: 21900, fiction: true, ebook: true,
+		genres: []string{"Classics", "Historical Fiction"}, moods: []string{"reflective", "slow-paced"},
+		description: "The lives of the inhabitants of a provincial English town intertwine through marriage, ambition and reform."},
+	{title: "Station Eleven", author: "Emily St. John Mandel", year: 2014, pages: 333, rating: 4.05, users: 56700, fiction: true, audio: true, ebook: true,
+		genres: []string{"Science Fiction", "Literary Fiction"}, moods: []string{"emotional", "hopeful"},
+		description: "A traveling troupe of actors and musicians performs Shakespeare in the years after a devastating pandemic."},
+	{title: "The Overstory", author: "Richard Powers", year: 2018, pages: 502, rating: 4.09, users: 24500, fiction: true, audio: true, ebook: true,
+		genres: []string{"Literary Fiction", "Nature"}, moods: []string{"reflective", "emotional"},
+		description: "Nine strangers are drawn together by their connection to trees in a fight to save the last old-growth forests."},
+	{title: "Thinking, Fast and Slow", author: "Daniel Kahneman", year: 2011, pages: 499, rating: 4.04, users: 63400, audio: true, ebook: true,
+		genres: []string{"Psychology", "Economics"}, moods: []string{"informative", "challenging"},
+		description: "A tour of the two systems that drive the way we think: fast, intuitive and emotional, and slow, deliberate and logical."},
+}
+
+// library is the demo user's shelf: book index, status, rating, pages read.
+var library = []struct {
+	book     int
+	status   int
+	rating   float64
+	progress int
+	review   string
+	spoilers bool
+	daysAgo  int
+}{
+	{book: 0, status: 2, progress: 212, daysAgo: 12},
+	{book: 5, status: 2, progress: 96, daysAgo: 4},
+	{book: 14, status: 2, progress: 140, daysAgo: 20},
+	{book: 2, status: 3, rating: 4.5, daysAgo: 80, review: "A slim book that feels enormous. The idea that naming a thing gives you power over it has stayed with me for weeks."},
+	{book: 3, status: 3, rating: 4, daysAgo: 60},
+	{book: 7, status: 3, rating: 5, daysAgo: 150, review: "Re-read for the first time since school. The ||Mines of Moria|| chapters are still the best thing in the book.", spoilers: true},
+	{book: 8, status: 3, rating: 4.5, daysAgo: 120},
+	{book: 10, status: 3, rating: 5, daysAgo: 40, review: "Pure joy from start to finish. Rocky is the best character I've met this year."},
+	{book: 12, status: 3, rating: 4, daysAgo: 200},
+	{book: 16, status: 3, rating: 4.5, daysAgo: 240},
+	{book: 13, status: 3, rating: 5, daysAgo: 30},
+	{book: 1, status: 1, daysAgo: 9},
+	{book: 4, status: 1, daysAgo: 55},
+	{book: 9, status: 1, daysAgo: 118},
+	{book: 6, status: 1, daysAgo: 17},
+	{book: 19, status: 1, daysAgo: 3},
+	{book: 17, status: 4, progress: 310, daysAgo: 95},
+	{book: 18, status: 5, progress: 120, rating: 2.5, daysAgo: 300},
+}
+
+var seedUsers = []row{
+	{"id": 1, "username": "demo_reader", "name": "Demo Reader", "bio": "Reading my way through speculative fiction, one shelf at a time.",
+		"location": "Lisbon", "link": nil, "flair": nil, "followers_count": 42, "followed_users_count": 37, "pro": false,
+		"pronoun_personal": "they", "pronoun_possessive": "their", "image": nil, "created_at": "2023-02-14T09:30:00Z"},
+	{"id": 2, "username": "ink_and_paper", "name": "Ines", "followers_count": 310, "image": nil,
+		"pronoun_personal": "she", "pronoun_possessive": "her", "created_at": "2021-06-02T18:04:00Z"},
+	{"id": 3, "username": "night_owl_reads", "name": nil, "followers_count": 128, "image": nil,
+		"pronoun_personal": "they", "pronoun_possessive": "their", "created_at": "2022-10-30T23:41:00Z"},
+	{"id": 4, "username": "margin_notes", "name": "Tom", "followers_count": 87, "image": nil,
+		"pronoun_personal": "he", "pronoun_possessive": "his", "created_at": "2024-01-08T12:15:00Z"},
+}
+
+var otherReviews = []struct {
+	user, book int
+	rating     float64
+	likes      int
+	review     string
+}{
+	{2, 0, 5, 34, "Le Guin at her best. The journey across the Gobrin ice is one of the great passages in science fiction."},
+	{3, 0, 4, 12, "Slow to start, but the friendship at its heart is worth every page."},
+	{4, 5, 4.5, 21, "A strange, beautiful puzzle box. Go in knowing as little as possible."},
+	{2, 10, 5, 58, "I laughed, I cried, I learned some astrophysics. Fist my bump."},
+	{3, 16, 4, 9, "Gorgeous prose. I'm still waiting for book three like everyone else."},
+	{4, 14, 4, 15, "Essential reading, though heavy going at times."},
+}
+
+// seed fills st with the demo library. Dates are relative to now so the
+// library always looks recently active.
+func seed(st *store) {
+	now := time.Now()
+	day := func(daysAgo int) string { return now.AddDate(0, 0, -daysAgo).Format("2006-01-02") }
+	stamp := func(daysAgo int) string { return now.AddDate(0, 0, -daysAgo).UTC().Format(time.RFC3339) }
+
+	st.me = 1
+	for _, u := range seedUsers {
+		r := row{}
+		applyObject(r, u)
+		st.insert("users", r)
+	}
+
+	authors := map[string]row{}
+	series := map[string]row{}
+	tagID := 0
+	tags := map[string]row{}
+	tagging := func(name string, category int) row {
+		t, ok := tags[name]
+		if !ok {
+			tagID++
+			t = row{"id": tagID, "tag": name, "tag_category_id": category}
+			tags[name] = t
+		}
+		return row{"tag": t}
+	}
+
+	for i, b := range seedBooks {
+		a, ok := authors[b.author]
+		if !ok {
+			a = st.insert("authors", row{"name": b.author, "slug": slugify(b.author), "books_count": 0})
+			authors[b.author] = a
+		}
+		a["books_count"] = int(toFloat(a["books_count"])) + 1
+
+		var taggings []any
+		for _, g := range b.genres {
+			taggings = append(taggings, tagging(g, 1))
+		}
+		for _, m := range b.moods {
+			taggings = append(taggings, tagging(m, 5))
+		}
+		literaryType := 2
+		if b.fiction {
+			literaryType = 1
+		}
+		ratings := b.users / 3
+		book := st.insert("books", row{
+			"id": i + 1, "title": b.title, "subtitle": nil, "description": b.description,
+			"pages": b.pages, "rating": b.rating, "ratings_count": ratings, "reviews_count": ratings / 12,
+			"users_count": b.users, "release_year": b.year, "slug": slugify(b.title),
+			"audio_seconds": nil, "literary_type_id": literaryType, "image": nil,
+			"contributions": []any{row{"author": row{"id": a["id"], "name": a["name"], "slug": a["slug"]}}},
+			"taggings":      taggings,
+			"has_audiobook": b.audio, "has_ebook": b.ebook,
+		})
+		if b.audio {
+			book["audio_seconds"] = b.pages * 110
+		}
+
+		if b.series != "" {
+			s, ok := series[b.series]
+			if !ok {
+				s = st.insert("series", row{"name": b.series, "slug": slugify(b.series), "author_name": b.author, "books_count": 0})
+				series[b.series] = s
+			}
+			s["books_count"] = int(toFloat(s["books_count"])) + 1
+			st.insert("book_series", row{"book_id": book["id"], "series_id": s["id"], "position": b.seriesPos})
+		}
+	}
+
+	for _, l := range library {
+		b := seedBooks[l.book]
+		ub := st.insert("user_books", row{
+			"user_id": st.me, "book_id": l.book + 1, "status_id": l.status,
+			"rating": nil, "review": nil, "review_html": nil, "review_has_spoilers": l.spoilers,
+			"has_review": l.review != "", "date_added": day(l.daysAgo + 30), "read_count": 0,
+			"owned": l.book%3 == 0, "starred": l.rating == 5, "likes_count": 0,
+			"private_notes": nil, "privacy_setting_id": 1,
+			"created_at": stamp(l.daysAgo + 30), "updated_at": stamp(l.daysAgo),
+		})
+		if l.rating > 0 {
+			ub["rating"] = l.rating
+		}
+		if l.review != "" {
+			ub["review"] = l.review
+			ub["likes_count"] = 3
+		}
+		format := "paperback"
+		if l.book%4 == 1 && b.audio {
+			format = "audiobook"
+		} else if l.book%4 == 2 && b.ebook {
+			format = "ebook"
+		}
+		read := row{
+			"user_book_id": ub["id"], "started_at": nil, "finished_at": nil,
+			"progress_pages": nil, "progress_seconds": nil, "edition_id": nil,
+			"edition": row{"edition_format": format, "pages": b.pages},
+		}
+		switch l.status {
+		case 2, 4, 5:
+			read["started_at"] = day(l.daysAgo + 14)
+			read["progress_pages"] = l.progress
+			st.insert("user_book_reads", read)
+		case 3:
+			read["started_at"] = day(l.daysAgo + 21)
+			read["finished_at"] = day(l.daysAgo)
+			read["progress_pages"] = b.pages
+			ub["read_count"] = 1
+			st.insert("user_book_reads", read)
+		}
+	}
+
+	for _, r := range otherReviews {
+		st.insert("user_books", row{
+			"user_id": r.user, "book_id": r.book + 1, "status_id": 3, "rating": r.rating,
+			"review": r.review, "review_html": nil, "review_has_spoilers": false, "has_review": true,
+			"date_added": day(100), "read_count": 1, "owned": false, "starred": false,
+			"likes_count": r.likes, "privacy_setting_id": 1,
+			"created_at": stamp(r.likes), "updated_at": stamp(r.likes),
+		})
+	}
+
+	lists := []struct {
+		name, description string
+		books             []int
+		likes             int
+	}{
+		{"Comfort Re-reads", "Books I come back to when the world is too much.", []int{2, 7, 10, 12}, 14},
+		{"Le Guin Deep Dive", "Working through the Hainish Cycle and Earthsea in publication order.", []int{2, 3, 0, 4, 1}, 31},
+		{"Nonfiction That Changed My Mind", "", []int{13, 14, 15, 21}, 6},
+	}
+	for i, l := range lists {
+		var desc any
+		if l.description != "" {
+			desc = l.description
+		}
+		list := st.insert("lists", row{
+			"user_id": st.me, "name": l.name, "description": desc, "books_count": len(l.books),
+			"likes_count": l.likes, "public": true, "ranked": i == 1, "privacy_setting_id": 1,
+			"slug": slugify(l.name), "created_at": stamp(200 - i*40), "updated_at": stamp(10 + i*7),
+		})
+		for pos, b := range l.books {
+			st.insert("list_books", row{
+				"list_id": list["id"], "book_id": b + 1, "position": pos + 1,
+				"date_added": stamp(200 - i*40 - pos),
+			})
+		}
+	}
+
+	journals := []struct {
+		book    int
+		event   string
+		entry   string
+		meta    row
+		daysAgo int
+	}{
+		{0, "status_2", "", row{"status_id": 2}, 26},
+		{0, "note", "The Gethenian calendar resets every year to Year One. I love how disorienting that is.", row{}, 21},
+		{0, "quote", "Light is the left hand of darkness, and darkness the right hand of light.", row{"page": 233}, 6},
+		{0, "progress_updated", "Crossing the ice now. Can't put it down.", row{"page": 212, "pages": 304, "percent": 69.7}, 1},
+		{5, "status_2", "", row{"status_id": 2}, 18},
+		{5, "quote", "The Beauty of the House is immeasurable; its Kindness infinite.", row{"page": 5}, 15},
+		{5, "progress_updated", "", row{"page": 96, "pages": 245, "percent": 39.2}, 0},
+		{10, "status_3", "Finished in three sittings.", row{"status_id": 3}, 40},
+		{10, "note", "Recommend to anyone who thinks they don't like science fiction.", row{}, 39},
+		{14, "progress_updated", "Chapter on yoga and trauma was a revelation.", row{"page": 140, "pages": 464, "percent": 30.2}, 20},
+		{17, "status_4", "Pausing this until the holidays, it needs real attention.", row{"status_id": 4}, 95},
+		{18, "status_5", "Not the right time for this one.", row{"status_id": 5}, 300},
+	}
+	for _, j := range journals {
+		var entry any
+		if j.entry != "" {
+			entry = j.entry
+		}
+		st.insert("reading_journals", row{
+			"user_id": st.me, "book_id": j.book + 1, "edition_id": nil, "event": j.event,
+			"entry": entry, "metadata": j.meta, "privacy_setting_id": 1, "likes_count": 0,
+			"action_at": stamp(j.daysAgo), "created_at": stamp(j.daysAgo), "updated_at": stamp(j.daysAgo),
+		})
+	}
+
+	year := now.Year()
+	read := 0
+	for _, l := range library {
+		if l.status == 3 && now.AddDate(0, 0, -l.daysAgo).Year() == year {
+			read++
+		}
+	}
+	st.insert("goals", row{
+		"user_id": st.me, "goal": 24, "metric": "book", "progress": read,
+		"start_date": fmt.Sprintf("%d-01-01", year), "end_date": fmt.Sprintf("%d-12-31", year),
+		"state": "active", "description": fmt.Sprintf("Read 24 books in %d", year),
+		"archived": false, "completed_at": nil, "privacy_setting_id": 1,
+	})
+
+	activities := []struct {
+		user, book int
+		event      string
+		data       row
+		daysAgo    int
+	}{
+		{1, 0, "UserBookActivity", row{"userBook": row{"statusId": 2}}, 26},
+		{1, 10, "UserBookActivity", row{"userBook": row{"rating": "5.0", "review": "Pure joy"}}, 40},
+		{1, -1, "GoalActivity", row{"goal": row{"goal": 24, "metric": "book", "progress": read, "description": fmt.Sprintf("Read 24 books in %d", year)}}, 60},
+		{2, 5, "UserBookActivity", row{"userBook": row{"statusId": 3}}, 1},
+		{3, 16, "UserBookActivity", row{"userBook": row{"rating": "4.0"}}, 2},
+		{4, 20, "ListActivity", row{"list": row{"name": "Trees, trees, trees", "booksCount": 7}}, 3},
+		{2, 11, "UserBookActivity", row{"userBook": row{"statusId": 2}}, 5},
+		{4, 8, "UserBookActivity", row{"userBook": row{"review": "Better than the films."}}, 8},
+	}
+	for _, a := range activities {
+		var bookID any
+		if a.book >= 0 {
+			bookID = a.book + 1
+		}
+		st.insert("activities", row{
+			"user_id": a.user, "book_id": bookID, "event": a.event, "data": a.data,
+			"likes_count": 0, "privacy_setting_id": 1, "created_at": stamp(a.daysAgo),
+		})
+	}
+
+	if u := st.byID("users", st.me); u != nil {
+		u["books_count"] = len(library)
+	}
+}
@@ -0,0 +1,380 @@
+// Package fake is an in-process stand-in for the Hardcover GraphQL API. It
+// implements the subset of queries and mutations the app sends against an
+// in-memory store seeded with a demo library. Mutations persist for the
+// lifetime of the server.
+package fake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Token is the API token the demo app signs in with. Any non-empty token is
+// accepted.
+const Token = "Bearer demo"
+
+// Server serves the fake GraphQL API over HTTP.
+type Server struct {
+	store *store
+	http  *http.Server
+	url   string
+}
+
+// NewServer creates a server seeded with the demo library.
+func NewServer() *Server {
+	s := &Server{store: newStore()}
+	seed(s.store)
+	return s
+}
+
+// Start listens on a random local port and serves in the background. It
+// returns the GraphQL endpoint URL.
+func (s *Server) Start() (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("fake server: %w", err)
+	}
+	s.http = &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	s.url = "http://" + ln.Addr().String() + "/v1/graphql"
+	go func() { _ = s.http.Serve(ln) }()
+	return s.url, nil
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	if s.http == nil {
+		return nil
+	}
+	return s.http.Close()
+}
+
+type gqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type gqlError struct {
+	Message    string         `json:"message"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// ServeHTTP handles a GraphQL POST request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if strings.TrimSpace(r.Header.Get("Authorization")) == "" {
+		writeJSON(w, map[string]any{"errors": []gqlError{{
+			Message:    "Missing Authorization header",
+			Extensions: map[string]any{"code": "invalid-headers"},
+		}}})
+		return
+	}
+
+	var req gqlRequest
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	op, err := parse(req.Query)
+	if err != nil {
+		writeJSON(w, map[string]any{"errors": []gqlError{{
+			Message:    err.Error(),
+			Extensions: map[string]any{"code": "validation-failed"},
+		}}})
+		return
+	}
+
+	s.store.mu.Lock()
+	data, err := s.store.execute(op, req.Variables)
+	s.store.mu.Unlock()
+	if err != nil {
+		writeJSON(w, map[string]any{"errors": []gqlError{{
+			Message:    err.Error(),
+			Extensions: map[string]any{"code": "validation-failed"},
+		}}})
+		return
+	}
+	writeJSON(w, map[string]any{"data": data})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// execute runs every root field of op.
+func (s *store) execute(op *operation, vars map[string]any) (map[string]any, error) {
+	data := map[string]any{}
+	for _, f := range op.selections {
+		args, _ := resolve(f.args, vars).(map[string]any)
+		var (
+			v   any
+			err error
+		)
+		if op.kind == "mutation" {
+			v, err = s.mutate(f.name, args)
+			if err == nil {
+				v = s.selectFields("", v, f.selections, vars)
+			}
+		} else {
+			v, err = s.resolveQuery(f, args, vars)
+		}
+		if err != nil {
+			return nil, err
+		}
+		data[f.key()] = v
+	}
+	return data, nil
+}
+
+func (s *store) resolveQuery(f *field, args, vars map[string]any) (any, error) {
+	switch f.name {
+	case "__typename":
+		return "query_root", nil
+	case "me":
+		return s.selectFields("users", []row{s.byID("users", s.me)}, f.selections, vars), nil
+	case "search":
+		return s.selectFields("", s.search(args), f.selections, vars), nil
+	case "activity_foryou_feed":
+		rows := s.query("activities", s.tables["activities"], map[string]any{
+			"where":    map[string]any{"user_id": map[string]any{"_neq": s.me}},
+			"order_by": map[string]any{"created_at": "desc"},
+			"limit":    args["limit"],
+			"offset":   args["offset"],
+		})
+		return s.selectFields("activities", rows, f.selections, vars), nil
+	}
+
+	if table, ok := strings.CutSuffix(f.name, "_by_pk"); ok && s.tables[table] != nil {
+		r := s.byID(table, args["id"])
+		if r == nil {
+			return nil, nil
+		}
+		return s.selectFields(table, r, f.selections, vars), nil
+	}
+	if table, ok := strings.CutSuffix(f.name, "_aggregate"); ok && s.tables[table] != nil {
+		rows := s.query(table, s.tables[table], args)
+		return s.selectFields("", aggregate(rows), f.selections, vars), nil
+	}
+	if _, ok := s.tables[f.name]; ok {
+		rows := s.query(f.name, s.tables[f.name], args)
+		return s.selectFields(f.name, rows, f.selections, vars), nil
+	}
+	return nil, fmt.Errorf("field '%s' not found in type: 'query_root'", f.name)
+}
+
+var errNotFound = errors.New("not found")
+
+// mutate applies a mutation and returns its payload object.
+func (s *store) mutate(name string, args map[string]any) (row, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	today := time.Now().Format("2006-01-02")
+	obj, _ := args["object"].(map[string]any)
+
+	switch name {
+	case "insert_user_book":
+		for _, ub := range s.tables["user_books"] {
+			if equal(ub["user_id"], s.me) && equal(ub["book_id"], obj["book_id"]) {
+				return row{"id": nil, "error": "Book is already in your library"}, nil
+			}
+		}
+		if s.byID("books", obj["book_id"]) == nil {
+			return row{"id": nil, "error": "Book not found"}, nil
+		}
+		ub := s.insert("user_books", row{
+			"user_id": s.me, "rating": nil, "review": nil, "review_html": nil,
+			"review_has_spoilers": false, "has_review": false, "date_added": today,
+			"read_count": 0, "owned": false, "starred": false, "likes_count": 0,
+			"private_notes": nil, "privacy_setting_id": 1,
+			"created_at": now, "updated_at": now,
+		})
+		applyObject(ub, obj)
+		s.adjustUsersCount(ub["book_id"], 1)
+		return row{"id": ub["id"], "error": nil}, nil
+
+	case "update_user_book":
+		ub := s.byID("user_books", args["id"])
+		if ub == nil || !equal(ub["user_id"], s.me) {
+			return row{"id": nil, "error": "User book not found"}, nil
+		}
+		if review, ok := obj["review_raw"]; ok {
+			delete(obj, "review_raw")
+			ub["review"] = review
+			ub["review_html"] = nil // unless sent with it
+			ub["has_review"] = review != nil && review != ""
+		}
+		applyObject(ub, obj)
+		ub["updated_at"] = now
+		if equal(ub["status_id"], 3) && toFloat(ub["read_count"]) == 0 {
+			ub["read_count"] = 1
+		}
+		return row{"id": ub["id"], "error": nil}, nil
+
+	case "delete_user_book":
+		ub := s.byID("user_books", args["id"])
+		if ub == nil || !equal(ub["user_id"], s.me) {
+			return nil, fmt.Errorf("user book %v: %w", args["id"], errNotFound)
+		}
+		s.deleteWhere("user_books", func(r row) bool { return equal(r["id"], ub["id"]) })
+		s.deleteWhere("user_book_reads", func(r row) bool { return equal(r["user_book_id"], ub["id"]) })
+		s.adjustUsersCount(ub["book_id"], -1)
+		return row{"id": ub["id"]}, nil
+
+	case "insert_user_book_read":
+		ub := s.byID("user_books", args["user_book_id"])
+		if ub == nil {
+			return row{"id": nil, "error": "User book not found"}, nil
+		}
+		read := s.insert("user_book_reads", row{
+			"user_book_id": ub["id"], "started_at": nil, "finished_at": nil,
+			"progress_pages": nil, "progress_seconds": nil, "edition_id": nil,
+			"edition": row{"edition_format": "paperback", "pages": s.byID("books", ub["book_id"])["pages"]},
+		})
+		fields, _ := args["user_book_read"].(map[string]any)
+		applyObject(read, fields)
+		ub["updated_at"] = now
+		return row{"id": read["id"], "error": nil}, nil
+
+	case "update_user_book_read":
+		read := s.byID("user_book_reads", args["id"])
+		if read == nil {
+			return row{"id": nil, "error": "Read not found"}, nil
+		}
+		applyObject(read, obj)
+		if ub := s.byID("user_books", read["user_book_id"]); ub != nil {
+			ub["updated_at"] = now
+		}
+		return row{"id": read["id"], "error": nil}, nil
+
+	case "insert_list":
+		l := s.insert("lists", row{
+			"user_id": s.me, "description": nil, "books_count": 0, "likes_count": 0,
+			"ranked": false, "privacy_setting_id": 1, "created_at": now, "updated_at": now,
+		})
+		applyObject(l, obj)
+		l["slug"] = slugify(fmt.Sprint(l["name"]))
+		l["public"] = equal(l["privacy_setting_id"], 1)
+		return row{"id": l["id"], "errors": nil}, nil
+
+	case "update_list":
+		l := s.byID("lists", args["id"])
+		if l == nil || !equal(l["user_id"], s.me) {
+			return row{"id": nil, "errors": []string{"List not found"}}, nil
+		}
+		applyObject(l, obj)
+		l["public"] = equal(l["privacy_setting_id"], 1)
+		l["updated_at"] = now
+		return row{"id": l["id"], "errors": nil}, nil
+
+	case "delete_list":
+		l := s.byID("lists", args["id"])
+		if l == nil || !equal(l["user_id"], s.me) {
+			return row{"success": false}, nil
+		}
+		s.deleteWhere("lists", func(r row) bool { return equal(r["id"], l["id"]) })
+		s.deleteWhere("list_books", func(r row) bool { return equal(r["list_id"], l["id"]) })
+		return row{"success": true}, nil
+
+	case "insert_list_book":
+		l := s.byID("lists", obj["list_id"])
+		if l == nil {
+			return nil, fmt.Errorf("list %v: %w", obj["list_id"], errNotFound)
+		}
+		lb := s.insert("list_books", row{"date_added": now})
+		applyObject(lb, obj)
+		l["books_count"] = int(toFloat(l["books_count"])) + 1
+		l["updated_at"] = now
+		return row{"id": lb["id"]}, nil
+
+	case "delete_list_book":
+		lb := s.byID("list_books", args["id"])
+		if lb == nil {
+			return nil, fmt.Errorf("list book %v: %w", args["id"], errNotFound)
+		}
+		s.deleteWhere("list_books", func(r row) bool { return equal(r["id"], lb["id"]) })
+		if l := s.byID("lists", lb["list_id"]); l != nil {
+			l["books_count"] = max(int(toFloat(l["books_count"]))-1, 0)
+			l["updated_at"] = now
+		}
+		return row{"id": lb["id"]}, nil
+
+	case "insert_reading_journal":
+		j := s.insert("reading_journals", row{
+			"user_id": s.me, "entry": nil, "edition_id": nil, "metadata": row{},
+			"privacy_setting_id": 1, "likes_count": 0,
+			"action_at": now, "created_at": now, "updated_at": now,
+		})
+		delete(obj, "tags")
+		applyObject(j, obj)
+		if m, ok := j["metadata"].(string); ok {
+			// jsonb variables may arrive as an encoded string.
+			var decoded any
+			if json.Unmarshal([]byte(m), &decoded) == nil {
+				j["metadata"] = decoded
+			}
+		}
+		return row{"id": j["id"], "errors": nil}, nil
+
+	case "update_reading_journal":
+		j := s.byID("reading_journals", args["id"])
+		if j == nil || !equal(j["user_id"], s.me) {
+			return row{"id": nil, "errors": []string{"Journal entry not found"}}, nil
+		}
+		applyObject(j, obj)
+		j["updated_at"] = now
+		return row{"id": j["id"], "errors": nil}, nil
+
+	case "delete_reading_journal":
+		j := s.byID("reading_journals", args["id"])
+		if j == nil || !equal(j["user_id"], s.me) {
+			return nil, fmt.Errorf("journal entry %v: %w", args["id"], errNotFound)
+		}
+		s.deleteWhere("reading_journals", func(r row) bool { return equal(r["id"], j["id"]) })
+		return row{"id": j["id"]}, nil
+
+	case "update_user":
+		u := s.byID("users", s.me)
+		fields, _ := args["user"].(map[string]any)
+		applyObject(u, fields)
+		return row{"id": u["id"], "errors": nil}, nil
+	}
+	return nil, fmt.Errorf("field '%s' not found in type: 'mutation_root'", name)
+}
+
+func (s *store) adjustUsersCount(bookID any, delta int) {
+	if b := s.byID("books", bookID); b != nil {
+		b["users_count"] = max(int(toFloat(b["users_count"]))+delta, 0)
+	}
+}
+
+// applyObject copies mutation input fields onto r.
+func applyObject(r row, obj map[string]any) {
+	for k, v := range obj {
+		r[k] = v
+	}
+}
+
+func slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
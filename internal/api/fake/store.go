@@ -0,0 +1,449 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// row is a database row, or any JSON object nested inside one.
+type row = map[string]any
+
+// relation describes how a field on one table joins to another table.
+type relation struct {
+	table  string
+	local  string // column on this row
+	remote string // column on the related rows
+	many   bool
+}
+
+var relations = map[string]map[string]relation{
+	"user_books": {
+		"book":            {table: "books", local: "book_id", remote: "id"},
+		"user":            {table: "users", local: "user_id", remote: "id"},
+		"user_book_reads": {table: "user_book_reads", local: "id", remote: "user_book_id", many: true},
+	},
+	"user_book_reads": {
+		"user_book": {table: "user_books", local: "user_book_id", remote: "id"},
+	},
+	"books": {
+		"user_books":  {table: "user_books", local: "id", remote: "book_id", many: true},
+		"book_series": {table: "book_series", local: "id", remote: "book_id", many: true},
+	},
+	"book_series": {
+		"book":   {table: "books", local: "book_id", remote: "id"},
+		"series": {table: "series", local: "series_id", remote: "id"},
+	},
+	"series": {
+		"book_series": {table: "book_series", local: "id", remote: "series_id", many: true},
+	},
+	"lists": {
+		"user":       {table: "users", local: "user_id", remote: "id"},
+		"list_books": {table: "list_books", local: "id", remote: "list_id", many: true},
+	},
+	"list_books": {
+		"book": {table: "books", local: "book_id", remote: "id"},
+		"list": {table: "lists", local: "list_id", remote: "id"},
+	},
+	"reading_journals": {
+		"book": {table: "books", local: "book_id", remote: "id"},
+		"user": {table: "users", local: "user_id", remote: "id"},
+	},
+	"activities": {
+		"book": {table: "books", local: "book_id", remote: "id"},
+		"user": {table: "users", local: "user_id", remote: "id"},
+	},
+	"goals": {
+		"user": {table: "users", local: "user_id", remote: "id"},
+	},
+	"users": {
+		"user_books": {table: "user_books", local: "id", remote: "user_id", many: true},
+		"activities": {table: "activities", local: "id", remote: "user_id", many: true},
+	},
+}
+
+// store is the in-memory database behind the fake server.
+type store struct {
+	mu     sync.Mutex
+	tables map[string][]row
+	nextID map[string]int
+	me     int // id of the signed-in user
+}
+
+func newStore() *store {
+	return &store{tables: map[string][]row{}, nextID: map[string]int{}}
+}
+
+// insert adds r to table, assigning the next id if r has none.
+func (s *store) insert(table string, r row) row {
+	id, ok := r["id"]
+	if !ok {
+		s.nextID[table]++
+		id = s.nextID[table]
+		r["id"] = id
+	} else if n := int(toFloat(id)); n > s.nextID[table] {
+		s.nextID[table] = n
+	}
+	s.tables[table] = append(s.tables[table], r)
+	return r
+}
+
+func (s *store) byID(table string, id any) row {
+	for _, r := range s.tables[table] {
+		if equal(r["id"], id) {
+			return r
+		}
+	}
+	return nil
+}
+
+func (s *store) deleteWhere(table string, match func(row) bool) int {
+	kept := s.tables[table][:0]
+	n := 0
+	for _, r := range s.tables[table] {
+		if match(r) {
+			n++
+			continue
+		}
+		kept = append(kept, r)
+	}
+	s.tables[table] = kept
+	return n
+}
+
+// related returns the rows joined to r through rel.
+func (s *store) related(r row, rel relation) []row {
+	var out []row
+	for _, other := range s.tables[rel.table] {
+		if equal(other[rel.remote], r[rel.local]) {
+			out = append(out, other)
+			if !rel.many {
+				break
+			}
+		}
+	}
+	return out
+}
+
+// query applies Hasura list arguments (where, order_by, offset, limit) to rows.
+func (s *store) query(table string, rows []row, args map[string]any) []row {
+	var out []row
+	where, _ := args["where"].(map[string]any)
+	for _, r := range rows {
+		if where == nil || s.matches(table, r, where) {
+			out = append(out, r)
+		}
+	}
+	if ob := args["order_by"]; ob != nil {
+		sortRows(out, ob)
+	}
+	if off, ok := args["offset"]; ok && off != nil {
+		n := int(toFloat(off))
+		if n >= len(out) {
+			return nil
+		}
+		out = out[n:]
+	}
+	if lim, ok := args["limit"]; ok && lim != nil {
+		// The app passes limit 0 when it wants every row.
+		if n := int(toFloat(lim)); n > 0 && n < len(out) {
+			out = out[:n]
+		}
+	}
+	return out
+}
+
+// matches evaluates a Hasura boolean expression against r.
+func (s *store) matches(table string, r row, cond map[string]any) bool {
+	for key, v := range cond {
+		switch key {
+		case "_and":
+			for _, c := range asList(v) {
+				if m, ok := c.(map[string]any); ok && !s.matches(table, r, m) {
+					return false
+				}
+			}
+			continue
+		case "_or":
+			matched := false
+			for _, c := range asList(v) {
+				if m, ok := c.(map[string]any); ok && s.matches(table, r, m) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+			continue
+		case "_not":
+			if m, ok := v.(map[string]any); ok && s.matches(table, r, m) {
+				return false
+			}
+			continue
+		}
+
+		ops, _ := v.(map[string]any)
+		if rel, ok := relations[table][key]; ok {
+			found := false
+			for _, other := range s.related(r, rel) {
+				if s.matches(rel.table, other, ops) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+			continue
+		}
+		for op, arg := range ops {
+			if !compare(r[key], op, arg) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func compare(val any, op string, arg any) bool {
+	switch op {
+	case "_eq":
+		return equal(val, arg)
+	case "_neq":
+		return !equal(val, arg)
+	case "_gt":
+		return val != nil && less(arg, val)
+	case "_gte":
+		return val != nil && !less(val, arg)
+	case "_lt":
+		return val != nil && less(val, arg)
+	case "_lte":
+		return val != nil && !less(arg, val)
+	case "_in", "_nin":
+		in := false
+		for _, a := range asList(arg) {
+			if equal(val, a) {
+				in = true
+				break
+			}
+		}
+		return in == (op == "_in")
+	case "_is_null":
+		isNull, _ := arg.(bool)
+		return (val == nil) == isNull
+	case "_like", "_ilike", "_nlike", "_nilike":
+		str, ok := val.(string)
+		pattern, _ := arg.(string)
+		if !ok {
+			return false
+		}
+		re := likePattern(pattern, op == "_ilike" || op == "_nilike")
+		return re.MatchString(str) == (op == "_like" || op == "_ilike")
+	}
+	return false
+}
+
+// likePattern converts a SQL LIKE pattern to a regular expression.
+func likePattern(pattern string, fold bool) *regexp.Regexp {
+	var b strings.Builder
+	if fold {
+		b.WriteString("(?is)")
+	} else {
+		b.WriteString("(?s)")
+	}
+	b.WriteString("^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			// Like Postgres, a backslash matches the next character as is.
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// sortRows sorts by an order_by argument: an object of column directions
+// or a list of such objects.
+func sortRows(rows []row, orderBy any) {
+	type key struct {
+		col  string
+		desc bool
+	}
+	var keys []key
+	for _, o := range asList(orderBy) {
+		m, _ := o.(map[string]any)
+		cols := make([]string, 0, len(m))
+		for c := range m {
+			cols = append(cols, c)
+		}
+		sort.Strings(cols)
+		for _, c := range cols {
+			dir, _ := m[c].(string)
+			keys = append(keys, key{col: c, desc: strings.HasPrefix(dir, "desc")})
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, k := range keys {
+			a, b := rows[i][k.col], rows[j][k.col]
+			if equal(a, b) {
+				continue
+			}
+			// Nulls sort last in both directions, as Hasura does by default
+			// for ascending order.
+			if a == nil {
+				return false
+			}
+			if b == nil {
+				return true
+			}
+			if k.desc {
+				return less(b, a)
+			}
+			return less(a, b)
+		}
+		return false
+	})
+}
+
+// aggregate computes count and the numeric column aggregates for rows.
+func aggregate(rows []row) row {
+	sum, minV, maxV, n := row{}, row{}, row{}, map[string]int{}
+	for _, r := range rows {
+		for col, v := range r {
+			if !isNumber(v) {
+				continue
+			}
+			f := toFloat(v)
+			sum[col] = toFloat(sum[col]) + f
+			if _, ok := minV[col]; !ok || f < toFloat(minV[col]) {
+				minV[col] = f
+			}
+			if _, ok := maxV[col]; !ok || f > toFloat(maxV[col]) {
+				maxV[col] = f
+			}
+			n[col]++
+		}
+	}
+	avg := row{}
+	for col, total := range sum {
+		avg[col] = toFloat(total) / float64(n[col])
+	}
+	return row{"aggregate": row{
+		"count": len(rows),
+		"sum":   sum,
+		"avg":   avg,
+		"min":   minV,
+		"max":   maxV,
+	}}
+}
+
+// selectFields shapes v to the requested selection set. Rows of table
+// resolve relation fields; nested JSON objects are selected as-is.
+func (s *store) selectFields(table string, v any, sel []*field, vars map[string]any) any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []row:
+		out := make([]any, len(v))
+		for i, r := range v {
+			out[i] = s.selectFields(table, r, sel, vars)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = s.selectFields(table, e, sel, vars)
+		}
+		return out
+	case row:
+		out := make(row, len(sel))
+		for _, f := range sel {
+			if f.name == "__typename" {
+				out[f.key()] = table
+				continue
+			}
+			if rel, ok := relations[table][f.name]; ok {
+				rows := s.related(v, rel)
+				if rel.many {
+					args := resolve(f.args, vars).(map[string]any)
+					out[f.key()] = s.selectFields(rel.table, s.query(rel.table, rows, args), f.selections, vars)
+				} else if len(rows) > 0 {
+					out[f.key()] = s.selectFields(rel.table, rows[0], f.selections, vars)
+				} else {
+					out[f.key()] = nil
+				}
+				continue
+			}
+			val := v[f.name]
+			if f.selections != nil {
+				out[f.key()] = s.selectFields("", val, f.selections, vars)
+			} else {
+				out[f.key()] = val
+			}
+		}
+		return out
+	}
+	return v
+}
+
+func asList(v any) []any {
+	if l, ok := v.([]any); ok {
+		return l
+	}
+	if v == nil {
+		return nil
+	}
+	return []any{v}
+}
+
+func isNumber(v any) bool {
+	switch v.(type) {
+	case int, int64, float64, json.Number:
+		return true
+	}
+	return false
+}
+
+func toFloat(v any) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	case json.Number:
+		f, _ := n.Float64()
+		return f
+	}
+	return 0
+}
+
+func equal(a, b any) bool {
+	if isNumber(a) && isNumber(b) {
+		return toFloat(a) == toFloat(b)
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b) && (a == nil) == (b == nil)
+}
+
+func less(a, b any) bool {
+	if isNumber(a) && isNumber(b) {
+		return toFloat(a) < toFloat(b)
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
@@ -0,0 +1,227 @@
+package fake
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testStore is a small library: three books, two of them in a series, two
+// lists and the shelves of two users.
+func testStore() *store {
+	s := newStore()
+	s.insert("books", row{"title": "Dune", "pages": 412, "rating": 4.3})
+	s.insert("books", row{"title": "Dune Messiah", "pages": 256, "rating": 3.9})
+	s.insert("books", row{"title": "Piranesi", "pages": 245, "rating": nil})
+	s.insert("lists", row{"user_id": 1, "name": "Desert planets"})
+	s.insert("lists", row{"user_id": 2, "name": "Labyrinths"})
+	s.insert("list_books", row{"list_id": 1, "book_id": 1, "position": 1})
+	s.insert("list_books", row{"list_id": 1, "book_id": 2, "position": 2})
+	s.insert("list_books", row{"list_id": 2, "book_id": 3, "position": 1})
+	s.insert("series", row{"name": "Dune"})
+	s.insert("book_series", row{"book_id": 1, "series_id": 1, "position": 1})
+	s.insert("book_series", row{"book_id": 2, "series_id": 1, "position": 2})
+	s.insert("user_books", row{"user_id": 1, "book_id": 1, "status_id": 3, "rating": 5.0, "updated_at": "2024-03-01"})
+	s.insert("user_books", row{"user_id": 1, "book_id": 2, "status_id": 2, "rating": nil, "updated_at": "2024-05-01"})
+	s.insert("user_books", row{"user_id": 1, "book_id": 3, "status_id": 1, "rating": 4.0, "updated_at": "2024-04-01"})
+	s.insert("user_books", row{"user_id": 2, "book_id": 3, "status_id": 3, "rating": 3.0, "updated_at": "2024-01-01"})
+	return s
+}
+
+// ids returns the id column of rows.
+func ids(rows []row) []int {
+	out := []int{}
+	for _, r := range rows {
+		out = append(out, int(toFloat(r["id"])))
+	}
+	return out
+}
+
+// obj is shorthand for a parsed GraphQL object argument.
+type obj = map[string]any
+
+func TestStoreQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		table string
+		args  obj
+		want  []int
+	}{
+		{"everything", "user_books", obj{}, []int{1, 2, 3, 4}},
+		{"_eq", "user_books", obj{"where": obj{"user_id": obj{"_eq": 1.0}}}, []int{1, 2, 3}},
+		{"_neq", "user_books", obj{"where": obj{"user_id": obj{"_neq": 1.0}}}, []int{4}},
+		{"_in", "user_books", obj{"where": obj{"status_id": obj{"_in": []any{2.0, 3.0}}}}, []int{1, 2, 4}},
+		{"_nin", "user_books", obj{"where": obj{"status_id": obj{"_nin": []any{2.0, 3.0}}}}, []int{3}},
+		{"_gt", "books", obj{"where": obj{"pages": obj{"_gt": 256.0}}}, []int{1}},
+		{"_gte", "books", obj{"where": obj{"pages": obj{"_gte": 256.0}}}, []int{1, 2}},
+		{"_lt", "books", obj{"where": obj{"pages": obj{"_lt": 256.0}}}, []int{3}},
+		{"_lte and null", "books", obj{"where": obj{"rating": obj{"_lte": 4.0}}}, []int{2}},
+		{"_is_null", "user_books", obj{"where": obj{"rating": obj{"_is_null": true}}}, []int{2}},
+		{"_is_null false", "user_books", obj{"where": obj{"rating": obj{"_is_null": false}}}, []int{1, 3, 4}},
+		{"_ilike", "books", obj{"where": obj{"title": obj{"_ilike": "%dune%"}}}, []int{1, 2}},
+		{"_like is case sensitive", "books", obj{"where": obj{"title": obj{"_like": "%dune%"}}}, []int{}},
+		{"_like single character", "books", obj{"where": obj{"title": obj{"_like": "D_ne"}}}, []int{1}},
+		{"escaped wildcards", "books", obj{"where": obj{"title": obj{"_like": `Dune\_Messiah`}}}, []int{}},
+		{"escaped character", "books", obj{"where": obj{"title": obj{"_ilike": `dune\ m%`}}}, []int{2}},
+		{"escaped backslash", "books", obj{"where": obj{"title": obj{"_ilike": `%\\%`}}}, []int{}},
+		{"_nilike", "books", obj{"where": obj{"title": obj{"_nilike": "dune%"}}}, []int{3}},
+		{"several columns", "user_books", obj{"where": obj{"user_id": obj{"_eq": 1.0}, "status_id": obj{"_eq": 3.0}}}, []int{1}},
+		{"_and", "user_books", obj{"where": obj{"_and": []any{
+			obj{"user_id": obj{"_eq": 1.0}},
+			obj{"rating": obj{"_gte": 4.0}},
+		}}}, []int{1, 3}},
+		{"_or", "user_books", obj{"where": obj{"_or": []any{
+			obj{"status_id": obj{"_eq": 2.0}},
+			obj{"user_id": obj{"_eq": 2.0}},
+		}}}, []int{2, 4}},
+		{"_not", "user_books", obj{"where": obj{"_not": obj{"user_id": obj{"_eq": 1.0}}}}, []int{4}},
+		{"object relation", "user_books", obj{"where": obj{"book": obj{"title": obj{"_eq": "Piranesi"}}}}, []int{3, 4}},
+		{"array relation", "books", obj{"where": obj{"user_books": obj{"user_id": obj{"_eq": 2.0}}}}, []int{3}},
+		{"nested relations", "lists", obj{"where": obj{"list_books": obj{"book": obj{
+			"user_books": obj{"user_id": obj{"_eq": 1.0}, "status_id": obj{"_in": []any{2.0}}},
+		}}}}, []int{1}},
+		{"nested relations without a match", "lists", obj{"where": obj{"list_books": obj{"book": obj{
+			"user_books": obj{"user_id": obj{"_eq": 3.0}},
+		}}}}, []int{}},
+		{"series of a book", "books", obj{"where": obj{"book_series": obj{"series_id": obj{"_eq": 1.0}}}}, []int{1, 2}},
+		{"series started by a user", "series", obj{"where": obj{"book_series": obj{
+			"position": obj{"_is_null": false},
+			"book":     obj{"user_books": obj{"user_id": obj{"_eq": 1.0}, "status_id": obj{"_in": []any{2.0, 3.0, 4.0}}}},
+		}}}, []int{1}},
+		{"series not started by a user", "series", obj{"where": obj{"book_series": obj{"book": obj{
+			"user_books": obj{"user_id": obj{"_eq": 2.0}},
+		}}}}, []int{}},
+		{"order asc", "books", obj{"order_by": obj{"pages": "asc"}}, []int{3, 2, 1}},
+		{"order desc", "user_books", obj{"order_by": obj{"updated_at": "desc"}}, []int{2, 3, 1, 4}},
+		{"nulls last", "books", obj{"order_by": obj{"rating": "desc"}}, []int{1, 2, 3}},
+		{"nulls last ascending", "books", obj{"order_by": obj{"rating": "asc"}}, []int{2, 1, 3}},
+		{"order by several columns", "user_books", obj{"order_by": []any{obj{"user_id": "desc"}, obj{"book_id": "desc"}}}, []int{4, 3, 2, 1}},
+		{"limit", "user_books", obj{"limit": 2.0}, []int{1, 2}},
+		{"limit 0 is every row", "user_books", obj{"limit": 0.0}, []int{1, 2, 3, 4}},
+		{"offset", "user_books", obj{"offset": 1.0, "limit": 2.0}, []int{2, 3}},
+		{"offset past the end", "user_books", obj{"offset": 9.0}, []int{}},
+		{"filter, order and page", "user_books", obj{
+			"where":    obj{"user_id": obj{"_eq": 1.0}},
+			"order_by": obj{"updated_at": "asc"},
+			"offset":   1.0,
+			"limit":    1.0,
+		}, []int{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testStore()
+			got := ids(s.query(tt.table, s.tables[tt.table], tt.args))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got ids %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	s := testStore()
+	rows := s.query("user_books", s.tables["user_books"], obj{"where": obj{"user_id": obj{"_eq": 1.0}}})
+	agg := aggregate(rows)["aggregate"].(row)
+	checks := []struct {
+		name string
+		got  any
+		want float64
+	}{
+		{"count", agg["count"], 3},
+		{"sum rating", agg["sum"].(row)["rating"], 9},
+		{"avg rating", agg["avg"].(row)["rating"], 4.5},
+		{"min rating", agg["min"].(row)["rating"], 4},
+		{"max rating", agg["max"].(row)["rating"], 5},
+		{"max status", agg["max"].(row)["status_id"], 3},
+	}
+	for _, c := range checks {
+		if toFloat(c.got) != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	empty := aggregate(nil)["aggregate"].(row)
+	if toFloat(empty["count"]) != 0 || len(empty["sum"].(row)) != 0 {
+		t.Errorf("aggregate of no rows = %v", empty)
+	}
+}
+
+func TestExecute(t *testing.T) {
+	s := testStore()
+	op, err := parse(`query ($userID: Int!) {
+		books: user_books(where: {user_id: {_eq: $userID}}, order_by: {updated_at: desc}, limit: 2) {
+			status_id
+			book { title }
+		}
+		read: user_books_aggregate(where: {user_id: {_eq: $userID}, status_id: {_eq: 3}}) {
+			aggregate { count }
+		}
+		lists_by_pk(id: 1) {
+			name
+			list_books(order_by: {position: desc}) { position }
+		}
+		missing: books_by_pk(id: 99) { title }
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.execute(op, map[string]any{"userID": float64(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"books": []any{
+			row{"status_id": 2, "book": row{"title": "Dune Messiah"}},
+			row{"status_id": 1, "book": row{"title": "Piranesi"}},
+		},
+		"read": row{"aggregate": row{"count": 1}},
+		"lists_by_pk": row{
+			"name":       "Desert planets",
+			"list_books": []any{row{"position": 2}, row{"position": 1}},
+		},
+		"missing": nil,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("execute\n got %#v\nwant %#v", got, want)
+	}
+
+	op, _ = parse(`{ nonsense { id } }`)
+	if _, err := s.execute(op, nil); err == nil {
+		t.Error("unknown root field: no error")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	s := newStore()
+	s.insert("books", row{"title": "Dune", "users_count": 10})
+	s.insert("books", row{"title": "Dune Messiah", "users_count": 30})
+	s.insert("books", row{"title": "Children of Dune", "users_count": 20})
+	s.insert("books", row{"title": "Piranesi", "users_count": 50})
+
+	titles := func(res row) []string {
+		var out []string
+		for _, h := range res["results"].(row)["hits"].([]any) {
+			out = append(out, h.(row)["document"].(row)["title"].(string))
+		}
+		return out
+	}
+	tests := []struct {
+		args  obj
+		found int
+		want  []string
+	}{
+		{obj{"query": "dune"}, 3, []string{"Dune Messiah", "Children of Dune", "Dune"}},
+		{obj{"query": "DUNE", "per_page": 2.0}, 3, []string{"Dune Messiah", "Children of Dune"}},
+		{obj{"query": "dune", "per_page": 2.0, "page": 2.0}, 3, []string{"Dune"}},
+		{obj{"query": "dune", "per_page": 2.0, "page": 3.0}, 3, nil},
+		{obj{"query": "nothing"}, 0, nil},
+	}
+	for _, tt := range tests {
+		res := s.search(tt.args)
+		if found := res["results"].(row)["found"]; found != tt.found {
+			t.Errorf("search(%v) found %v, want %d", tt.args, found, tt.found)
+		}
+		if got := titles(res); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("search(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
@@ -23,6 +23,13 @@ type Date string
 // GetGraphQLType implements the go-graphql-client GraphQLType interface.
 func (d Date) GetGraphQLType() string { return "date" }
 
+// Timestamptz is a custom GraphQL scalar that maps to Hardcover's
+// "timestamptz" type.
+type Timestamptz string
+
+// GetGraphQLType implements the go-graphql-client GraphQLType interface.
+func (t Timestamptz) GetGraphQLType() string { return "timestamptz" }
+
 // InsertUserBook adds a book to the user's library.
 func InsertUserBook(ctx context.Context, c *api.Client, bookID, statusID int) (*api.UserBook, error) {
 	var m struct {
@@ -90,19 +97,54 @@ func UpdateUserBookRating(ctx context.Context, c *api.Client, userBookID int, ra
 	return c.Mutate(ctx, &m, vars)
 }
 
-// UpdateUserBookReview updates the review for a user book.
-func UpdateUserBookReview(ctx context.Context, c *api.Client, userBookID int, review string, hasSpoilers bool) error {
+// ClearUserBookRating removes the rating from a user book.
+func ClearUserBookRating(ctx context.Context, c *api.Client, userBookID int) error {
 	var m struct {
 		UpdateUserBook struct {
 			ID    *int    `graphql:"id"`
 			Error *string `graphql:"error"`
-		} `graphql:"update_user_book(id: $id, object: {review_raw: $review, review_has_spoilers: $spoilers})"`
+		} `graphql:"update_user_book(id: $id, object: {rating: null})"`
 	}
 
 	vars := map[string]interface{}{
-		"id":       graphql.Int(userBookID),
-		"review":   graphql.String(review),
-		"spoilers": graphql.Boolean(hasSpoilers),
+		"id": graphql.Int(userBookID),
+	}
+
+	return c.Mutate(ctx, &m, vars)
+}
+
+// UpdateUserBookPrivacy sets who can see a user book.
+func UpdateUserBookPrivacy(ctx context.Context, c *api.Client, userBookID, privacySettingID int) error {
+	var m struct {
+		UpdateUserBook struct {
+			ID    *int    `graphql:"id"`
+			Error *string `graphql:"error"`
+		} `graphql:"update_user_book(id: $id, object: {privacy_setting_id: $privacySettingId})"`
+	}
+
+	vars := map[string]interface{}{
+		"id":               graphql.Int(userBookID),
+		"privacySettingId": graphql.Int(privacySettingID),
+	}
+
+	return c.Mutate(ctx, &m, vars)
+}
+
+// UpdateUserBookReview updates the review for a user book. review is the
+// Markdown source and reviewHTML its rendering, which Hardcover shows.
+func UpdateUserBookReview(ctx context.Context, c *api.Client, userBookID int, review, reviewHTML string, hasSpoilers bool) error {
+	var m struct {
+		UpdateUserBook struct {
+			ID    *int    `graphql:"id"`
+			Error *string `graphql:"error"`
+		} `graphql:"update_user_book(id: $id, object: {review_raw: $review, review_html: $reviewHTML, review_has_spoilers: $spoilers})"`
+	}
+
+	vars := map[string]interface{}{
+		"id":         graphql.Int(userBookID),
+		"review":     graphql.String(review),
+		"reviewHTML": graphql.String(reviewHTML),
+		"spoilers":   graphql.Boolean(hasSpoilers),
 	}
 
 	return c.Mutate(ctx, &m, vars)
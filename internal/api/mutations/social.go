@@ -3,6 +3,7 @@ package mutations
 import (
 	"context"
 	"fmt"
+	"time"
 
 	graphql "github.com/hasura/go-graphql-client"
 
@@ -104,19 +105,32 @@ func DeleteListBook(ctx context.Context, c *api.Client, listBookID int) error {
 	return c.Mutate(ctx, &m, vars)
 }
 
-// InsertReadingJournal creates a new journal entry.
+// InsertReadingJournal creates a new journal entry dated actionAt, an
+// RFC 3339 timestamp, or now if actionAt is empty.
 func InsertReadingJournal(ctx context.Context, c *api.Client, bookID int, event, entry, actionAt string) error {
+	return InsertReadingJournalEvent(ctx, c, bookID, event, entry, api.JournalMetadata{}, actionAt)
+}
+
+// InsertReadingJournalEvent creates a typed journal entry such as a quote,
+// progress update or status change, with its structured metadata. It is
+// dated actionAt, or now if actionAt is empty.
+func InsertReadingJournalEvent(ctx context.Context, c *api.Client, bookID int, event, entry string, meta api.JournalMetadata, actionAt string) error {
 	var m struct {
 		InsertReadingJournal struct {
 			ID     *int      `graphql:"id"`
 			Errors *[]string `graphql:"errors"`
-		} `graphql:"insert_reading_journal(object: {book_id: $bookId, event: $event, entry: $entry, privacy_setting_id: $privacySettingId, tags: []})"`
+		} `graphql:"insert_reading_journal(object: {book_id: $bookId, event: $event, entry: $entry, metadata: $metadata, action_at: $actionAt, privacy_setting_id: $privacySettingId, tags: []})"`
 	}
 
+	if actionAt == "" {
+		actionAt = time.Now().UTC().Format(time.RFC3339)
+	}
 	vars := map[string]interface{}{
 		"bookId":           graphql.Int(bookID),
 		"event":            graphql.String(event),
 		"entry":            graphql.String(entry),
+		"metadata":         meta,
+		"actionAt":         Timestamptz(actionAt),
 		"privacySettingId": graphql.Int(1),
 	}
 
@@ -11,18 +11,48 @@ import (
 	"github.com/NotMugil/hardcover-tui/internal/api"
 )
 
-// Search performs a book search using the Hardcover search API.
+// searchPerPage is the number of hits requested per search page.
+const searchPerPage = 20
+
+// searchFields maps each query type to the fields searched and their weights.
+var searchFields = map[api.SearchType][2]string{
+	api.SearchBooks:   {"title,author_names", "7,3"},
+	api.SearchAuthors: {"name,name_personal,alternate_names", "4,3,1"},
+	api.SearchSeries:  {"name,author_name", "3,1"},
+	api.SearchLists:   {"name,description", "3,1"},
+	api.SearchUsers:   {"username,name", "3,2"},
+}
+
+// Search performs a book search using the Hardcover search API and returns
+// the first page of results.
+func Search(ctx context.Context, c *api.Client, query string) ([]api.Book, error) {
+	page, err := SearchPage(ctx, c, query, api.SearchBooks, 1)
+	if err != nil {
+		return nil, err
+	}
+	return page.Books, nil
+}
+
+// SearchPage fetches one page of search results for the given query type.
 // Follows the approach from github.com/Kameleon21/oku: inline query values,
 // input sanitization, and search fields/weights for better relevance.
-func Search(ctx context.Context, c *api.Client, query string) ([]api.Book, error) {
+func SearchPage(ctx context.Context, c *api.Client, query string, queryType api.SearchType, page int) (*api.SearchPage, error) {
 	sanitized := strings.ReplaceAll(query, `\`, `\\`)
 	sanitized = strings.ReplaceAll(sanitized, `"`, `\"`)
+	if page < 1 {
+		page = 1
+	}
+	fw, ok := searchFields[queryType]
+	if !ok {
+		queryType = api.SearchBooks
+		fw = searchFields[queryType]
+	}
 
 	gqlQuery := fmt.Sprintf(`query {
-		search(query: "%s", query_type: "Book", per_page: 20, page: 1, fields: "title,author_names", weights: "7,3") {
+		search(query: "%s", query_type: "%s", per_page: %d, page: %d, fields: "%s", weights: "%s") {
 			results
 		}
-	}`, sanitized)
+	}`, sanitized, queryType, searchPerPage, page, fw[0], fw[1])
 
 	raw, err := c.ExecRaw(ctx, gqlQuery, nil)
 	if err != nil {
@@ -38,8 +68,9 @@ func Search(ctx context.Context, c *api.Client, query string) ([]api.Book, error
 		return nil, fmt.Errorf("parse search response: %w", err)
 	}
 
+	result := &api.SearchPage{Type: queryType, Page: page, PerPage: searchPerPage}
 	if len(resp.Search.Results) == 0 || string(resp.Search.Results) == "null" {
-		return nil, nil
+		return result, nil
 	}
 
 	results := resp.Search.Results
@@ -52,23 +83,11 @@ func Search(ctx context.Context, c *api.Client, query string) ([]api.Book, error
 	}
 
 	type searchHit struct {
-		Document struct {
-			ID           json.Number `json:"id"`
-			Title        string      `json:"title"`
-			Slug         string      `json:"slug"`
-			AuthorNames  []string    `json:"author_names"`
-			Pages        json.Number `json:"pages"`
-			Rating       float64     `json:"rating"`
-			UsersCount   int         `json:"users_count"`
-			ReleaseYear  int         `json:"release_year"`
-			Genres       []string    `json:"genres"`
-			Description  string      `json:"description"`
-			HasAudiobook bool        `json:"has_audiobook"`
-			HasEbook     bool        `json:"has_ebook"`
-		} `json:"document"`
+		Document json.RawMessage `json:"document"`
 	}
 	type hitsContainer struct {
-		Hits []searchHit `json:"hits"`
+		Found int         `json:"found"`
+		Hits  []searchHit `json:"hits"`
 	}
 
 	var allHits []searchHit
@@ -76,6 +95,7 @@ func Search(ctx context.Context, c *api.Client, query string) ([]api.Book, error
 	var flat hitsContainer
 	if err := json.Unmarshal(results, &flat); err == nil && len(flat.Hits) > 0 {
 		allHits = flat.Hits
+		result.Found = flat.Found
 	}
 
 	if len(allHits) == 0 {
@@ -83,66 +103,141 @@ func Search(ctx context.Context, c *api.Client, query string) ([]api.Book, error
 		if err := json.Unmarshal(results, &groups); err == nil {
 			for _, g := range groups {
 				allHits = append(allHits, g.Hits...)
+				result.Found += g.Found
 			}
 		}
 	}
 
 	if len(allHits) == 0 {
 		var wrapper struct {
+			Found       int             `json:"found"`
 			GroupedHits []hitsContainer `json:"grouped_hits"`
 		}
 		if err := json.Unmarshal(results, &wrapper); err == nil {
 			for _, g := range wrapper.GroupedHits {
 				allHits = append(allHits, g.Hits...)
 			}
+			result.Found = wrapper.Found
 		}
 	}
 
-	if len(allHits) == 0 {
-		return nil, nil
+	if result.Found < len(allHits) {
+		result.Found = len(allHits)
 	}
 
-	books := make([]api.Book, 0, len(allHits))
 	for _, hit := range allHits {
-		doc := hit.Document
-		docID, _ := doc.ID.Int64()
-		docPages, _ := doc.Pages.Int64()
-
-		b := api.Book{
-			ID:           int(docID),
-			Title:        doc.Title,
-			Slug:         &doc.Slug,
-			UsersCount:   doc.UsersCount,
-			HasAudiobook: doc.HasAudiobook,
-			HasEbook:     doc.HasEbook,
-		}
-		if doc.Rating > 0 {
-			r := doc.Rating
-			b.Rating = &r
-		}
-		if docPages > 0 {
-			p := int(docPages)
-			b.Pages = &p
-		}
-		if doc.ReleaseYear > 0 {
-			ry := doc.ReleaseYear
-			b.ReleaseYear = &ry
+		if queryType == api.SearchBooks {
+			if b, ok := toSearchBook(hit.Document); ok {
+				result.Books = append(result.Books, b)
+			}
+			continue
 		}
-		if doc.Description != "" {
-			d := doc.Description
-			b.Description = &d
+		if r, ok := toSearchResult(queryType, hit.Document); ok {
+			result.Results = append(result.Results, r)
 		}
-		for _, name := range doc.AuthorNames {
-			b.Contributions = append(b.Contributions, api.Contribution{
-				Author: api.Author{Name: name},
-			})
+	}
+	return result, nil
+}
+
+// toSearchBook converts a book search document to an api.Book.
+func toSearchBook(raw json.RawMessage) (api.Book, bool) {
+	var doc struct {
+		ID           json.Number `json:"id"`
+		Title        string      `json:"title"`
+		Slug         string      `json:"slug"`
+		AuthorNames  []string    `json:"author_names"`
+		Pages        json.Number `json:"pages"`
+		Rating       float64     `json:"rating"`
+		UsersCount   int         `json:"users_count"`
+		ReleaseYear  int         `json:"release_year"`
+		Genres       []string    `json:"genres"`
+		Description  string      `json:"description"`
+		HasAudiobook bool        `json:"has_audiobook"`
+		HasEbook     bool        `json:"has_ebook"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return api.Book{}, false
+	}
+	docID, _ := doc.ID.Int64()
+	docPages, _ := doc.Pages.Int64()
+
+	b := api.Book{
+		ID:           int(docID),
+		Title:        doc.Title,
+		Slug:         &doc.Slug,
+		UsersCount:   doc.UsersCount,
+		HasAudiobook: doc.HasAudiobook,
+		HasEbook:     doc.HasEbook,
+	}
+	if doc.Rating > 0 {
+		r := doc.Rating
+		b.Rating = &r
+	}
+	if docPages > 0 {
+		p := int(docPages)
+		b.Pages = &p
+	}
+	if doc.ReleaseYear > 0 {
+		ry := doc.ReleaseYear
+		b.ReleaseYear = &ry
+	}
+	if doc.Description != "" {
+		d := doc.Description
+		b.Description = &d
+	}
+	for _, name := range doc.AuthorNames {
+		b.Contributions = append(b.Contributions, api.Contribution{
+			Author: api.Author{Name: name},
+		})
+	}
+	for _, g := range doc.Genres {
+		b.Genres = append(b.Genres, api.TagItem{Name: g})
+	}
+	return b, true
+}
+
+// toSearchResult converts an author, series, list or user search document.
+func toSearchResult(queryType api.SearchType, raw json.RawMessage) (api.SearchResult, bool) {
+	var doc struct {
+		ID             json.Number `json:"id"`
+		Name           string      `json:"name"`
+		Username       string      `json:"username"`
+		Slug           string      `json:"slug"`
+		AuthorName     string      `json:"author_name"`
+		BooksCount     int         `json:"books_count"`
+		LikesCount     int         `json:"likes_count"`
+		FollowersCount int         `json:"followers_count"`
+		User           *struct {
+			Username string `json:"username"`
+		} `json:"user"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return api.SearchResult{}, false
+	}
+	id, _ := doc.ID.Int64()
+	r := api.SearchResult{
+		ID:         int(id),
+		Name:       doc.Name,
+		Slug:       doc.Slug,
+		BooksCount: doc.BooksCount,
+	}
+	switch queryType {
+	case api.SearchSeries:
+		r.Detail = doc.AuthorName
+	case api.SearchLists:
+		if doc.User != nil {
+			r.Detail = "@" + doc.User.Username
 		}
-		for _, g := range doc.Genres {
-			b.Genres = append(b.Genres, api.TagItem{Name: g})
+		r.Count = doc.LikesCount
+	case api.SearchUsers:
+		r.Name = doc.Username
+		r.Detail = doc.Name
+		r.Count = doc.FollowersCount
+		if r.Slug == "" {
+			r.Slug = doc.Username
 		}
-		books = append(books, b)
 	}
-	return books, nil
+	return r, true
 }
 
 // GetBookByID fetches a book by its primary key (book ID, not user_book ID).
@@ -170,6 +265,7 @@ func GetBookByID(ctx context.Context, c *api.Client, bookID int) (*api.Book, err
 					Slug string `graphql:"slug"`
 				} `graphql:"author"`
 			} `graphql:"contributions"`
+			BookSeries []api.BookSeries `graphql:"book_series(order_by: {position: asc})"`
 		} `graphql:"books_by_pk(id: $id)"`
 	}
 
@@ -181,7 +277,7 @@ func GetBookByID(ctx context.Context, c *api.Client, bookID int) (*api.Book, err
 		return nil, fmt.Errorf("query books_by_pk: %w", err)
 	}
 	if q.Book == nil {
-		return nil, fmt.Errorf("book %d not found", bookID)
+		return nil, fmt.Errorf("book %d: %w", bookID, api.ErrNotFound)
 	}
 
 	b := &api.Book{
@@ -199,6 +295,7 @@ func GetBookByID(ctx context.Context, c *api.Client, bookID int) (*api.Book, err
 		AudioSeconds:   q.Book.AudioSeconds,
 		LiteraryTypeID: q.Book.LiteraryTypeID,
 		Image:          q.Book.Image,
+		BookSeries:     q.Book.BookSeries,
 	}
 	for _, ct := range q.Book.Contributions {
 		b.Contributions = append(b.Contributions, api.Contribution{
@@ -212,6 +309,31 @@ func GetBookByID(ctx context.Context, c *api.Client, bookID int) (*api.Book, err
 	return b, nil
 }
 
+// GetBookBySlug looks a book up by the slug in its hardcover.app URL. Only
+// the ID, title and slug are fetched; GetBookByID has the rest.
+func GetBookBySlug(ctx context.Context, c *api.Client, slug string) (*api.Book, error) {
+	var q struct {
+		Books []struct {
+			ID    int     `graphql:"id"`
+			Title string  `graphql:"title"`
+			Slug  *string `graphql:"slug"`
+		} `graphql:"books(where: {slug: {_eq: $slug}}, limit: 1)"`
+	}
+
+	vars := map[string]interface{}{
+		"slug": graphql.String(slug),
+	}
+
+	if err := c.Query(ctx, &q, vars); err != nil {
+		return nil, fmt.Errorf("query books by slug: %w", err)
+	}
+	if len(q.Books) == 0 {
+		return nil, fmt.Errorf("book %q: %w", slug, api.ErrNotFound)
+	}
+	b := q.Books[0]
+	return &api.Book{ID: b.ID, Title: b.Title, Slug: b.Slug}, nil
+}
+
 // GetBookTags fetches genres, moods, and content warnings for a book via ExecRaw.
 func GetBookTags(ctx context.Context, c *api.Client, bookID int) (genres, moods, contentWarnings []api.TagItem, err error) {
 	const gqlQuery = `query ($bookId: Int!) {
@@ -277,6 +399,7 @@ func GetBookReviews(ctx context.Context, c *api.Client, bookID, limit int) ([]ap
 			id
 			rating
 			review
+			review_html
 			review_has_spoilers
 			likes_count
 			created_at
@@ -303,6 +426,7 @@ func GetBookReviews(ctx context.Context, c *api.Client, bookID, limit int) ([]ap
 			ID                int      `json:"id"`
 			Rating            *float64 `json:"rating"`
 			Review            *string  `json:"review"`
+			ReviewHTML        *string  `json:"review_html"`
 			ReviewHasSpoilers bool     `json:"review_has_spoilers"`
 			LikesCount        int      `json:"likes_count"`
 			CreatedAt         string   `json:"created_at"`
@@ -323,6 +447,7 @@ func GetBookReviews(ctx context.Context, c *api.Client, bookID, limit int) ([]ap
 			ID:                ub.ID,
 			Rating:            ub.Rating,
 			Review:            ub.Review,
+			ReviewHTML:        ub.ReviewHTML,
 			ReviewHasSpoilers: ub.ReviewHasSpoilers,
 			LikesCount:        ub.LikesCount,
 			CreatedAt:         ub.CreatedAt,
@@ -0,0 +1,204 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	graphql "github.com/hasura/go-graphql-client"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+)
+
+// seriesFragment is a series with its numbered books in order.
+type seriesFragment struct {
+	api.Series
+	BookSeries []struct {
+		Position float64      `graphql:"position"`
+		Book     bookFragment `graphql:"book"`
+	} `graphql:"book_series(where: {position: {_is_null: false}}, order_by: {position: asc})"`
+}
+
+// seriesStatus is the user's status for one book of a series.
+type seriesStatus struct {
+	BookID   int `graphql:"book_id"`
+	StatusID int `graphql:"status_id"`
+}
+
+// toSeriesDetail pairs a series' books with the user's statuses. Hardcover
+// can list several books at one position, such as an omnibus next to the
+// novel; the position keeps the one the user got furthest with, so a read
+// omnibus counts the novel as read, and otherwise the first.
+func (sf seriesFragment) toSeriesDetail(statuses map[int]int) api.SeriesDetail {
+	d := api.SeriesDetail{Series: sf.Series}
+	for _, bs := range sf.BookSeries {
+		e := api.SeriesEntry{
+			Position: bs.Position,
+			Book:     bs.Book.toBook(),
+			StatusID: statuses[bs.Book.ID],
+		}
+		if n := len(d.Entries); n > 0 && d.Entries[n-1].Position == bs.Position {
+			if statusRank(e.StatusID) > statusRank(d.Entries[n-1].StatusID) {
+				d.Entries[n-1] = e
+			}
+			continue
+		}
+		d.Entries = append(d.Entries, e)
+	}
+	return d
+}
+
+// statusRank orders statuses by how far they take the user through a
+// book, read being furthest.
+func statusRank(statusID int) int {
+	switch api.StatusID(statusID) {
+	case api.StatusRead:
+		return 6
+	case api.StatusCurrentlyReading:
+		return 5
+	case api.StatusPaused:
+		return 4
+	case api.StatusWantToRead:
+		return 3
+	case api.StatusDidNotFinish:
+		return 2
+	case api.StatusIgnored:
+		return 1
+	}
+	return 0
+}
+
+func statusMap(rows []seriesStatus) map[int]int {
+	m := make(map[int]int, len(rows))
+	for _, r := range rows {
+		m[r.BookID] = r.StatusID
+	}
+	return m
+}
+
+// GetBookSeries fetches the series a book belongs to, numbered ones first.
+func GetBookSeries(ctx context.Context, c *api.Client, bookID int) ([]api.BookSeries, error) {
+	var q struct {
+		BookSeries []api.BookSeries `graphql:"book_series(where: {book_id: {_eq: $bookID}}, order_by: {position: asc})"`
+	}
+
+	vars := map[string]interface{}{
+		"bookID": graphql.Int(bookID),
+	}
+
+	if err := c.Query(ctx, &q, vars); err != nil {
+		return nil, fmt.Errorf("query book_series: %w", err)
+	}
+	return q.BookSeries, nil
+}
+
+// GetSeries fetches a series' numbered books in order, with userID's
+// status for the ones in their library.
+func GetSeries(ctx context.Context, c *api.Client, seriesID, userID int) (*api.SeriesDetail, error) {
+	var q struct {
+		Series    *seriesFragment `graphql:"series_by_pk(id: $seriesID)"`
+		UserBooks []seriesStatus  `graphql:"user_books(where: {user_id: {_eq: $userID}, book: {book_series: {series_id: {_eq: $seriesID}}}})"`
+	}
+
+	vars := map[string]interface{}{
+		"seriesID": graphql.Int(seriesID),
+		"userID":   graphql.Int(userID),
+	}
+
+	if err := c.Query(ctx, &q, vars); err != nil {
+		return nil, fmt.Errorf("query series_by_pk: %w", err)
+	}
+	if q.Series == nil {
+		return nil, fmt.Errorf("series %d: %w", seriesID, api.ErrNotFound)
+	}
+	d := q.Series.toSeriesDetail(statusMap(q.UserBooks))
+	return &d, nil
+}
+
+// GetSeriesInProgress fetches up to limit series userID has started, that
+// is read, is reading or paused a numbered book of, most recently active
+// first. Finished series are left out.
+//
+// The user's started books are paged through newest first, and the series
+// they belong to fetched a page at a time, until limit unfinished series
+// are found or the books run out.
+func GetSeriesInProgress(ctx context.Context, c *api.Client, userID, limit int) ([]api.SeriesDetail, error) {
+	seen := make(map[int]bool)
+	var out []api.SeriesDetail
+	for offset := 0; len(out) < limit; offset += limit {
+		var q struct {
+			// status_id 2, 3 and 4 are reading, read and paused.
+			UserBooks []struct {
+				Book struct {
+					BookSeries []struct {
+						SeriesID int `graphql:"series_id"`
+					} `graphql:"book_series(where: {position: {_is_null: false}})"`
+				} `graphql:"book"`
+			} `graphql:"user_books(where: {user_id: {_eq: $userID}, status_id: {_in: [2, 3, 4]}, book: {book_series: {position: {_is_null: false}}}}, order_by: {updated_at: desc}, limit: $limit, offset: $offset)"`
+		}
+
+		vars := map[string]interface{}{
+			"userID": graphql.Int(userID),
+			"limit":  graphql.Int(limit),
+			"offset": graphql.Int(offset),
+		}
+
+		if err := c.Query(ctx, &q, vars); err != nil {
+			return nil, fmt.Errorf("query started series books: %w", err)
+		}
+
+		// Series in the order their latest started book was updated.
+		var ids []graphql.Int
+		for _, ub := range q.UserBooks {
+			for _, bs := range ub.Book.BookSeries {
+				if !seen[bs.SeriesID] {
+					seen[bs.SeriesID] = true
+					ids = append(ids, graphql.Int(bs.SeriesID))
+				}
+			}
+		}
+		if len(ids) > 0 {
+			details, err := getSeriesDetails(ctx, c, userID, ids)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				d, ok := details[int(id)]
+				if !ok {
+					continue
+				}
+				if _, ok := d.Next(); ok && len(out) < limit {
+					out = append(out, d)
+				}
+			}
+		}
+		if len(q.UserBooks) < limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// getSeriesDetails fetches the given series with userID's statuses for
+// their books, keyed by series ID.
+func getSeriesDetails(ctx context.Context, c *api.Client, userID int, ids []graphql.Int) (map[int]api.SeriesDetail, error) {
+	var q struct {
+		Series   []seriesFragment `graphql:"series(where: {id: {_in: $ids}})"`
+		Statuses []seriesStatus   `graphql:"statuses: user_books(where: {user_id: {_eq: $userID}, book: {book_series: {series_id: {_in: $ids}}}})"`
+	}
+
+	vars := map[string]interface{}{
+		"userID": graphql.Int(userID),
+		"ids":    ids,
+	}
+
+	if err := c.Query(ctx, &q, vars); err != nil {
+		return nil, fmt.Errorf("query series: %w", err)
+	}
+
+	statuses := statusMap(q.Statuses)
+	details := make(map[int]api.SeriesDetail, len(q.Series))
+	for _, sf := range q.Series {
+		details[sf.ID] = sf.toSeriesDetail(statuses)
+	}
+	return details, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	graphql "github.com/hasura/go-graphql-client"
 
@@ -81,27 +82,28 @@ func GetForYouActivities(ctx context.Context, c *api.Client, _ int, limit int) (
 	return mapActivities(resp.ActivityForyouFeed), nil
 }
 
-// activityRaw is the JSON shape returned by activity queries.
+// activityRaw is the shape returned by activity queries, decoded from
+// ExecRaw JSON or selected directly in typed queries.
 type activityRaw struct {
-	ID               int              `json:"id"`
-	Event            string           `json:"event"`
-	Data             json.RawMessage  `json:"data"`
-	BookID           *int             `json:"book_id"`
-	LikesCount       int              `json:"likes_count"`
-	PrivacySettingID int              `json:"privacy_setting_id"`
-	CreatedAt        string           `json:"created_at"`
+	ID               int              `json:"id" graphql:"id"`
+	Event            string           `json:"event" graphql:"event"`
+	Data             json.RawMessage  `json:"data" graphql:"data"`
+	BookID           *int             `json:"book_id" graphql:"book_id"`
+	LikesCount       int              `json:"likes_count" graphql:"likes_count"`
+	PrivacySettingID int              `json:"privacy_setting_id" graphql:"privacy_setting_id"`
+	CreatedAt        string           `json:"created_at" graphql:"created_at"`
 	Book             *struct {
-		ID    int    `json:"id"`
-		Title string `json:"title"`
+		ID    int    `json:"id" graphql:"id"`
+		Title string `json:"title" graphql:"title"`
 		Image *struct {
-			URL string `json:"url"`
-		} `json:"image"`
-	} `json:"book"`
+			URL string `json:"url" graphql:"url"`
+		} `json:"image" graphql:"image"`
+	} `json:"book" graphql:"book"`
 	User struct {
-		ID       int     `json:"id"`
-		Username string  `json:"username"`
-		Name     *string `json:"name"`
-	} `json:"user"`
+		ID       int     `json:"id" graphql:"id"`
+		Username string  `json:"username" graphql:"username"`
+		Name     *string `json:"name" graphql:"name"`
+	} `json:"user" graphql:"user"`
 }
 
 // mapActivities converts raw query results to api.Activity values.
@@ -140,20 +142,7 @@ func mapActivities(raw []activityRaw) []api.Activity {
 // GetLists fetches the user's lists.
 func GetLists(ctx context.Context, c *api.Client, userID int) ([]api.List, error) {
 	var q struct {
-		Lists []struct {
-			ID               int     `graphql:"id"`
-			Name             string  `graphql:"name"`
-			Description      *string `graphql:"description"`
-			BooksCount       int     `graphql:"books_count"`
-			LikesCount       int     `graphql:"likes_count"`
-			Public           bool    `graphql:"public"`
-			Ranked           bool    `graphql:"ranked"`
-			PrivacySettingID int     `graphql:"privacy_setting_id"`
-			Slug             *string `graphql:"slug"`
-			UserID           int     `graphql:"user_id"`
-			CreatedAt        *string `graphql:"created_at"`
-			UpdatedAt        *string `graphql:"updated_at"`
-		} `graphql:"lists(where: {user_id: {_eq: $userID}}, order_by: {updated_at: desc})"`
+		Lists []listFragment `graphql:"lists(where: {user_id: {_eq: $userID}}, order_by: {updated_at: desc})"`
 	}
 
 	vars := map[string]interface{}{
@@ -166,24 +155,65 @@ func GetLists(ctx context.Context, c *api.Client, userID int) ([]api.List, error
 
 	lists := make([]api.List, len(q.Lists))
 	for i, l := range q.Lists {
-		lists[i] = api.List{
-			ID:               l.ID,
-			Name:             l.Name,
-			Description:      l.Description,
-			BooksCount:       l.BooksCount,
-			LikesCount:       l.LikesCount,
-			Public:           l.Public,
-			Ranked:           l.Ranked,
-			PrivacySettingID: l.PrivacySettingID,
-			Slug:             l.Slug,
-			UserID:           l.UserID,
-			CreatedAt:        l.CreatedAt,
-			UpdatedAt:        l.UpdatedAt,
-		}
+		lists[i] = l.toList()
 	}
 	return lists, nil
 }
 
+// GetListBySlug fetches one of username's lists by the slug in its
+// hardcover.app URL.
+func GetListBySlug(ctx context.Context, c *api.Client, username, slug string) (*api.List, error) {
+	var q struct {
+		Lists []listFragment `graphql:"lists(where: {slug: {_eq: $slug}, user: {username: {_eq: $username}}}, limit: 1)"`
+	}
+
+	vars := map[string]interface{}{
+		"slug":     graphql.String(slug),
+		"username": Citext(username),
+	}
+
+	if err := c.Query(ctx, &q, vars); err != nil {
+		return nil, fmt.Errorf("query lists by slug: %w", err)
+	}
+	if len(q.Lists) == 0 {
+		return nil, fmt.Errorf("list @%s/%s: %w", username, slug, api.ErrNotFound)
+	}
+	l := q.Lists[0].toList()
+	return &l, nil
+}
+
+type listFragment struct {
+	ID               int     `graphql:"id"`
+	Name             string  `graphql:"name"`
+	Description      *string `graphql:"description"`
+	BooksCount       int     `graphql:"books_count"`
+	LikesCount       int     `graphql:"likes_count"`
+	Public           bool    `graphql:"public"`
+	Ranked           bool    `graphql:"ranked"`
+	PrivacySettingID int     `graphql:"privacy_setting_id"`
+	Slug             *string `graphql:"slug"`
+	UserID           int     `graphql:"user_id"`
+	CreatedAt        *string `graphql:"created_at"`
+	UpdatedAt        *string `graphql:"updated_at"`
+}
+
+func (l listFragment) toList() api.List {
+	return api.List{
+		ID:               l.ID,
+		Name:             l.Name,
+		Description:      l.Description,
+		BooksCount:       l.BooksCount,
+		LikesCount:       l.LikesCount,
+		Public:           l.Public,
+		Ranked:           l.Ranked,
+		PrivacySettingID: l.PrivacySettingID,
+		Slug:             l.Slug,
+		UserID:           l.UserID,
+		CreatedAt:        l.CreatedAt,
+		UpdatedAt:        l.UpdatedAt,
+	}
+}
+
 // GetListBooks fetches books within a list.
 func GetListBooks(ctx context.Context, c *api.Client, listID int) ([]api.ListBook, error) {
 	var q struct {
@@ -266,39 +296,29 @@ func GetGoals(ctx context.Context, c *api.Client, userID int) ([]api.Goal, error
 	return goals, nil
 }
 
-// GetReadingJournals fetches the user's reading journal entries.
-func GetReadingJournals(ctx context.Context, c *api.Client, userID int, limit int) ([]api.ReadingJournal, error) {
-	var q struct {
-		Journals []struct {
-			ID               int     `graphql:"id"`
-			Event            string  `graphql:"event"`
-			Entry            *string `graphql:"entry"`
-			ActionAt         string  `graphql:"action_at"`
-			BookID           *int    `graphql:"book_id"`
-			EditionID        *int    `graphql:"edition_id"`
-			PrivacySettingID int     `graphql:"privacy_setting_id"`
-			LikesCount       int     `graphql:"likes_count"`
-			CreatedAt        string  `graphql:"created_at"`
-			UpdatedAt        string  `graphql:"updated_at"`
-			Book             *struct {
-				ID    int        `graphql:"id"`
-				Title string     `graphql:"title"`
-				Image *api.Image `graphql:"image"`
-			} `graphql:"book"`
-		} `graphql:"reading_journals(where: {user_id: {_eq: $userID}}, order_by: {action_at: desc}, limit: $limit)"`
-	}
-
-	vars := map[string]interface{}{
-		"userID": graphql.Int(userID),
-		"limit":  graphql.Int(limit),
-	}
-
-	if err := c.Query(ctx, &q, vars); err != nil {
-		return nil, fmt.Errorf("query reading_journals: %w", err)
-	}
+// journalRow is the shape of a reading_journals row in journal queries.
+type journalRow struct {
+	ID               int             `graphql:"id"`
+	Event            string          `graphql:"event"`
+	Entry            *string         `graphql:"entry"`
+	ActionAt         string          `graphql:"action_at"`
+	BookID           *int            `graphql:"book_id"`
+	EditionID        *int            `graphql:"edition_id"`
+	PrivacySettingID int             `graphql:"privacy_setting_id"`
+	LikesCount       int             `graphql:"likes_count"`
+	CreatedAt        string          `graphql:"created_at"`
+	UpdatedAt        string          `graphql:"updated_at"`
+	Metadata         json.RawMessage `graphql:"metadata"`
+	Book             *struct {
+		ID    int        `graphql:"id"`
+		Title string     `graphql:"title"`
+		Image *api.Image `graphql:"image"`
+	} `graphql:"book"`
+}
 
-	journals := make([]api.ReadingJournal, len(q.Journals))
-	for i, j := range q.Journals {
+func toReadingJournals(rows []journalRow) []api.ReadingJournal {
+	journals := make([]api.ReadingJournal, len(rows))
+	for i, j := range rows {
 		rj := api.ReadingJournal{
 			ID:               j.ID,
 			Event:            j.Event,
@@ -310,6 +330,7 @@ func GetReadingJournals(ctx context.Context, c *api.Client, userID int, limit in
 			LikesCount:       j.LikesCount,
 			CreatedAt:        j.CreatedAt,
 			UpdatedAt:        j.UpdatedAt,
+			Metadata:         j.Metadata,
 		}
 		if j.Book != nil {
 			b := &api.Book{
@@ -321,5 +342,51 @@ func GetReadingJournals(ctx context.Context, c *api.Client, userID int, limit in
 		}
 		journals[i] = rj
 	}
-	return journals, nil
+	return journals
+}
+
+// GetReadingJournals fetches the user's reading journal entries.
+func GetReadingJournals(ctx context.Context, c *api.Client, userID int, limit int) ([]api.ReadingJournal, error) {
+	return GetReadingJournalsPage(ctx, c, userID, "", limit, 0)
+}
+
+// GetReadingJournalsPage fetches one page of the user's journal entries
+// across all books, newest first. A non-empty search matches entry text
+// case-insensitively.
+func GetReadingJournalsPage(ctx context.Context, c *api.Client, userID int, search string, limit, offset int) ([]api.ReadingJournal, error) {
+	vars := map[string]interface{}{
+		"userID": graphql.Int(userID),
+		"limit":  graphql.Int(limit),
+		"offset": graphql.Int(offset),
+	}
+
+	var rows []journalRow
+	if search == "" {
+		var q struct {
+			Journals []journalRow `graphql:"reading_journals(where: {user_id: {_eq: $userID}}, order_by: {action_at: desc}, limit: $limit, offset: $offset)"`
+		}
+		if err := c.Query(ctx, &q, vars); err != nil {
+			return nil, fmt.Errorf("query reading_journals: %w", err)
+		}
+		rows = q.Journals
+	} else {
+		var q struct {
+			Journals []journalRow `graphql:"reading_journals(where: {user_id: {_eq: $userID}, entry: {_ilike: $search}}, order_by: {action_at: desc}, limit: $limit, offset: $offset)"`
+		}
+		vars["search"] = graphql.String("%" + escapeLike(search) + "%")
+		if err := c.Query(ctx, &q, vars); err != nil {
+			return nil, fmt.Errorf("query reading_journals: %w", err)
+		}
+		rows = q.Journals
+	}
+
+	return toReadingJournals(rows), nil
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern, so text the user
+// typed is matched as is.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
 }
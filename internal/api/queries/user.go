@@ -13,23 +13,7 @@ import (
 // GetMe fetches the authenticated user's profile.
 func GetMe(ctx context.Context, c *api.Client) (*api.User, error) {
 	var q struct {
-		Me []struct {
-			ID                 int        `graphql:"id"`
-			Username           string     `graphql:"username"`
-			Name               *string    `graphql:"name"`
-			Bio                *string    `graphql:"bio"`
-			Location           *string    `graphql:"location"`
-			Link               *string    `graphql:"link"`
-			Flair              *string    `graphql:"flair"`
-			BooksCount         int        `graphql:"books_count"`
-			FollowersCount     int        `graphql:"followers_count"`
-			FollowedUsersCount int        `graphql:"followed_users_count"`
-			Pro                bool       `graphql:"pro"`
-			PronounPersonal    string     `graphql:"pronoun_personal"`
-			PronounPossessive  string     `graphql:"pronoun_possessive"`
-			Image              *api.Image `graphql:"image"`
-			CreatedAt          localTime  `graphql:"created_at"`
-		} `graphql:"me"`
+		Me []userFragment `graphql:"me"`
 	}
 
 	if err := c.Query(ctx, &q, nil); err != nil {
@@ -38,46 +22,40 @@ func GetMe(ctx context.Context, c *api.Client) (*api.User, error) {
 	if len(q.Me) == 0 {
 		return nil, fmt.Errorf("not authenticated or no user found")
 	}
+	return q.Me[0].toUser(), nil
+}
 
-	me := q.Me[0]
-	return &api.User{
-		ID:                 me.ID,
-		Username:           me.Username,
-		Name:               me.Name,
-		Bio:                me.Bio,
-		Location:           me.Location,
-		Link:               me.Link,
-		Flair:              me.Flair,
-		BooksCount:         me.BooksCount,
-		FollowersCount:     me.FollowersCount,
-		FollowedUsersCount: me.FollowedUsersCount,
-		Pro:                me.Pro,
-		PronounPersonal:    me.PronounPersonal,
-		PronounPossessive:  me.PronounPossessive,
-		Image:              me.Image,
-		CreatedAt:          me.CreatedAt.Time,
-	}, nil
+// Citext is a custom GraphQL scalar that maps to Hardcover's "citext"
+// type, which usernames use so they match regardless of case. The standard
+// graphql.String maps to "String" which causes type mismatch errors.
+type Citext string
+
+// GetGraphQLType implements the go-graphql-client GraphQLType interface.
+func (c Citext) GetGraphQLType() string { return "citext" }
+
+// GetUserByUsername fetches another user's public profile.
+func GetUserByUsername(ctx context.Context, c *api.Client, username string) (*api.User, error) {
+	var q struct {
+		Users []userFragment `graphql:"users(where: {username: {_eq: $username}}, limit: 1)"`
+	}
+
+	vars := map[string]interface{}{
+		"username": Citext(username),
+	}
+
+	if err := c.Query(ctx, &q, vars); err != nil {
+		return nil, fmt.Errorf("query users by username: %w", err)
+	}
+	if len(q.Users) == 0 {
+		return nil, fmt.Errorf("user @%s: %w", username, api.ErrNotFound)
+	}
+	return q.Users[0].toUser(), nil
 }
 
 // GetUserBooks fetches the user's books with optional status filter.
 func GetUserBooks(ctx context.Context, c *api.Client, userID int, statusID *int, limit, offset int) ([]api.UserBook, error) {
 	var q struct {
-		UserBooks []struct {
-			ID            int          `graphql:"id"`
-			BookID        int          `graphql:"book_id"`
-			StatusID      int          `graphql:"status_id"`
-			Rating        *float64     `graphql:"rating"`
-			Review        *string      `graphql:"review"`
-			HasReview     bool         `graphql:"has_review"`
-			DateAdded     string       `graphql:"date_added"`
-			ReadCount     int          `graphql:"read_count"`
-			Owned         bool         `graphql:"owned"`
-			Starred       bool         `graphql:"starred"`
-			LikesCount    int          `graphql:"likes_count"`
-			CreatedAt     string       `graphql:"created_at"`
-			Book          bookFragment `graphql:"book"`
-			UserBookReads []ubReadFrag `graphql:"user_book_reads"`
-		} `graphql:"user_books(where: $where, order_by: {updated_at: desc}, limit: $limit, offset: $offset)"`
+		UserBooks []userBookFragment `graphql:"user_books(where: $where, order_by: {updated_at: desc}, limit: $limit, offset: $offset)"`
 	}
 
 	where := map[string]interface{}{
@@ -96,27 +74,7 @@ func GetUserBooks(ctx context.Context, c *api.Client, userID int, statusID *int,
 	if err := c.Query(ctx, &q, vars); err != nil {
 		return nil, fmt.Errorf("query user_books: %w", err)
 	}
-
-	books := make([]api.UserBook, len(q.UserBooks))
-	for i, ub := range q.UserBooks {
-		books[i] = api.UserBook{
-			ID:            ub.ID,
-			BookID:        ub.BookID,
-			StatusID:      ub.StatusID,
-			Rating:        ub.Rating,
-			Review:        ub.Review,
-			HasReview:     ub.HasReview,
-			DateAdded:     ub.DateAdded,
-			ReadCount:     ub.ReadCount,
-			Owned:         ub.Owned,
-			Starred:       ub.Starred,
-			LikesCount:    ub.LikesCount,
-			CreatedAt:     ub.CreatedAt,
-			Book:          ub.Book.toBook(),
-			UserBookReads: toReads(ub.UserBookReads),
-		}
-	}
-	return books, nil
+	return toUserBooks(q.UserBooks), nil
 }
 
 // GetCurrentlyReading fetches the user's currently reading books.
@@ -125,6 +83,60 @@ func GetCurrentlyReading(ctx context.Context, c *api.Client, userID int) ([]api.
 	return GetUserBooks(ctx, c, userID, &status, 20, 0)
 }
 
+// GetDashboard fetches everything the Home screen shows on startup in a
+// single request: the signed-in user, one page of their books with an
+// optional status filter, currently reading, per-status counts and their
+// recent activity.
+func GetDashboard(ctx context.Context, c *api.Client, statusID *int, limit, offset, activityLimit int) (*api.Dashboard, error) {
+	var q struct {
+		Me []struct {
+			userFragment
+			UserBooks []userBookFragment `graphql:"user_books(where: $where, order_by: {updated_at: desc}, limit: $limit, offset: $offset)"`
+			// status_id 2 is api.StatusCurrentlyReading.
+			Reading  []userBookFragment `graphql:"reading: user_books(where: {status_id: {_eq: 2}}, order_by: {updated_at: desc}, limit: 20)"`
+			Statuses []struct {
+				StatusID int `graphql:"status_id"`
+			} `graphql:"statuses: user_books"`
+			Activities []activityRaw `graphql:"activities(order_by: {created_at: desc}, limit: $activityLimit)"`
+		} `graphql:"me"`
+	}
+
+	where := map[string]interface{}{}
+	if statusID != nil {
+		where["status_id"] = map[string]interface{}{"_eq": *statusID}
+	}
+
+	vars := map[string]interface{}{
+		"where":         user_books_bool_exp(where),
+		"limit":         graphql.Int(limit),
+		"offset":        graphql.Int(offset),
+		"activityLimit": graphql.Int(activityLimit),
+	}
+
+	if err := c.Query(ctx, &q, vars); err != nil {
+		return nil, fmt.Errorf("query dashboard: %w", err)
+	}
+	if len(q.Me) == 0 {
+		return nil, fmt.Errorf("not authenticated or no user found")
+	}
+
+	me := q.Me[0]
+	counts := make(map[api.StatusID]int)
+	for _, s := range api.AllStatuses() {
+		counts[s] = 0
+	}
+	for _, ub := range me.Statuses {
+		counts[api.StatusID(ub.StatusID)]++
+	}
+	return &api.Dashboard{
+		User:         me.toUser(),
+		Books:        toUserBooks(me.UserBooks),
+		Reading:      toUserBooks(me.Reading),
+		StatusCounts: counts,
+		Activities:   mapActivities(me.Activities),
+	}, nil
+}
+
 // GetUserBookByPK fetches a single user_book by primary key.
 func GetUserBookByPK(ctx context.Context, c *api.Client, id int) (*api.UserBook, error) {
 	var q struct {
@@ -134,6 +146,7 @@ func GetUserBookByPK(ctx context.Context, c *api.Client, id int) (*api.UserBook,
 			StatusID          int          `graphql:"status_id"`
 			Rating            *float64     `graphql:"rating"`
 			Review            *string      `graphql:"review"`
+			ReviewHTML        *string      `graphql:"review_html"`
 			ReviewHasSpoilers bool         `graphql:"review_has_spoilers"`
 			HasReview         bool         `graphql:"has_review"`
 			DateAdded         string       `graphql:"date_added"`
@@ -167,6 +180,7 @@ func GetUserBookByPK(ctx context.Context, c *api.Client, id int) (*api.UserBook,
 		StatusID:          ub.StatusID,
 		Rating:            ub.Rating,
 		Review:            ub.Review,
+		ReviewHTML:        ub.ReviewHTML,
 		ReviewHasSpoilers: ub.ReviewHasSpoilers,
 		HasReview:         ub.HasReview,
 		DateAdded:         ub.DateAdded,
@@ -187,20 +201,22 @@ func GetUserBookByPK(ctx context.Context, c *api.Client, id int) (*api.UserBook,
 func GetUserBookByBookID(ctx context.Context, c *api.Client, userID, bookID int) (*api.UserBook, error) {
 	var q struct {
 		UserBooks []struct {
-			ID            int          `graphql:"id"`
-			BookID        int          `graphql:"book_id"`
-			StatusID      int          `graphql:"status_id"`
-			Rating        *float64     `graphql:"rating"`
-			Review        *string      `graphql:"review"`
-			HasReview     bool         `graphql:"has_review"`
-			DateAdded     string       `graphql:"date_added"`
-			ReadCount     int          `graphql:"read_count"`
-			Owned         bool         `graphql:"owned"`
-			Starred       bool         `graphql:"starred"`
-			LikesCount    int          `graphql:"likes_count"`
-			CreatedAt     string       `graphql:"created_at"`
-			Book          bookFragment `graphql:"book"`
-			UserBookReads []ubReadFrag `graphql:"user_book_reads"`
+			ID                int          `graphql:"id"`
+			BookID            int          `graphql:"book_id"`
+			StatusID          int          `graphql:"status_id"`
+			Rating            *float64     `graphql:"rating"`
+			Review            *string      `graphql:"review"`
+			ReviewHTML        *string      `graphql:"review_html"`
+			ReviewHasSpoilers bool         `graphql:"review_has_spoilers"`
+			HasReview         bool         `graphql:"has_review"`
+			DateAdded         string       `graphql:"date_added"`
+			ReadCount         int          `graphql:"read_count"`
+			Owned             bool         `graphql:"owned"`
+			Starred           bool         `graphql:"starred"`
+			LikesCount        int          `graphql:"likes_count"`
+			CreatedAt         string       `graphql:"created_at"`
+			Book              bookFragment `graphql:"book"`
+			UserBookReads     []ubReadFrag `graphql:"user_book_reads"`
 		} `graphql:"user_books(where: {user_id: {_eq: $userID}, book_id: {_eq: $bookID}}, limit: 1)"`
 	}
 
@@ -218,20 +234,22 @@ func GetUserBookByBookID(ctx context.Context, c *api.Client, userID, bookID int)
 
 	ub := q.UserBooks[0]
 	return &api.UserBook{
-		ID:            ub.ID,
-		BookID:        ub.BookID,
-		StatusID:      ub.StatusID,
-		Rating:        ub.Rating,
-		Review:        ub.Review,
-		HasReview:     ub.HasReview,
-		DateAdded:     ub.DateAdded,
-		ReadCount:     ub.ReadCount,
-		Owned:         ub.Owned,
-		Starred:       ub.Starred,
-		LikesCount:    ub.LikesCount,
-		CreatedAt:     ub.CreatedAt,
-		Book:          ub.Book.toBook(),
-		UserBookReads: toReads(ub.UserBookReads),
+		ID:                ub.ID,
+		BookID:            ub.BookID,
+		StatusID:          ub.StatusID,
+		Rating:            ub.Rating,
+		Review:            ub.Review,
+		ReviewHTML:        ub.ReviewHTML,
+		ReviewHasSpoilers: ub.ReviewHasSpoilers,
+		HasReview:         ub.HasReview,
+		DateAdded:         ub.DateAdded,
+		ReadCount:         ub.ReadCount,
+		Owned:             ub.Owned,
+		Starred:           ub.Starred,
+		LikesCount:        ub.LikesCount,
+		CreatedAt:         ub.CreatedAt,
+		Book:              ub.Book.toBook(),
+		UserBookReads:     toReads(ub.UserBookReads),
 	}, nil
 }
 
@@ -278,6 +296,88 @@ func GetUserBookStatusCounts(ctx context.Context, c *api.Client, userID int) (ma
 
 // --- internal fragment types for queries ---
 
+type userFragment struct {
+	ID                 int        `graphql:"id"`
+	Username           string     `graphql:"username"`
+	Name               *string    `graphql:"name"`
+	Bio                *string    `graphql:"bio"`
+	Location           *string    `graphql:"location"`
+	Link               *string    `graphql:"link"`
+	Flair              *string    `graphql:"flair"`
+	BooksCount         int        `graphql:"books_count"`
+	FollowersCount     int        `graphql:"followers_count"`
+	FollowedUsersCount int        `graphql:"followed_users_count"`
+	Pro                bool       `graphql:"pro"`
+	PronounPersonal    string     `graphql:"pronoun_personal"`
+	PronounPossessive  string     `graphql:"pronoun_possessive"`
+	Image              *api.Image `graphql:"image"`
+	CreatedAt          localTime  `graphql:"created_at"`
+}
+
+func (uf userFragment) toUser() *api.User {
+	return &api.User{
+		ID:                 uf.ID,
+		Username:           uf.Username,
+		Name:               uf.Name,
+		Bio:                uf.Bio,
+		Location:           uf.Location,
+		Link:               uf.Link,
+		Flair:              uf.Flair,
+		BooksCount:         uf.BooksCount,
+		FollowersCount:     uf.FollowersCount,
+		FollowedUsersCount: uf.FollowedUsersCount,
+		Pro:                uf.Pro,
+		PronounPersonal:    uf.PronounPersonal,
+		PronounPossessive:  uf.PronounPossessive,
+		Image:              uf.Image,
+		CreatedAt:          uf.CreatedAt.Time,
+	}
+}
+
+type userBookFragment struct {
+	ID                int          `graphql:"id"`
+	BookID            int          `graphql:"book_id"`
+	StatusID          int          `graphql:"status_id"`
+	Rating            *float64     `graphql:"rating"`
+	Review            *string      `graphql:"review"`
+	ReviewHTML        *string      `graphql:"review_html"`
+	ReviewHasSpoilers bool         `graphql:"review_has_spoilers"`
+	HasReview         bool         `graphql:"has_review"`
+	DateAdded         string       `graphql:"date_added"`
+	ReadCount         int          `graphql:"read_count"`
+	Owned             bool         `graphql:"owned"`
+	Starred           bool         `graphql:"starred"`
+	LikesCount        int          `graphql:"likes_count"`
+	CreatedAt         string       `graphql:"created_at"`
+	Book              bookFragment `graphql:"book"`
+	UserBookReads     []ubReadFrag `graphql:"user_book_reads"`
+}
+
+func toUserBooks(frags []userBookFragment) []api.UserBook {
+	books := make([]api.UserBook, len(frags))
+	for i, ub := range frags {
+		books[i] = api.UserBook{
+			ID:                ub.ID,
+			BookID:            ub.BookID,
+			StatusID:          ub.StatusID,
+			Rating:            ub.Rating,
+			Review:            ub.Review,
+			ReviewHTML:        ub.ReviewHTML,
+			ReviewHasSpoilers: ub.ReviewHasSpoilers,
+			HasReview:         ub.HasReview,
+			DateAdded:         ub.DateAdded,
+			ReadCount:         ub.ReadCount,
+			Owned:             ub.Owned,
+			Starred:           ub.Starred,
+			LikesCount:        ub.LikesCount,
+			CreatedAt:         ub.CreatedAt,
+			Book:              ub.Book.toBook(),
+			UserBookReads:     toReads(ub.UserBookReads),
+		}
+	}
+	return books
+}
+
 type bookFragment struct {
 	ID            int        `graphql:"id"`
 	Title         string     `graphql:"title"`
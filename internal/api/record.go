@@ -0,0 +1,247 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// scrubbedHeaders are never written to a recording.
+var scrubbedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// exchange is one recorded request/response pair, stored one per line.
+type exchange struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body"`
+}
+
+type recordedResponse struct {
+	Status int             `json:"status"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body"`
+}
+
+var (
+	modeMu   sync.Mutex
+	recorder *recordingTransport
+	replayer *replayTransport
+)
+
+// StartRecording writes every API request and response to path until the
+// returned closer is closed. Authorization and cookies are scrubbed. It
+// must be called before any Client is created.
+func StartRecording(path string) (io.Closer, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	modeMu.Lock()
+	defer modeMu.Unlock()
+	recorder = &recordingTransport{w: f}
+	return f, nil
+}
+
+// StartReplay serves API responses from a file written by StartRecording
+// instead of the network. It must be called before any Client is created.
+func StartReplay(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("replay: %w", err)
+	}
+	defer f.Close()
+
+	r := &replayTransport{responses: map[string][]recordedResponse{}}
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var ex exchange
+		if err := json.Unmarshal(sc.Bytes(), &ex); err != nil {
+			return fmt.Errorf("replay: %s line %d: %w", path, line, err)
+		}
+		key := replayKey(ex.Request.Body)
+		r.responses[key] = append(r.responses[key], ex.Response)
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("replay: %w", err)
+	}
+
+	modeMu.Lock()
+	defer modeMu.Unlock()
+	replayer = r
+	return nil
+}
+
+// Replaying reports whether responses are served from a recording.
+func Replaying() bool {
+	modeMu.Lock()
+	defer modeMu.Unlock()
+	return replayer != nil
+}
+
+// apiTransport returns the transport under authTransport for the current
+// record/replay mode.
+func apiTransport() http.RoundTripper {
+	modeMu.Lock()
+	defer modeMu.Unlock()
+	switch {
+	case replayer != nil:
+		return replayer
+	case recorder != nil:
+		recorder.wrapped = Transport()
+		return recorder
+	}
+	return Transport()
+}
+
+// recordingTransport appends each exchange to w as a JSON line.
+type recordingTransport struct {
+	wrapped http.RoundTripper
+	mu      sync.Mutex
+	w       io.Writer
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+
+	resp, err := t.wrapped.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	ex := exchange{
+		Request: recordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: scrub(req.Header),
+			Body:   rawJSON(reqBody),
+		},
+		Response: recordedResponse{
+			Status: resp.StatusCode,
+			Header: scrub(resp.Header),
+			Body:   rawJSON(respBody),
+		},
+	}
+	line, err := json.Marshal(ex)
+	if err == nil {
+		t.mu.Lock()
+		_, _ = t.w.Write(append(line, '\n'))
+		t.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// replayTransport answers requests from recorded responses. Identical
+// requests are answered in recorded order; the last answer repeats once
+// they run out.
+type replayTransport struct {
+	mu        sync.Mutex
+	responses map[string][]recordedResponse
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+
+	key := replayKey(body)
+	t.mu.Lock()
+	queue := t.responses[key]
+	var rec recordedResponse
+	found := len(queue) > 0
+	if found {
+		rec = queue[0]
+		if len(queue) > 1 {
+			t.responses[key] = queue[1:]
+		}
+	}
+	t.mu.Unlock()
+
+	if !found {
+		rec = recordedResponse{
+			Status: http.StatusOK,
+			Body:   json.RawMessage(`{"errors":[{"message":"replay: no recorded response for this request"}]}`),
+		}
+	}
+
+	header := rec.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Del("Content-Encoding") // the recorded body is already decoded
+	header.Del("Content-Length")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}, nil
+}
+
+// replayKey normalises a request body so that key order and whitespace
+// don't affect matching.
+func replayKey(body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func scrub(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range scrubbedHeaders {
+		out.Del(k)
+	}
+	return out
+}
+
+// rawJSON returns b as a JSON value, quoting it if it isn't valid JSON.
+func rawJSON(b []byte) json.RawMessage {
+	if json.Valid(b) {
+		return b
+	}
+	q, _ := json.Marshal(string(b))
+	return q
+}
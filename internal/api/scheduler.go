@@ -0,0 +1,224 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Priority orders requests waiting for a rate limit slot. Higher
+// priorities are always sent first; requests of equal priority are sent
+// in the order they arrived.
+type Priority int
+
+const (
+	// PriorityBackground is for refreshes and prefetches the user did not
+	// ask for, such as the activity feed and stats.
+	PriorityBackground Priority = iota
+	// PriorityNormal is the default for queries.
+	PriorityNormal
+	// PriorityUser is the default for mutations: saves the user is
+	// waiting on.
+	PriorityUser
+
+	numPriorities = int(PriorityUser) + 1
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose requests are scheduled at p.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// priorityFrom returns the priority set with WithPriority, or fallback.
+func priorityFrom(ctx context.Context, fallback Priority) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return fallback
+}
+
+// scheduler hands out request slots at a steady rate, highest priority
+// first. The rate follows the server's rate limit headers when it sends
+// them.
+type scheduler struct {
+	mu       sync.Mutex
+	base     time.Duration // interval to use when the server says nothing
+	interval time.Duration // current interval between requests
+	next     time.Time     // earliest time the next slot is free
+	queues   [numPriorities][]*waiter
+	timer    *time.Timer
+
+	// changed receives a value whenever the queue depth changes. Changes
+	// before the value is read are coalesced.
+	changed chan struct{}
+}
+
+type waiter struct {
+	ready chan struct{}
+}
+
+func newScheduler(interval time.Duration) *scheduler {
+	return &scheduler{
+		base:     interval,
+		interval: interval,
+		changed:  make(chan struct{}, 1),
+	}
+}
+
+// Wait blocks until a slot is free for a request at priority p or ctx is
+// done.
+func (s *scheduler) Wait(ctx context.Context, p Priority) error {
+	w := &waiter{ready: make(chan struct{})}
+	s.mu.Lock()
+	s.queues[p] = append(s.queues[p], w)
+	s.dispatchLocked()
+	s.mu.Unlock()
+	s.notify()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		// If the slot was granted as ctx ended it is simply spent.
+		if s.removeLocked(w) {
+			defer s.notify()
+		}
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// dispatchLocked grants the next slot if it is due, and otherwise arms a
+// timer for when it will be.
+func (s *scheduler) dispatchLocked() {
+	if s.timer != nil {
+		return
+	}
+	for s.depthLocked() > 0 {
+		now := time.Now()
+		if wait := s.next.Sub(now); wait > 0 {
+			s.timer = time.AfterFunc(wait, s.fire)
+			return
+		}
+		for p := numPriorities - 1; p >= 0; p-- {
+			if len(s.queues[p]) > 0 {
+				w := s.queues[p][0]
+				s.queues[p] = s.queues[p][1:]
+				close(w.ready)
+				break
+			}
+		}
+		s.next = now.Add(s.interval)
+	}
+}
+
+func (s *scheduler) fire() {
+	s.mu.Lock()
+	s.timer = nil
+	before := s.depthLocked()
+	s.dispatchLocked()
+	after := s.depthLocked()
+	s.mu.Unlock()
+	if before != after {
+		s.notify()
+	}
+}
+
+func (s *scheduler) removeLocked(w *waiter) bool {
+	for p, q := range s.queues {
+		for i, other := range q {
+			if other == w {
+				s.queues[p] = append(q[:i:i], q[i+1:]...)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *scheduler) depthLocked() int {
+	n := 0
+	for _, q := range s.queues {
+		n += len(q)
+	}
+	return n
+}
+
+// Depth returns how many requests are waiting for a slot.
+func (s *scheduler) Depth() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.depthLocked()
+}
+
+func (s *scheduler) notify() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// pause holds every request until d from now, for example after a 429.
+func (s *scheduler) pause(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if until := time.Now().Add(d); until.After(s.next) {
+		s.next = until
+		s.rearmLocked()
+	}
+}
+
+// observe adapts the rate to the rate limit headers on a response. Both
+// the X-RateLimit-* and the draft standard RateLimit-* names are read;
+// the reset may be given in seconds or as a Unix timestamp.
+func (s *scheduler) observe(h http.Header) {
+	remaining, okRemaining := headerInt(h, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	reset, okReset := headerInt(h, "X-RateLimit-Reset", "RateLimit-Reset")
+	if !okRemaining || !okReset {
+		return
+	}
+	window := time.Duration(reset) * time.Second
+	if reset > 1e9 {
+		window = max(time.Until(time.Unix(int64(reset), 0)), 0)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if remaining <= 0 {
+		// Out of budget: hold everything until the window resets.
+		if until := time.Now().Add(window); until.After(s.next) {
+			s.next = until
+		}
+		s.rearmLocked()
+		return
+	}
+	// Spread the remaining budget over the window, but never go faster
+	// than the default rate.
+	s.interval = max(s.base, window/time.Duration(remaining))
+}
+
+// rearmLocked restarts the dispatch timer after next moved.
+func (s *scheduler) rearmLocked() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.dispatchLocked()
+}
+
+// headerInt returns the first of names present in h as an integer.
+func headerInt(h http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		if v := strings.TrimSpace(h.Get(name)); v != "" {
+			n, err := strconv.Atoi(v)
+			return n, err == nil
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// waitDepth waits until s has n requests queued.
+func waitDepth(t *testing.T, s *scheduler, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for s.Depth() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("depth %d, want %d", s.Depth(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// grant frees the next slot now instead of waiting for the interval.
+func grant(s *scheduler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next = time.Time{}
+	s.rearmLocked()
+}
+
+func TestSchedulerPriorityOrder(t *testing.T) {
+	s := newScheduler(time.Hour)
+	// Take the free slot so the rest queue up.
+	if err := s.Wait(context.Background(), PriorityNormal); err != nil {
+		t.Fatal(err)
+	}
+
+	arrivals := []struct {
+		name string
+		p    Priority
+	}{
+		{"background 1", PriorityBackground},
+		{"normal 1", PriorityNormal},
+		{"user 1", PriorityUser},
+		{"background 2", PriorityBackground},
+		{"normal 2", PriorityNormal},
+		{"user 2", PriorityUser},
+	}
+	done := make(chan string, len(arrivals))
+	for i, a := range arrivals {
+		go func() {
+			if err := s.Wait(context.Background(), a.p); err != nil {
+				t.Error(err)
+			}
+			done <- a.name
+		}()
+		waitDepth(t, s, i+1)
+	}
+
+	var order []string
+	for range arrivals {
+		grant(s)
+		order = append(order, <-done)
+	}
+	want := []string{"user 1", "user 2", "normal 1", "normal 2", "background 1", "background 2"}
+	if !slices.Equal(order, want) {
+		t.Errorf("sent in order %q, want %q", order, want)
+	}
+}
+
+func TestSchedulerCancelledWaitLeavesQueue(t *testing.T) {
+	s := newScheduler(time.Hour)
+	if err := s.Wait(context.Background(), PriorityNormal); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() { errc <- s.Wait(ctx, PriorityUser) }()
+	waitDepth(t, s, 1)
+	cancel()
+	if err := <-errc; err != context.Canceled {
+		t.Errorf("Wait = %v, want %v", err, context.Canceled)
+	}
+	if s.Depth() != 0 {
+		t.Errorf("depth %d after cancel, want 0", s.Depth())
+	}
+}
+
+func TestSchedulerPause(t *testing.T) {
+	s := newScheduler(0)
+	s.pause(50 * time.Millisecond)
+	start := time.Now()
+	if err := s.Wait(context.Background(), PriorityUser); err != nil {
+		t.Fatal(err)
+	}
+	if waited := time.Since(start); waited < 40*time.Millisecond {
+		t.Errorf("slot granted after %v, want the 50ms pause", waited)
+	}
+}
+
+func TestPriorityFrom(t *testing.T) {
+	ctx := context.Background()
+	if p := priorityFrom(ctx, PriorityUser); p != PriorityUser {
+		t.Errorf("priorityFrom without a priority = %v, want the fallback", p)
+	}
+	if p := priorityFrom(WithPriority(ctx, PriorityBackground), PriorityUser); p != PriorityBackground {
+		t.Errorf("priorityFrom = %v, want %v", p, PriorityBackground)
+	}
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NotMugil/hardcover-tui/internal/debug"
+)
+
+// trace collects one request attempt for the debug log. The client fills
+// in timing and the outcome; authTransport fills in what was sent.
+type trace struct {
+	req debug.Request
+}
+
+type traceKey struct{}
+
+// startTrace returns ctx carrying a new trace, or ctx unchanged and nil
+// when debugging is off.
+func startTrace(ctx context.Context, wait time.Duration) (context.Context, *trace) {
+	if !debug.Enabled() {
+		return ctx, nil
+	}
+	t := &trace{req: debug.Request{At: time.Now(), Wait: wait}}
+	return context.WithValue(ctx, traceKey{}, t), t
+}
+
+func traceFrom(ctx context.Context) *trace {
+	t, _ := ctx.Value(traceKey{}).(*trace)
+	return t
+}
+
+// finish logs the attempt with its outcome.
+func (t *trace) finish(err error) {
+	if t == nil {
+		return
+	}
+	t.req.Latency = time.Since(t.req.At)
+	if err != nil {
+		t.req.Err = err.Error()
+	}
+	debug.LogRequest(t.req)
+}
+
+// observe records the operation and variables of req and the status of
+// resp.
+func (t *trace) observe(req *http.Request, resp *http.Response) {
+	if resp != nil {
+		t.req.Status = resp.StatusCode
+	}
+	if req.GetBody == nil {
+		return
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return
+	}
+	var payload struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+	if json.Unmarshal(bytes.TrimSpace(data), &payload) == nil {
+		t.req.Operation = operationName(payload.Query)
+		t.req.Variables = redactVariables(payload.Variables)
+	}
+}
+
+// redactVariables replaces every string in vars, however deeply nested, by
+// its length. Strings carry what the user wrote, such as reviews and
+// journal entries, and the log gets attached to bug reports; IDs and other
+// numbers are kept.
+func redactVariables(vars map[string]any) map[string]any {
+	if vars == nil {
+		return nil
+	}
+	out := make(map[string]any, len(vars))
+	for k, v := range vars {
+		out[k] = redactValue(v)
+	}
+	return out
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("[%d chars]", len([]rune(v)))
+	case map[string]any:
+		return redactVariables(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = redactValue(e)
+		}
+		return out
+	}
+	return v
+}
+
+// operationName summarises a GraphQL document as its operation type and
+// first root field, e.g. "mutation update_user_book".
+func operationName(query string) string {
+	query = strings.TrimSpace(query)
+	kind := "query"
+	if strings.HasPrefix(query, "mutation") {
+		kind = "mutation"
+	}
+	i := strings.IndexByte(query, '{')
+	if i < 0 {
+		return kind
+	}
+	name, rest := identifier(query[i+1:])
+	if after, ok := strings.CutPrefix(rest, ":"); ok {
+		// The first name was an alias.
+		name, _ = identifier(after)
+	}
+	if name == "" {
+		return kind
+	}
+	return kind + " " + name
+}
+
+// identifier splits a leading GraphQL name off s, skipping whitespace
+// around it.
+func identifier(s string) (name, rest string) {
+	s = strings.TrimSpace(s)
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	if end < 0 {
+		end = len(s)
+	}
+	return s[:end], strings.TrimSpace(s[end:])
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+)
+
+// Environment variables that configure how the app reaches Hardcover.
+const (
+	// EnvEndpoint overrides the GraphQL endpoint, e.g. for a local mock server.
+	EnvEndpoint = "HARDCOVER_ENDPOINT"
+	// EnvProxy sets an explicit proxy URL for all requests. When unset the
+	// standard HTTPS_PROXY/HTTP_PROXY/NO_PROXY variables apply.
+	EnvProxy = "HARDCOVER_PROXY"
+	// EnvCABundle names a PEM file of extra CA certificates to trust in
+	// addition to the system pool.
+	EnvCABundle = "HARDCOVER_CA_BUNDLE"
+)
+
+var (
+	transportOnce sync.Once
+	transport     http.RoundTripper
+	transportErr  error
+)
+
+// Endpoint returns the GraphQL endpoint, honouring HARDCOVER_ENDPOINT.
+func Endpoint() string {
+	if v := os.Getenv(EnvEndpoint); v != "" {
+		return v
+	}
+	return graphqlEndpoint
+}
+
+// Transport returns the HTTP transport shared by API and image requests,
+// configured from the environment. If the configuration is invalid it
+// falls back to http.DefaultTransport; call CheckConfig to surface the error.
+func Transport() http.RoundTripper {
+	transportOnce.Do(func() {
+		transport, transportErr = newTransport()
+		if transportErr != nil {
+			transport = http.DefaultTransport
+		}
+	})
+	return transport
+}
+
+// CheckConfig validates the endpoint, proxy and CA bundle settings.
+func CheckConfig() error {
+	if v := os.Getenv(EnvEndpoint); v != "" {
+		if u, err := url.Parse(v); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("%s: invalid URL %q", EnvEndpoint, v)
+		}
+	}
+	Transport()
+	return transportErr
+}
+
+func newTransport() (http.RoundTripper, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if v := os.Getenv(EnvProxy); v != "" {
+		proxyURL, err := url.Parse(v)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("%s: invalid proxy URL %q", EnvProxy, v)
+		}
+		t.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if path := os.Getenv(EnvCABundle); path != "" {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", EnvCABundle, err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no certificates found in %s", EnvCABundle, path)
+		}
+		t.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return t, nil
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -48,6 +50,43 @@ func AllStatuses() []StatusID {
 	}
 }
 
+// Slug returns the status as a single lowercase word, as typed in
+// commands: "want-to-read", "reading", "read", "paused", "dnf", "ignored".
+func (s StatusID) Slug() string {
+	switch s {
+	case StatusWantToRead:
+		return "want-to-read"
+	case StatusCurrentlyReading:
+		return "reading"
+	case StatusRead:
+		return "read"
+	case StatusPaused:
+		return "paused"
+	case StatusDidNotFinish:
+		return "dnf"
+	case StatusIgnored:
+		return "ignored"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseStatus returns the status named by s, which may be its slug or
+// its full name in any case.
+func ParseStatus(s string) (StatusID, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, st := range AllStatuses() {
+		name := strings.ToLower(st.String())
+		if s == st.Slug() || s == name || s == strings.ReplaceAll(name, " ", "-") {
+			return st, true
+		}
+	}
+	if s == "want" {
+		return StatusWantToRead, true
+	}
+	return 0, false
+}
+
 // PrivacySettingID represents privacy levels.
 type PrivacySettingID int
 
@@ -119,6 +158,7 @@ type Book struct {
 	HasAudiobook   bool           `json:"-"`
 	HasEbook       bool           `json:"-"`
 	Genres         []TagItem      `json:"-"`
+	BookSeries     []BookSeries   `json:"book_series,omitempty"` // only fetched by GetBookByID
 }
 
 // FormatIndicator returns a short string indicating available formats.
@@ -169,6 +209,87 @@ func (b Book) CoverURL() string {
 	return ""
 }
 
+// Series is a series of books, like a trilogy or a cycle.
+type Series struct {
+	ID                int    `json:"id" graphql:"id"`
+	Name              string `json:"name" graphql:"name"`
+	Slug              string `json:"slug" graphql:"slug"`
+	BooksCount        int    `json:"books_count" graphql:"books_count"`
+	PrimaryBooksCount int    `json:"primary_books_count" graphql:"primary_books_count"`
+}
+
+// Total returns how many books the series has. Hardcover leaves novellas
+// and other in-between entries out of the primary count when it has one.
+func (s Series) Total() int {
+	if s.PrimaryBooksCount > 0 {
+		return s.PrimaryBooksCount
+	}
+	return s.BooksCount
+}
+
+// BookSeries places a book in a series. Position is nil for entries that
+// are not numbered, such as companions and box sets.
+type BookSeries struct {
+	Position *float64 `json:"position" graphql:"position"`
+	Series   Series   `json:"series" graphql:"series"`
+}
+
+// SeriesEntry is a numbered book of a series with the user's status for
+// it, 0 if it is not in their library.
+type SeriesEntry struct {
+	Position float64
+	Book     Book
+	StatusID int
+}
+
+// SeriesDetail is a series with its numbered books in order.
+type SeriesDetail struct {
+	Series
+	Entries []SeriesEntry
+}
+
+// Read returns how many of the series' books the user has read.
+func (s SeriesDetail) Read() int {
+	n := 0
+	for _, e := range s.Entries {
+		if StatusID(e.StatusID) == StatusRead {
+			n++
+		}
+	}
+	return n
+}
+
+// Started reports whether the user has read, is reading or has paused any
+// book of the series.
+func (s SeriesDetail) Started() bool {
+	for _, e := range s.Entries {
+		switch StatusID(e.StatusID) {
+		case StatusCurrentlyReading, StatusRead, StatusPaused:
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the first book of the series the user has yet to read.
+// Books they gave up on or ignored are passed over.
+func (s SeriesDetail) Next() (SeriesEntry, bool) {
+	for _, e := range s.Entries {
+		switch StatusID(e.StatusID) {
+		case StatusRead, StatusDidNotFinish, StatusIgnored:
+			continue
+		}
+		return e, true
+	}
+	return SeriesEntry{}, false
+}
+
+// FormatPosition writes a series position as Hardcover shows it, as in
+// "3" or "2.5".
+func FormatPosition(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
+}
+
 // UserBookRead represents a single read-through of a book.
 type UserBookRead struct {
 	ID              int     `json:"id" graphql:"id"`
@@ -275,17 +396,85 @@ type ListBook struct {
 
 // ReadingJournal represents a reading journal entry.
 type ReadingJournal struct {
-	ID               int     `json:"id" graphql:"id"`
-	Event            string  `json:"event" graphql:"event"`
-	Entry            *string `json:"entry" graphql:"entry"`
-	ActionAt         string  `json:"action_at" graphql:"action_at"`
-	BookID           *int    `json:"book_id" graphql:"book_id"`
-	EditionID        *int    `json:"edition_id" graphql:"edition_id"`
-	PrivacySettingID int     `json:"privacy_setting_id" graphql:"privacy_setting_id"`
-	LikesCount       int     `json:"likes_count" graphql:"likes_count"`
-	CreatedAt        string  `json:"created_at" graphql:"created_at"`
-	UpdatedAt        string  `json:"updated_at" graphql:"updated_at"`
-	Book             *Book   `json:"book" graphql:"book"`
+	ID               int             `json:"id" graphql:"id"`
+	Event            string          `json:"event" graphql:"event"`
+	Entry            *string         `json:"entry" graphql:"entry"`
+	ActionAt         string          `json:"action_at" graphql:"action_at"`
+	BookID           *int            `json:"book_id" graphql:"book_id"`
+	EditionID        *int            `json:"edition_id" graphql:"edition_id"`
+	PrivacySettingID int             `json:"privacy_setting_id" graphql:"privacy_setting_id"`
+	LikesCount       int             `json:"likes_count" graphql:"likes_count"`
+	CreatedAt        string          `json:"created_at" graphql:"created_at"`
+	UpdatedAt        string          `json:"updated_at" graphql:"updated_at"`
+	Metadata         json.RawMessage `json:"metadata" graphql:"metadata"`
+	Book             *Book           `json:"book" graphql:"book"`
+}
+
+// Journal event types stored in reading_journals.event. Status changes are
+// recorded as "status_" followed by the status slug, see StatusEvent.
+const (
+	JournalEventNote     = "note"
+	JournalEventQuote    = "quote"
+	JournalEventProgress = "progress_updated"
+	journalStatusPrefix  = "status_"
+)
+
+// JournalMetadata holds the structured payload of typed journal entries.
+type JournalMetadata struct {
+	Page     *int     `json:"page,omitempty"`
+	Pages    *int     `json:"pages,omitempty"`
+	Percent  *float64 `json:"percent,omitempty"`
+	StatusID *int     `json:"status_id,omitempty"`
+}
+
+// GetGraphQLType lets JournalMetadata be passed as a jsonb variable.
+func (JournalMetadata) GetGraphQLType() string { return "jsonb" }
+
+// JournalStatusEvent returns the journal event name for a status change.
+func JournalStatusEvent(s StatusID) string {
+	switch s {
+	case StatusWantToRead:
+		return journalStatusPrefix + "want_to_read"
+	case StatusCurrentlyReading:
+		return journalStatusPrefix + "currently_reading"
+	case StatusRead:
+		return journalStatusPrefix + "read"
+	case StatusPaused:
+		return journalStatusPrefix + "paused"
+	case StatusDidNotFinish:
+		return journalStatusPrefix + "did_not_finish"
+	case StatusIgnored:
+		return journalStatusPrefix + "ignored"
+	default:
+		return journalStatusPrefix + "updated"
+	}
+}
+
+// ParseMetadata parses the JSON metadata field into a structured type.
+func (j ReadingJournal) ParseMetadata() JournalMetadata {
+	var m JournalMetadata
+	if len(j.Metadata) > 0 {
+		_ = json.Unmarshal(j.Metadata, &m)
+	}
+	return m
+}
+
+// IsStatusEvent reports whether the entry records a reading status change.
+func (j ReadingJournal) IsStatusEvent() bool {
+	return strings.HasPrefix(j.Event, journalStatusPrefix)
+}
+
+// Status returns the status recorded by a status change entry.
+func (j ReadingJournal) Status() StatusID {
+	if m := j.ParseMetadata(); m.StatusID != nil {
+		return StatusID(*m.StatusID)
+	}
+	for _, s := range AllStatuses() {
+		if JournalStatusEvent(s) == j.Event {
+			return s
+		}
+	}
+	return 0
 }
 
 // Goal represents a reading goal.
@@ -423,6 +612,16 @@ func (u ActivityUser) DisplayName() string {
 	return u.Username
 }
 
+// Dashboard is what the Home screen shows on startup, fetched in one
+// request.
+type Dashboard struct {
+	User         *User
+	Books        []UserBook // one page, filtered by status if requested
+	Reading      []UserBook
+	StatusCounts map[StatusID]int
+	Activities   []Activity
+}
+
 // UserBookAggregate represents aggregate stats for user books.
 type UserBookAggregate struct {
 	Aggregate struct {
@@ -438,6 +637,7 @@ type BookReview struct {
 	ID                int        `json:"id"`
 	Rating            *float64   `json:"rating"`
 	Review            *string    `json:"review"`
+	ReviewHTML        *string    `json:"review_html"`
 	ReviewHasSpoilers bool       `json:"review_has_spoilers"`
 	LikesCount        int        `json:"likes_count"`
 	CreatedAt         string     `json:"created_at"`
@@ -486,3 +686,146 @@ type ReadingHistoryEntry struct {
 	Pages         int    // number of pages read/listened
 	EditionFormat string // "physical", "ebook", "audiobook", etc.
 }
+
+// SearchType is the kind of entity returned by the search API.
+type SearchType string
+
+const (
+	SearchBooks   SearchType = "Book"
+	SearchAuthors SearchType = "Author"
+	SearchSeries  SearchType = "Series"
+	SearchLists   SearchType = "List"
+	SearchUsers   SearchType = "User"
+)
+
+// AllSearchTypes returns the searchable entity types in tab order.
+func AllSearchTypes() []SearchType {
+	return []SearchType{SearchBooks, SearchAuthors, SearchSeries, SearchLists, SearchUsers}
+}
+
+// SearchResult is a single non-book search hit (author, series, list or user).
+type SearchResult struct {
+	ID         int
+	Name       string
+	Slug       string
+	Detail     string // author of a series, owner of a list, display name of a user
+	BooksCount int
+	Count      int // likes for lists, followers for users
+}
+
+// SearchPage is one page of search results. Books is set for book searches,
+// Results for every other type.
+type SearchPage struct {
+	Type    SearchType
+	Page    int
+	PerPage int
+	Found   int
+	Books   []Book
+	Results []SearchResult
+}
+
+// Len returns the number of hits on the page.
+func (p SearchPage) Len() int {
+	if p.Type == SearchBooks {
+		return len(p.Books)
+	}
+	return len(p.Results)
+}
+
+// TotalPages returns the number of pages available for the search.
+func (p SearchPage) TotalPages() int {
+	if p.PerPage <= 0 || p.Found <= 0 {
+		return 1
+	}
+	return (p.Found + p.PerPage - 1) / p.PerPage
+}
+
+// BookFormat restricts book search results to a format.
+type BookFormat int
+
+const (
+	FormatAny BookFormat = iota
+	FormatAudiobook
+	FormatEbook
+)
+
+func (f BookFormat) String() string {
+	switch f {
+	case FormatAudiobook:
+		return "Audiobook"
+	case FormatEbook:
+		return "Ebook"
+	default:
+		return "Any"
+	}
+}
+
+// SearchFilters narrows book search results. Zero values mean "no limit".
+// The search API has no filter arguments, so these apply to fetched hits.
+type SearchFilters struct {
+	YearFrom  int
+	YearTo    int
+	PagesMin  int
+	PagesMax  int
+	MinRating float64
+	Format    BookFormat
+	Genre     string
+}
+
+// Active reports whether any filter is set.
+func (f SearchFilters) Active() bool {
+	return f != SearchFilters{}
+}
+
+// Matches reports whether a book passes every filter.
+func (f SearchFilters) Matches(b Book) bool {
+	if f.YearFrom > 0 || f.YearTo > 0 {
+		if b.ReleaseYear == nil {
+			return false
+		}
+		if f.YearFrom > 0 && *b.ReleaseYear < f.YearFrom {
+			return false
+		}
+		if f.YearTo > 0 && *b.ReleaseYear > f.YearTo {
+			return false
+		}
+	}
+	if f.PagesMin > 0 || f.PagesMax > 0 {
+		if b.Pages == nil {
+			return false
+		}
+		if f.PagesMin > 0 && *b.Pages < f.PagesMin {
+			return false
+		}
+		if f.PagesMax > 0 && *b.Pages > f.PagesMax {
+			return false
+		}
+	}
+	if f.MinRating > 0 && (b.Rating == nil || *b.Rating < f.MinRating) {
+		return false
+	}
+	switch f.Format {
+	case FormatAudiobook:
+		if !b.HasAudiobook {
+			return false
+		}
+	case FormatEbook:
+		if !b.HasEbook {
+			return false
+		}
+	}
+	if f.Genre != "" {
+		genre := strings.ToLower(f.Genre)
+		found := false
+		for _, g := range b.Genres {
+			if strings.Contains(strings.ToLower(g.Name), genre) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+package api
+
+import "testing"
+
+func TestSearchFiltersMatches(t *testing.T) {
+	year := func(y int) *int { return &y }
+	rating := func(r float64) *float64 { return &r }
+	book := Book{
+		ReleaseYear:  year(1965),
+		Pages:        year(412),
+		Rating:       rating(4.3),
+		HasAudiobook: true,
+		Genres:       []TagItem{{Name: "Science Fiction"}, {Name: "Classics"}},
+	}
+	tests := []struct {
+		name    string
+		filters SearchFilters
+		book    Book
+		want    bool
+	}{
+		{"no filters", SearchFilters{}, book, true},
+		{"no filters, bare book", SearchFilters{}, Book{}, true},
+		{"year in range", SearchFilters{YearFrom: 1960, YearTo: 1970}, book, true},
+		{"year on the bounds", SearchFilters{YearFrom: 1965, YearTo: 1965}, book, true},
+		{"year too early", SearchFilters{YearFrom: 1970}, book, false},
+		{"year too late", SearchFilters{YearTo: 1960}, book, false},
+		{"year unknown", SearchFilters{YearFrom: 1900}, Book{}, false},
+		{"pages in range", SearchFilters{PagesMin: 300, PagesMax: 500}, book, true},
+		{"too few pages", SearchFilters{PagesMin: 500}, book, false},
+		{"too many pages", SearchFilters{PagesMax: 300}, book, false},
+		{"pages unknown", SearchFilters{PagesMax: 300}, Book{}, false},
+		{"rating high enough", SearchFilters{MinRating: 4}, book, true},
+		{"rating too low", SearchFilters{MinRating: 4.5}, book, false},
+		{"rating unknown", SearchFilters{MinRating: 1}, Book{}, false},
+		{"audiobook", SearchFilters{Format: FormatAudiobook}, book, true},
+		{"no ebook", SearchFilters{Format: FormatEbook}, book, false},
+		{"genre substring, any case", SearchFilters{Genre: "science"}, book, true},
+		{"genre missing", SearchFilters{Genre: "romance"}, book, false},
+		{"every filter", SearchFilters{YearFrom: 1965, PagesMin: 400, MinRating: 4, Format: FormatAudiobook, Genre: "classic"}, book, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filters.Matches(tt.book); got != tt.want {
+				t.Errorf("Matches = %v, want %v", got, tt.want)
+			}
+			if active := tt.filters.Active(); active != (tt.filters != SearchFilters{}) {
+				t.Errorf("Active = %v", active)
+			}
+		})
+	}
+}
+
+func TestSearchPageTotalPages(t *testing.T) {
+	tests := []struct {
+		perPage, found, want int
+	}{
+		{20, 0, 1},
+		{20, 1, 1},
+		{20, 20, 1},
+		{20, 21, 2},
+		{25, 100, 4},
+		{0, 100, 1},
+	}
+	for _, tt := range tests {
+		p := SearchPage{PerPage: tt.perPage, Found: tt.found}
+		if got := p.TotalPages(); got != tt.want {
+			t.Errorf("TotalPages(per page %d, found %d) = %d, want %d", tt.perPage, tt.found, got, tt.want)
+		}
+	}
+}
+
+func TestSearchPageLen(t *testing.T) {
+	books := SearchPage{Type: SearchBooks, Books: make([]Book, 3), Results: make([]SearchResult, 1)}
+	if books.Len() != 3 {
+		t.Errorf("book page Len = %d, want 3", books.Len())
+	}
+	authors := SearchPage{Type: SearchAuthors, Books: make([]Book, 3), Results: make([]SearchResult, 1)}
+	if authors.Len() != 1 {
+		t.Errorf("author page Len = %d, want 1", authors.Len())
+	}
+}
+
+// series builds a series whose books have the given statuses, numbered
+// from 1, with book IDs 1, 2, ...
+func series(statuses ...StatusID) SeriesDetail {
+	var d SeriesDetail
+	for i, s := range statuses {
+		d.Entries = append(d.Entries, SeriesEntry{
+			Position: float64(i + 1),
+			Book:     Book{ID: i + 1},
+			StatusID: int(s),
+		})
+	}
+	return d
+}
+
+func TestSeriesDetailNext(t *testing.T) {
+	const none StatusID = 0
+	tests := []struct {
+		name     string
+		series   SeriesDetail
+		wantBook int // 0 when there is no next book
+	}{
+		{"empty", series(), 0},
+		{"nothing read", series(none, none), 1},
+		{"first read", series(StatusRead, none, none), 2},
+		{"reading the second", series(StatusRead, StatusCurrentlyReading, none), 2},
+		{"paused counts as unread", series(StatusRead, StatusPaused), 2},
+		{"want to read counts as unread", series(StatusWantToRead, StatusRead), 1},
+		{"gaps are next", series(StatusRead, none, StatusRead), 2},
+		{"did not finish is passed over", series(StatusRead, StatusDidNotFinish, none), 3},
+		{"ignored is passed over", series(StatusIgnored, StatusRead, StatusWantToRead), 3},
+		{"all read", series(StatusRead, StatusRead), 0},
+		{"read or given up", series(StatusRead, StatusDidNotFinish, StatusIgnored), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, ok := tt.series.Next()
+			if ok != (tt.wantBook != 0) {
+				t.Fatalf("Next() ok = %v, want %v", ok, tt.wantBook != 0)
+			}
+			if ok && next.Book.ID != tt.wantBook {
+				t.Errorf("Next() = book %d, want %d", next.Book.ID, tt.wantBook)
+			}
+		})
+	}
+}
+
+func TestSeriesDetailReadAndStarted(t *testing.T) {
+	tests := []struct {
+		series  SeriesDetail
+		read    int
+		started bool
+	}{
+		{series(), 0, false},
+		{series(StatusWantToRead, 0), 0, false},
+		{series(StatusDidNotFinish, StatusIgnored), 0, false},
+		{series(StatusCurrentlyReading, 0), 0, true},
+		{series(StatusPaused), 0, true},
+		{series(StatusRead, StatusRead, 0), 2, true},
+	}
+	for i, tt := range tests {
+		if got := tt.series.Read(); got != tt.read {
+			t.Errorf("%d: Read() = %d, want %d", i, got, tt.read)
+		}
+		if got := tt.series.Started(); got != tt.started {
+			t.Errorf("%d: Started() = %v, want %v", i, got, tt.started)
+		}
+	}
+}
+
+func TestFormatPosition(t *testing.T) {
+	for p, want := range map[float64]string{1: "1", 12: "12", 2.5: "2.5", 0.5: "0.5"} {
+		if got := FormatPosition(p); got != want {
+			t.Errorf("FormatPosition(%v) = %q, want %q", p, got, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/kevm/bubbleo/navstack"
@@ -17,9 +19,10 @@ import (
 	"go.dalton.dog/bubbleup"
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
-	"github.com/NotMugil/hardcover-tui/internal/api/queries"
 	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/debug"
 	"github.com/NotMugil/hardcover-tui/internal/keystore"
+	"github.com/NotMugil/hardcover-tui/internal/service"
 	"github.com/NotMugil/hardcover-tui/internal/ui/bookdetail"
 	"github.com/NotMugil/hardcover-tui/internal/ui/home"
 	"github.com/NotMugil/hardcover-tui/internal/ui/journal"
@@ -27,8 +30,10 @@ import (
 	"github.com/NotMugil/hardcover-tui/internal/ui/progress"
 	"github.com/NotMugil/hardcover-tui/internal/ui/review"
 	"github.com/NotMugil/hardcover-tui/internal/ui/search"
+	"github.com/NotMugil/hardcover-tui/internal/ui/series"
 	"github.com/NotMugil/hardcover-tui/internal/ui/setup"
 	"github.com/NotMugil/hardcover-tui/internal/ui/stats"
+	"github.com/NotMugil/hardcover-tui/internal/ui/timeline"
 )
 
 // Screen is an interface that all screens implement.
@@ -64,13 +69,17 @@ var navTabs = []navTab{
 	{"Search", "nav-search"},
 	{"Lists", "nav-lists"},
 	{"Stats", "nav-stats"},
+	{"Journal", "nav-journal"},
 }
 
 // Model is the root application model.
 type Model struct {
-	nav        *navstack.Model
+	nav        *navstack.Model // the active tab's stack
+	tabs       []tabState
+	maxAge     time.Duration // how long tab data stays fresh, 0 for ever
 	win        *window.Model
 	client     *api.Client
+	svc        service.HardcoverService
 	user       *api.User
 	spinner    spinner.Model
 	keys       common.KeyMap
@@ -78,6 +87,7 @@ type Model struct {
 	loading    bool
 	setupMode  bool
 	setupScr   Screen
+	reauthScr  Screen // token re-entry overlay shown after a 401
 	err        error
 	width      int
 	height     int
@@ -86,6 +96,29 @@ type Model struct {
 	alert      bubbleup.AlertModel
 	loader     common.Loader
 	tabLoading bool
+	token      string // preset API token; skips the keyring when set
+	debugOpen  bool   // developer overlay, only available with --debug
+	queueDepth int    // API requests waiting for a rate limit slot
+	started    time.Time
+	startupSet bool             // startup time has been logged
+	session    *session         // where the last run left off, if saved
+	deepLink   *common.DeepLink // what to open on launch instead of session
+	palette    *palette         // command palette, nil when closed
+	library    []api.UserBook
+	libraryAt  time.Time     // when library was fetched for the palette
+	cmdline    *commandLine  // ":" command line, nil when closed
+	cmdHistory []string      // command lines run, oldest first
+	undo       []common.Undo // changes that can be undone, oldest first
+
+	notifications []notification      // every notification shown, oldest first
+	center        *notificationCenter // notification center, nil when closed
+
+	// Opening or copying a link on hardcover.app.
+	linkPicker common.PickerState // which of several links, when open
+	links      []common.Link      // what linkPicker offers
+	linkURL    string             // link the open confirm is for
+
+	route *common.ScreenMsg // envelope of the message being handled, if addressed
 }
 
 // keyringCheckMsg is returned after checking the keyring for an API key.
@@ -96,8 +129,38 @@ type keyringCheckMsg struct {
 
 // userLoadedMsg is returned after fetching the user profile.
 type userLoadedMsg struct {
-	user *api.User
-	err  error
+	user      *api.User
+	dashboard *api.Dashboard // nil if only the profile could be loaded
+	filter    int            // status filter the dashboard's books match
+	err       error
+}
+
+// authExpiredMsg is returned when the client reports that the token was
+// rejected.
+type authExpiredMsg struct {
+	client *api.Client
+}
+
+// waitForAuthExpiry blocks until client reports an auth failure.
+func waitForAuthExpiry(client *api.Client) tea.Cmd {
+	return func() tea.Msg {
+		<-client.Unauthorized()
+		return authExpiredMsg{client: client}
+	}
+}
+
+// queueChangedMsg reports the client's rate limit queue depth.
+type queueChangedMsg struct {
+	client *api.Client
+	depth  int
+}
+
+// waitForQueueChange blocks until client's request queue grows or shrinks.
+func waitForQueueChange(client *api.Client) tea.Cmd {
+	return func() tea.Msg {
+		<-client.QueueChanged()
+		return queueChangedMsg{client: client, depth: client.QueueDepth()}
+	}
 }
 
 // New creates the root application model.
@@ -107,7 +170,7 @@ func New() Model {
 		spinner.WithStyle(common.SpinnerStyle),
 	)
 	w := window.New(120, 30, 0, 0)
-	n := navstack.New(&w)
+	tabs := newTabs(&w)
 
 	alertModel := bubbleup.NewAlertModel(50, false, 3*time.Second).
 		WithMinWidth(20).
@@ -120,20 +183,61 @@ func New() Model {
 		Prefix:    "\u2714",  // checkmark
 	})
 
+	keys := common.Keys
+	keys.Debug.SetEnabled(debug.Enabled())
+
 	return Model{
-		nav:       &n,
-		win:       &w,
-		spinner:   s,
-		keys:      common.Keys,
-		help:      common.NewHelp(),
-		loading:   true,
-		setupMode: true,
-		alert:     alertModel,
-		loader:    common.NewLoader(),
+		nav:        tabs[0].nav,
+		tabs:       tabs,
+		maxAge:     tabMaxAge(),
+		win:        &w,
+		spinner:    s,
+		keys:       keys,
+		help:       common.NewHelp(),
+		loading:    true,
+		setupMode:  true,
+		alert:      alertModel,
+		loader:     common.NewLoader(),
+		started:    time.Now(),
+		session:    loadSession(),
+		cmdHistory: loadCommandHistory(),
+
+		notifications: loadNotifications(),
 	}
 }
 
+// NewWithToken creates the root application model signed in with token.
+// The keyring is neither read nor written, which suits demo sessions.
+func NewWithToken(token string) Model {
+	m := New()
+	m.token = token
+	return m
+}
+
+// newSetup creates the token prompt. Sessions given their token keep any
+// token typed in out of the keyring too.
+func (m Model) newSetup() *setup.Model {
+	if m.token != "" {
+		return setup.New().WithoutSaving()
+	}
+	return setup.New()
+}
+
+// newReauth creates the prompt shown when the token stops working.
+func (m Model) newReauth() *setup.Model {
+	if m.token != "" {
+		return setup.NewReauth().WithoutSaving()
+	}
+	return setup.NewReauth()
+}
+
 func (m Model) Init() tea.Cmd {
+	if m.token != "" {
+		token := m.token
+		return tea.Batch(m.spinner.Tick, func() tea.Msg {
+			return keyringCheckMsg{apiKey: token}
+		})
+	}
 	return tea.Batch(m.spinner.Tick, checkKeyringCmd())
 }
 
@@ -145,11 +249,27 @@ func checkKeyringCmd() tea.Cmd {
 }
 
 func (m Model) loadUser() tea.Cmd {
-	client := m.client
+	svc := m.svc
+	// Fetch the books under the filter saved last session. If the session
+	// turns out to be another user's, Home loads again unfiltered.
+	var filter int
+	if m.session != nil {
+		filter = m.session.Home.Filter
+	}
 	return func() tea.Msg {
 		ctx, cancel := makeContext()
 		defer cancel()
-		user, err := queries.GetMe(ctx, client)
+		// The dashboard carries the user and everything Home shows, so
+		// startup needs one request. Fall back to the profile alone if it
+		// fails for any other reason than auth, and let Home load itself.
+		d, err := home.FetchDashboard(ctx, svc, filter)
+		if err == nil {
+			return userLoadedMsg{user: d.User, dashboard: d, filter: filter}
+		}
+		if errors.Is(err, api.ErrUnauthorized) {
+			return userLoadedMsg{err: err}
+		}
+		user, err := svc.GetMe(ctx)
 		return userLoadedMsg{user: user, err: err}
 	}
 }
@@ -169,71 +289,101 @@ func (m Model) pushScreen(title string, screen Screen) (Model, tea.Cmd) {
 		s.SetSize(m.width, m.contentHeight())
 	}
 	item := navstack.NavigationItem{Title: title, Model: screen}
-	cmd := m.nav.Push(item)
-	return m, cmd
+	t := &m.tabs[m.activeTab]
+	t.screens = append(t.screens, screen)
+	cmd := common.WithOrigin(m.nav.Push(item), m.origin(m.activeTab))
+	return m, common.ForScreen(cmd, screen)
 }
 
-// switchTab clears the navstack and pushes the given tab screen.
-func (m Model) switchTab(idx int) (Model, tea.Cmd) {
-	if idx == m.activeTab && len(m.nav.StackSummary()) == 1 {
-		cmd := m.nav.Update(navstack.ReloadCurrent{})
-		if top := m.nav.Top(); top != nil {
-			if s, ok := top.Model.(sizable); ok && m.width > 0 {
-				s.SetSize(m.width, m.contentHeight())
-			}
-		}
-		m.tabLoading = true
-		loaderCmd := m.loader.Start()
-		return m, tea.Batch(cmd, loaderCmd)
-	}
-	m.activeTab = idx
-	_ = m.nav.Clear()
-	screen := m.createTabScreen(idx)
-
-	if l, ok := screen.(loadable); ok && l.Loaded() {
-		return m.pushScreen(navTabs[idx].name, screen)
+// openSeriesScreen pushes a series' screen on the active tab, selecting
+// bookID.
+func (m Model) openSeriesScreen(seriesID int, name string, bookID int) (Model, tea.Cmd) {
+	screen := series.New(m.svc, m.user, seriesID, bookID)
+	nm, pushCmd := m.pushScreen(name, screen)
+	if !screen.Loaded() && !nm.tabLoading {
+		nm.tabLoading = true
+		return nm, tea.Batch(pushCmd, nm.loader.Start())
 	}
-
-	m.tabLoading = true
-	loaderCmd := m.loader.Start()
-	nm, pushCmd := m.pushScreen(navTabs[idx].name, screen)
-	return nm, tea.Batch(pushCmd, loaderCmd)
+	return nm, pushCmd
 }
 
 // createTabScreen instantiates a screen for the given tab index.
 func (m Model) createTabScreen(idx int) Screen {
 	switch idx {
 	case 0:
-		return home.New(m.client, m.user)
+		return home.New(m.svc, m.user)
 	case 1:
-		return search.New(m.client, m.user)
+		s := search.New(m.svc, m.user)
+		m.restoreTabScreen(s)
+		return s
 	case 2:
-		return lists.New(m.client, m.user)
+		l := lists.New(m.svc, m.user)
+		m.restoreTabScreen(l)
+		return l
 	case 3:
-		return stats.New(m.client, m.user)
+		return stats.New(m.svc, m.user)
+	case 4:
+		return timeline.New(m.svc, m.user)
 	default:
-		return home.New(m.client, m.user)
+		return home.New(m.svc, m.user)
 	}
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if r, ok := msg.(common.ScreenMsg); ok {
+		// Handle the message as usual, but hand it only to the screen
+		// that asked for it if the app does not handle it itself.
+		m.route = &r
+		updated, cmd := m.Update(r.Msg)
+		nm := updated.(Model)
+		nm.route = nil
+		return nm, cmd
+	}
+
 	var alertCmd tea.Cmd
 	outAlert, alertTickCmd := m.alert.Update(msg)
 	m.alert = outAlert.(bubbleup.AlertModel)
 	alertCmd = alertTickCmd
 
+	debug.LogMsg(msg)
+
 	switch msg := msg.(type) {
+	case debugTickMsg:
+		if m.debugOpen {
+			return m, debugTick()
+		}
+		return m, nil
+
 	case common.NotifyMsg:
+		m.logNotification(msg.Level, msg.Message, msg.Origin, msg.Retry)
 		alertKey := string(msg.Level)
-		newAlertCmd := m.alert.NewAlertCmd(alertKey, msg.Message)
+		text := msg.Message
+		if msg.Retry != nil {
+			text += " · Retry (!)"
+		}
+		newAlertCmd := m.alert.NewAlertCmd(alertKey, text)
+		return m, tea.Batch(alertCmd, newAlertCmd)
+
+	case common.UndoableMsg:
+		m.pushUndo(msg.Undo)
+		m.logNotification(msg.Level, msg.Message, msg.Origin, nil)
+		newAlertCmd := m.alert.NewAlertCmd(string(msg.Level), msg.Message+" · Undo (u)")
 		return m, tea.Batch(alertCmd, newAlertCmd)
 
+	case undoneMsg:
+		updated, cmd := m.undone(msg)
+		return updated, tea.Batch(alertCmd, cmd)
+
 	case common.LoaderFrameMsg:
 		if m.tabLoading {
 			if top := m.nav.Top(); top != nil {
 				if l, ok := top.Model.(loadable); ok && l.Loaded() {
 					m.tabLoading = false
 					m.loader.Stop()
+					if !m.startupSet {
+						m.startupSet = true
+						debug.LogStartup(time.Since(m.started))
+					}
 					return m, alertCmd
 				}
 			}
@@ -254,6 +404,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+		if s, ok := m.reauthScr.(sizable); ok {
+			s.SetSize(msg.Width, msg.Height)
+		}
 		if top := m.nav.Top(); top != nil {
 			if s, ok := top.Model.(sizable); ok {
 				s.SetSize(msg.Width, m.contentHeight())
@@ -265,11 +418,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil || msg.apiKey == "" {
 			m.loading = false
 			m.setupMode = true
-			s := setup.New()
+			s := m.newSetup()
 			m.setupScr = s
 			return m, s.Init()
 		}
 		m.client = api.NewClient(msg.apiKey)
+		m.svc = service.NewGraphQL(m.client)
 		return m, m.loadUser()
 
 	case userLoadedMsg:
@@ -277,22 +431,109 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			m.err = msg.err
 			m.setupMode = true
-			s := setup.New()
+			s := m.newSetup()
 			m.setupScr = s
 			return m, s.Init()
 		}
 		m.user = msg.user
 		m.setupMode = false
 		m.setupScr = nil
-		m.activeTab = 0
-		screen := home.New(m.client, m.user)
+		m.resetTabs()
+		m.tabs[0].loadedAt = time.Now()
+		var state home.State
+		if s := m.sessionFor(m.user.ID); s != nil {
+			state = s.Home
+		}
+		screen := home.New(m.svc, m.user)
+		if msg.dashboard != nil && msg.filter == state.Filter {
+			screen = home.NewFromDashboard(m.svc, msg.dashboard)
+		}
+		screen.Restore(state)
 		m.tabLoading = true
 		loaderCmd := m.loader.Start()
 		nm, pushCmd := m.pushScreen("Home", screen)
-		return nm, tea.Batch(pushCmd, loaderCmd)
+		var restoreCmd tea.Cmd
+		if nm.deepLink != nil {
+			nm, restoreCmd = nm.openDeepLink()
+		} else {
+			nm, restoreCmd = nm.restoreSession()
+		}
+		return nm, tea.Batch(pushCmd, loaderCmd, restoreCmd, waitForAuthExpiry(m.client), waitForQueueChange(m.client))
+
+	case gotoTabMsg:
+		return m.switchTab(msg.tab)
+
+	case deepLinkMsg:
+		return m.handleDeepLink(msg)
+
+	case refreshMsg:
+		return m.refreshTab()
+
+	case undoMsg:
+		return m.runUndo()
+
+	case notificationsMsg:
+		return m.openNotificationCenter()
+
+	case common.LinkMsg:
+		return m.handleLink(msg)
+
+	case quitMsg:
+		m.saveSession()
+		m.saveNotifications()
+		return m, tea.Quit
+
+	case libraryLoadedMsg:
+		if msg.err != nil {
+			return m, alertCmd
+		}
+		m.library = msg.books
+		m.libraryAt = time.Now()
+		if m.palette != nil {
+			m.palette.setEntries(m.paletteEntries())
+		}
+		return m, alertCmd
+
+	case queueChangedMsg:
+		if msg.client != m.client {
+			return m, alertCmd
+		}
+		m.queueDepth = msg.depth
+		return m, tea.Batch(alertCmd, waitForQueueChange(m.client))
+
+	case authExpiredMsg:
+		if msg.client != m.client || m.setupMode || m.reauthScr != nil {
+			return m, alertCmd
+		}
+		s := m.newReauth()
+		s.SetSize(m.width, m.height)
+		m.reauthScr = s
+		return m, tea.Batch(alertCmd, s.Init())
+
+	case setup.ReauthCancelledMsg:
+		m.reauthScr = nil
+		return m, tea.Batch(alertCmd, waitForAuthExpiry(m.client))
 
 	case setup.SetupCompleteMsg:
+		if m.reauthScr != nil {
+			// Keep the navstack and reload the current screen with the new token.
+			m.reauthScr = nil
+			m.client.SetToken(msg.Token)
+			select {
+			case <-m.client.Unauthorized(): // drop failures from the old token
+			default:
+			}
+			m.tabs[m.activeTab].loadedAt = time.Now()
+			m.invalidateTabs()
+			return m, tea.Batch(
+				alertCmd,
+				m.updateTab(m.activeTab, navstack.ReloadCurrent{}),
+				common.NotifyCmd(common.NotifySuccess, "Signed in again"),
+				waitForAuthExpiry(m.client),
+			)
+		}
 		m.client = api.NewClient(msg.Token)
+		m.svc = service.NewGraphQL(m.client)
 		m.loading = true
 		return m, tea.Batch(m.spinner.Tick, m.loadUser())
 
@@ -301,65 +542,64 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.UserBook != nil {
 			title = msg.UserBook.Book.Title
 		}
-		screen := bookdetail.NewFromUserBook(m.client, m.user, msg.UserBook)
-		nm, pushCmd := m.pushScreen(title, screen)
-		if !screen.Loaded() {
-			nm.tabLoading = true
-			loaderCmd := nm.loader.Start()
-			return nm, tea.Batch(pushCmd, loaderCmd)
-		}
-		return nm, pushCmd
+		return m.pushBookScreen(title, bookdetail.NewFromUserBook(m.svc, m.user, msg.UserBook))
 
 	case search.NavigateToBookMsg:
-		screen := bookdetail.NewFromBookID(m.client, m.user, msg.BookID)
+		screen := bookdetail.NewFromBookID(m.svc, m.user, msg.BookID)
 		if len(msg.Genres) > 0 {
 			screen.SetGenres(msg.Genres)
 		}
-		nm, pushCmd := m.pushScreen("Book", screen)
-		if !screen.Loaded() {
-			nm.tabLoading = true
-			loaderCmd := nm.loader.Start()
-			return nm, tea.Batch(pushCmd, loaderCmd)
-		}
-		return nm, pushCmd
+		return m.pushBookScreen("Book", screen)
+
+	case timeline.NavigateToBookMsg:
+		return m.openBookScreen(msg.BookID, "Book")
 
 	case lists.NavigateToBookFromListMsg:
 		entries := make([]bookdetail.ListBookEntry, len(msg.ListBooks))
 		for i, lb := range msg.ListBooks {
 			entries[i] = bookdetail.ListBookEntry{BookID: lb.BookID, Title: lb.Title}
 		}
-		screen := bookdetail.NewFromListBook(m.client, m.user, msg.BookID, entries, msg.ListIndex, msg.ListID, msg.ListName)
-		nm, pushCmd := m.pushScreen("Book", screen)
-		if !screen.Loaded() {
-			nm.tabLoading = true
-			loaderCmd := nm.loader.Start()
-			return nm, tea.Batch(pushCmd, loaderCmd)
+		screen := bookdetail.NewFromListBook(m.svc, m.user, msg.BookID, entries, msg.ListIndex, msg.ListID, msg.ListName)
+		return m.pushBookScreen("Book", screen)
+
+	case bookdetail.BookNotFoundMsg:
+		// A book reopened from the last session may have been removed
+		// from Hardcover since, and need not be on top of its tab.
+		if m.route == nil {
+			return m, alertCmd
+		}
+		if cmd, ok := m.removeScreen(m.route.Screen); ok {
+			return m, tea.Batch(alertCmd, cmd,
+				common.NotifyCmd(common.NotifyWarning, "That book no longer exists on Hardcover"))
 		}
-		return nm, pushCmd
+		return m, alertCmd
 
 	case bookdetail.NavigateToReviewMsg:
-		screen := review.New(m.client, m.user, msg.UserBook)
+		screen := review.New(m.svc, m.user, msg.UserBook)
 		return m.pushScreen("Review", screen)
 
 	case bookdetail.NavigateToProgressMsg:
-		screen := progress.New(m.client, m.user, msg.UserBook)
+		screen := progress.New(m.svc, m.user, msg.UserBook)
 		return m.pushScreen("Progress", screen)
 
 	case progress.NavigateBackMsg:
 		if len(m.nav.StackSummary()) > 1 {
-			cmd := m.nav.Pop()
-			if top := m.nav.Top(); top != nil {
-				if s, ok := top.Model.(sizable); ok {
-					s.SetSize(m.width, m.contentHeight())
-				}
-			}
-			return m, cmd
+			return m, m.popScreen()
 		}
 
 	case bookdetail.NavigateToJournalMsg:
-		screen := journal.New(m.client, m.user, msg.UserBook)
+		screen := journal.New(m.svc, m.user, msg.UserBook)
 		return m.pushScreen("Journal", screen)
 
+	case bookdetail.NavigateToSeriesMsg:
+		return m.openSeriesScreen(msg.SeriesID, msg.Name, msg.BookID)
+
+	case home.NavigateToSeriesMsg:
+		return m.openSeriesScreen(msg.SeriesID, msg.Name, msg.BookID)
+
+	case series.NavigateToBookMsg:
+		return m.openBookScreen(msg.BookID, msg.Title)
+
 	case spinner.TickMsg:
 		if m.loading {
 			var cmd tea.Cmd
@@ -367,8 +607,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 		if !m.setupMode {
-			cmd := m.nav.Update(msg)
-			return m, cmd
+			return m.updateScreens(msg)
 		}
 		return m, nil
 
@@ -382,22 +621,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		if !m.setupMode && !m.loading {
-			cmd := m.nav.Update(msg)
+			cmd := m.updateTab(m.activeTab, msg)
 			return m, cmd
 		}
 		return m, nil
 
 	case tea.KeyMsg:
 		if msg.String() == "ctrl+c" {
+			m.saveSession()
+			m.saveNotifications()
 			return m, tea.Quit
 		}
 
+		if key.Matches(msg, m.keys.Debug) {
+			m.debugOpen = !m.debugOpen
+			if m.debugOpen {
+				return m, debugTick()
+			}
+			return m, nil
+		}
+
 		if m.setupMode && m.setupScr != nil {
 			updated, cmd := m.setupScr.Update(msg)
 			m.setupScr = updated.(Screen)
 			return m, cmd
 		}
 
+		if m.reauthScr != nil {
+			updated, cmd := m.reauthScr.Update(msg)
+			m.reauthScr = updated.(Screen)
+			return m, cmd
+		}
+
 		if m.loading {
 			return m, nil
 		}
@@ -406,50 +661,92 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		if top := m.nav.Top(); top != nil {
-			if f, ok := top.Model.(inputFocusable); ok && f.InputFocused() {
-				cmd := m.nav.Update(msg)
-				return m, cmd
-			}
+		if m.palette != nil {
+			return m.updatePalette(msg)
+		}
+
+		if m.cmdline != nil {
+			return m.updateCommandLine(msg)
+		}
+
+		if m.center != nil {
+			return m.updateNotificationCenter(msg)
+		}
+
+		if m.linkPicker.Active {
+			return m.updateLinkPicker(msg)
 		}
 
+		// The confirm comes before the screen, which may have asked for
+		// it while taking every key.
 		if m.confirm.Active {
+			if m.confirm.Action == "open-link" && msg.String() == "c" {
+				m.confirm.Active = false
+				return m, common.CopyCmd(m.linkURL, "Link")
+			}
 			confirmed, _ := m.confirm.HandleKey(msg.String())
 			if !m.confirm.Active && confirmed {
 				switch m.confirm.Action {
+				case "open-link":
+					return m, common.OpenBrowserCmd(m.linkURL)
 				case "logout":
-					_ = keystore.Delete()
+					if m.token == "" {
+						_ = keystore.Delete()
+					}
 					m.client = nil
+					m.svc = nil
 					m.user = nil
+					m.session = nil
+					m.library = nil
+					m.undo = nil
+					m.reauthScr = nil
 					m.setupMode = true
-					s := setup.New()
+					s := m.newSetup()
 					s.SetSize(m.width, m.height)
 					m.setupScr = s
-					_ = m.nav.Clear()
+					m.resetTabs()
 					return m, s.Init()
 				}
 			}
 			return m, nil
 		}
 
+		if top := m.nav.Top(); top != nil {
+			if f, ok := top.Model.(inputFocusable); ok && f.InputFocused() {
+				cmd := m.updateTab(m.activeTab, msg)
+				return m, cmd
+			}
+		}
+
 		switch {
 		case key.Matches(msg, common.Keys.Quit):
+			m.saveSession()
+			m.saveNotifications()
 			return m, tea.Quit
 		case key.Matches(msg, common.Keys.Back):
 			if len(m.nav.StackSummary()) > 1 {
-				cmd := m.nav.Pop()
-				if top := m.nav.Top(); top != nil {
-					if s, ok := top.Model.(sizable); ok {
-						s.SetSize(m.width, m.contentHeight())
-					}
-				}
-				return m, cmd
+				return m, m.popScreen()
 			}
-			cmd := m.nav.Update(msg)
+			cmd := m.updateTab(m.activeTab, msg)
 			return m, cmd
 		case key.Matches(msg, common.Keys.Help):
 			m.help.ShowAll = !m.help.ShowAll
 			return m, nil
+		case key.Matches(msg, common.Keys.Refresh):
+			return m.refreshTab()
+		case key.Matches(msg, common.Keys.Undo):
+			return m.runUndo()
+		case key.Matches(msg, common.Keys.Palette):
+			return m.openPalette()
+		case key.Matches(msg, common.Keys.Notifications):
+			return m.openNotificationCenter()
+		case key.Matches(msg, common.Keys.OpenLink, common.Keys.CopyLink):
+			if links, ok := m.screenLinks(); ok {
+				return m.handleLink(common.LinkMsg{Links: links, Copy: key.Matches(msg, common.Keys.CopyLink)})
+			}
+		case key.Matches(msg, common.Keys.Command):
+			m.cmdline = newCommandLine(len(m.cmdHistory))
+			return m, tea.Batch(textinput.Blink, m.updateTab(m.activeTab, common.CommandLineOpenedMsg{}))
 		case key.Matches(msg, common.Keys.Logout):
 			m.confirm = common.NewConfirm("Are you sure you want to log out?", "logout")
 			return m, nil
@@ -461,6 +758,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.switchTab(2)
 		case key.Matches(msg, common.Keys.Stats):
 			return m.switchTab(3)
+		case key.Matches(msg, common.Keys.Journal):
+			return m.switchTab(4)
 		case key.Matches(msg, common.Keys.NextTab):
 			next := (m.activeTab + 1) % len(navTabs)
 			return m.switchTab(next)
@@ -469,7 +768,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.switchTab(prev)
 		}
 
-		cmd := m.nav.Update(msg)
+		cmd := m.updateTab(m.activeTab, msg)
 		return m, cmd
 	}
 
@@ -480,14 +779,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	if !m.loading {
-		cmd := m.nav.Update(msg)
-		return m, cmd
+		return m.updateScreens(msg)
 	}
 
 	return m, nil
 }
 
+// updateScreens hands a message the app does not handle to the screen it
+// is addressed to, wherever that screen is. Other messages go to the
+// active tab's top screen and the sign-in overlay.
+func (m Model) updateScreens(msg tea.Msg) (Model, tea.Cmd) {
+	if m.route != nil {
+		return m, m.updateOwner(*m.route)
+	}
+	var reauthCmd tea.Cmd
+	if m.reauthScr != nil {
+		var updated tea.Model
+		updated, reauthCmd = m.reauthScr.Update(msg)
+		m.reauthScr = updated.(Screen)
+	}
+	cmd := m.updateTab(m.activeTab, msg)
+	return m, tea.Batch(cmd, reauthCmd)
+}
+
 func (m Model) View() string {
+	start := time.Now()
+	output := m.render()
+	if m.debugOpen {
+		output = overlay.Composite(m.renderDebugOverlay(), output, overlay.Center, overlay.Center, 0, 0)
+	}
+	debug.LogRender(time.Since(start))
+	return output
+}
+
+// render draws the current frame without the developer overlay.
+func (m Model) render() string {
 	if m.loading {
 		return common.AppStyle.Render(
 			fmt.Sprintf("\n  %s Loading...\n", m.spinner.View()),
@@ -515,6 +841,9 @@ func (m Model) View() string {
 
 	status := m.renderStatusBar()
 	help := m.renderHelp()
+	if m.cmdline != nil {
+		help = m.renderCommandLine()
+	}
 
 	output := lipgloss.JoinVertical(lipgloss.Left,
 		nav,
@@ -529,6 +858,22 @@ func (m Model) View() string {
 		output = overlay.Composite(fg, output, overlay.Center, overlay.Center, 0, 0)
 	}
 
+	if m.palette != nil {
+		output = overlay.Composite(m.renderPalette(), output, overlay.Center, overlay.Top, 0, 3)
+	}
+
+	if m.center != nil {
+		output = overlay.Composite(m.renderNotificationCenter(), output, overlay.Center, overlay.Top, 0, 3)
+	}
+
+	if m.linkPicker.Active {
+		output = overlay.Composite(common.RenderPickerOverlay(m.linkPicker, 60), output, overlay.Center, overlay.Center, 0, 0)
+	}
+
+	if m.reauthScr != nil {
+		output = overlay.Composite(m.reauthScr.View(), output, overlay.Center, overlay.Center, 0, 0)
+	}
+
 	output = m.alert.Render(output)
 
 	return zone.Scan(output)
@@ -589,7 +934,14 @@ func (m Model) renderBreadcrumb() string {
 }
 
 func (m Model) renderStatusBar() string {
-	return ""
+	if m.queueDepth == 0 {
+		return ""
+	}
+	label := "1 request queued"
+	if m.queueDepth > 1 {
+		label = fmt.Sprintf("%d requests queued", m.queueDepth)
+	}
+	return lipgloss.NewStyle().Padding(0, 1).Render(common.HelpStyle.Render(label))
 }
 
 func (m Model) renderHelp() string {
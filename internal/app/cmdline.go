@@ -0,0 +1,342 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/storage"
+)
+
+const (
+	commandHistoryFile = "command_history.json"
+	maxCommandHistory  = 100
+)
+
+// commandLine is the vim-style ":" prompt shown in place of the help bar.
+type commandLine struct {
+	input   textinput.Model
+	histIdx int    // history entry shown, len(history) while editing
+	draft   string // line being typed before browsing history
+
+	// Tab completion cycles through matches for the last word.
+	matches  []string
+	matchIdx int
+	base     string // the line before the word being completed
+}
+
+// gotoTabMsg, refreshMsg, undoMsg, notificationsMsg and quitMsg carry out
+// the app's own commands.
+type (
+	gotoTabMsg       struct{ tab int }
+	refreshMsg       struct{}
+	undoMsg          struct{}
+	notificationsMsg struct{}
+	quitMsg          struct{}
+)
+
+func loadCommandHistory() []string {
+	var h []string
+	_ = storage.Load(commandHistoryFile, &h)
+	return h
+}
+
+func newCommandLine(historyLen int) *commandLine {
+	ti := textinput.New()
+	ti.Prompt = ":"
+	ti.Cursor.Style = common.CursorStyle
+	ti.Focus()
+	return &commandLine{input: ti, histIdx: historyLen}
+}
+
+// appCommands are available on every screen.
+func (m Model) appCommands() []common.Command {
+	tabNames := make([]string, len(navTabs))
+	for i, t := range navTabs {
+		tabNames[i] = strings.ToLower(t.name)
+	}
+	return []common.Command{
+		{
+			Name:  "goto",
+			Usage: "<tab>",
+			Help:  "switch to a tab",
+			Complete: func(args []string) []string {
+				if len(args) != 1 {
+					return nil
+				}
+				return common.CompletePrefix(args[0], tabNames)
+			},
+			Run: func(args []string) (tea.Cmd, error) {
+				if len(args) != 1 {
+					return nil, common.ErrUsage
+				}
+				for i, name := range tabNames {
+					if strings.EqualFold(args[0], name) || args[0] == fmt.Sprint(i+1) {
+						return func() tea.Msg { return gotoTabMsg{tab: i} }, nil
+					}
+				}
+				return nil, fmt.Errorf("unknown tab %q", args[0])
+			},
+		},
+		{
+			Name: "refresh",
+			Help: "reload the current screen",
+			Run: func([]string) (tea.Cmd, error) {
+				return func() tea.Msg { return refreshMsg{} }, nil
+			},
+		},
+		{
+			Name: "undo",
+			Help: "undo the last change",
+			Run: func([]string) (tea.Cmd, error) {
+				return func() tea.Msg { return undoMsg{} }, nil
+			},
+		},
+		{
+			Name: "notifications",
+			Help: "show past notifications",
+			Run: func([]string) (tea.Cmd, error) {
+				return func() tea.Msg { return notificationsMsg{} }, nil
+			},
+		},
+		{
+			Name: "quit",
+			Help: "quit the app",
+			Run: func([]string) (tea.Cmd, error) {
+				return func() tea.Msg { return quitMsg{} }, nil
+			},
+		},
+	}
+}
+
+// commands returns the current screen's commands followed by the app's.
+func (m Model) commands() []common.Command {
+	var cmds []common.Command
+	if top := m.nav.Top(); top != nil {
+		if c, ok := top.Model.(common.Commandable); ok {
+			for _, cmd := range c.Commands() {
+				run, screen := cmd.Run, top.Model
+				cmd.Run = func(args []string) (tea.Cmd, error) {
+					c, err := run(args)
+					return common.ForScreen(c, screen), err
+				}
+				cmds = append(cmds, cmd)
+			}
+		}
+	}
+	return append(cmds, m.appCommands()...)
+}
+
+// findCommand returns the command called name, or the only command whose
+// name starts with it, so ":prog 212" runs ":progress".
+func findCommand(cmds []common.Command, name string) (common.Command, bool) {
+	var found []common.Command
+	for _, c := range cmds {
+		if c.Name == name {
+			return c, true
+		}
+		if strings.HasPrefix(c.Name, name) {
+			found = append(found, c)
+		}
+	}
+	if len(found) == 1 {
+		return found[0], true
+	}
+	return common.Command{}, false
+}
+
+// updateCommandLine handles a key while the command line is open.
+func (m Model) updateCommandLine(msg tea.KeyMsg) (Model, tea.Cmd) {
+	c := m.cmdline
+	switch msg.String() {
+	case "esc":
+		m.cmdline = nil
+		return m, nil
+	case "enter":
+		line := strings.TrimSpace(c.input.Value())
+		m.cmdline = nil
+		if line == "" {
+			return m, nil
+		}
+		m.addCommandHistory(line)
+		return m, m.runCommandLine(line)
+	case "backspace":
+		if c.input.Value() == "" {
+			m.cmdline = nil
+			return m, nil
+		}
+	case "tab", "shift+tab":
+		m.completeCommandLine(msg.String() == "shift+tab")
+		return m, nil
+	case "up", "ctrl+p":
+		if c.histIdx > 0 {
+			if c.histIdx == len(m.cmdHistory) {
+				c.draft = c.input.Value()
+			}
+			c.histIdx--
+			c.input.SetValue(m.cmdHistory[c.histIdx])
+			c.input.CursorEnd()
+		}
+		c.matches = nil
+		return m, nil
+	case "down", "ctrl+n":
+		if c.histIdx < len(m.cmdHistory) {
+			c.histIdx++
+			if c.histIdx == len(m.cmdHistory) {
+				c.input.SetValue(c.draft)
+			} else {
+				c.input.SetValue(m.cmdHistory[c.histIdx])
+			}
+			c.input.CursorEnd()
+		}
+		c.matches = nil
+		return m, nil
+	}
+
+	c.matches = nil
+	var cmd tea.Cmd
+	c.input, cmd = c.input.Update(msg)
+	return m, cmd
+}
+
+// runCommandLine parses and runs line, reporting mistakes as
+// notifications.
+func (m Model) runCommandLine(line string) tea.Cmd {
+	words, err := common.ParseCommand(line)
+	if err != nil {
+		return common.NotifyCmd(common.NotifyError, err.Error())
+	}
+	if len(words) == 0 {
+		return nil
+	}
+	name := words[0]
+	if name == "q" {
+		name = "quit"
+	}
+	c, ok := findCommand(m.commands(), name)
+	if !ok {
+		return common.NotifyCmd(common.NotifyError, "Not a command here: "+words[0])
+	}
+	cmd, err := c.Run(words[1:])
+	if errors.Is(err, common.ErrUsage) {
+		return common.NotifyCmd(common.NotifyError, strings.TrimSpace("Usage: :"+c.Name+" "+c.Usage))
+	}
+	if err != nil {
+		return common.NotifyCmd(common.NotifyError, err.Error())
+	}
+	return cmd
+}
+
+// completeCommandLine replaces the last word with the next completion.
+// The first word completes to command names; later words ask the
+// command.
+func (m Model) completeCommandLine(reverse bool) {
+	c := m.cmdline
+	if len(c.matches) > 0 {
+		n := len(c.matches)
+		if reverse {
+			c.matchIdx = (c.matchIdx - 1 + n) % n
+		} else {
+			c.matchIdx = (c.matchIdx + 1) % n
+		}
+		c.input.SetValue(c.base + common.QuoteWord(c.matches[c.matchIdx]))
+		c.input.CursorEnd()
+		return
+	}
+
+	line := c.input.Value()
+	words, err := common.ParseCommand(line)
+	if err != nil {
+		// Complete inside an unfinished quoted word.
+		words, err = common.ParseCommand(line + `"`)
+		if err != nil {
+			return
+		}
+	}
+	if len(words) == 0 || strings.HasSuffix(line, " ") {
+		words = append(words, "")
+	}
+
+	cmds := m.commands()
+	var matches []string
+	if len(words) == 1 {
+		var names []string
+		for _, cmd := range cmds {
+			names = append(names, cmd.Name)
+		}
+		matches = common.CompletePrefix(words[0], names)
+	} else if cmd, ok := findCommand(cmds, words[0]); ok && cmd.Complete != nil {
+		matches = cmd.Complete(words[1:])
+	}
+	if len(matches) == 0 {
+		return
+	}
+
+	var base strings.Builder
+	for _, w := range words[:len(words)-1] {
+		base.WriteString(common.QuoteWord(w) + " ")
+	}
+	c.base = base.String()
+	c.matchIdx = 0
+	if len(matches) == 1 {
+		// A single match is accepted outright, ready for the next word.
+		c.input.SetValue(c.base + common.QuoteWord(matches[0]) + " ")
+		c.input.CursorEnd()
+		return
+	}
+	c.matches = matches
+	c.input.SetValue(c.base + common.QuoteWord(matches[0]))
+	c.input.CursorEnd()
+}
+
+// addCommandHistory records line, dropping an earlier copy of it.
+func (m *Model) addCommandHistory(line string) {
+	h := m.cmdHistory[:0:0]
+	for _, e := range m.cmdHistory {
+		if e != line {
+			h = append(h, e)
+		}
+	}
+	h = append(h, line)
+	if len(h) > maxCommandHistory {
+		h = h[len(h)-maxCommandHistory:]
+	}
+	m.cmdHistory = h
+	_ = storage.Save(commandHistoryFile, h)
+}
+
+// renderCommandLine draws the prompt with the completions being cycled,
+// or the usage of the command being typed.
+func (m Model) renderCommandLine() string {
+	c := m.cmdline
+	line := c.input.View()
+
+	var extra string
+	if len(c.matches) > 1 {
+		parts := make([]string, len(c.matches))
+		for i, s := range c.matches {
+			if i == c.matchIdx {
+				parts[i] = common.TitleStyle.Render(s)
+			} else {
+				parts[i] = common.HelpStyle.Render(s)
+			}
+		}
+		extra = strings.Join(parts, "  ")
+	} else if words, err := common.ParseCommand(c.input.Value()); err == nil && len(words) > 0 {
+		if cmd, ok := findCommand(m.commands(), words[0]); ok {
+			extra = common.HelpStyle.Render(strings.TrimSpace(":" + cmd.Name + " " + cmd.Usage + "  " + cmd.Help))
+		}
+	}
+
+	w := max(m.width, 40)
+	if extra != "" {
+		gap := max(w-lipgloss.Width(line)-lipgloss.Width(extra)-2, 2)
+		line += strings.Repeat(" ", gap) + extra
+	}
+	return lipgloss.NewStyle().Width(w).MaxWidth(w).PaddingLeft(1).Render(line)
+}
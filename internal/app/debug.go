@@ -0,0 +1,115 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/debug"
+)
+
+// debugOverlayRows is how many recent requests the overlay lists.
+const debugOverlayRows = 12
+
+// debugTickMsg refreshes the developer overlay while it is open.
+type debugTickMsg struct{}
+
+func debugTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return debugTickMsg{} })
+}
+
+// renderDebugOverlay shows recent API requests, rate limiter waits and
+// frame render timings.
+func (m Model) renderDebugOverlay() string {
+	w := min(max(m.width-8, 60), 110)
+	snap := debug.Recent()
+
+	var waits []time.Duration
+	for _, r := range snap.Requests {
+		waits = append(waits, r.Wait)
+	}
+
+	var b strings.Builder
+	b.WriteString(common.LabelStyle.Render("Limiter wait  ") +
+		common.ValueStyle.Render(durationSummary(waits)) + "\n")
+	b.WriteString(common.LabelStyle.Render("Render        ") +
+		common.ValueStyle.Render(durationSummary(snap.Renders)) + "\n")
+	startup := "-"
+	if snap.Startup > 0 {
+		startup = formatMillis(snap.Startup)
+	}
+	b.WriteString(common.LabelStyle.Render("Startup       ") +
+		common.ValueStyle.Render(startup) + "\n")
+	b.WriteString(common.LabelStyle.Render("Messages      ") +
+		common.ValueStyle.Render(fmt.Sprintf("%d", snap.Messages)) + "\n\n")
+
+	header := fmt.Sprintf("%-8s  %-34s  %6s  %7s  %6s  %s", "time", "operation", "wait", "latency", "status", "error")
+	b.WriteString(common.HelpStyle.Render(common.Truncate(header, w-4)) + "\n")
+
+	reqs := snap.Requests
+	if len(reqs) > debugOverlayRows {
+		reqs = reqs[len(reqs)-debugOverlayRows:]
+	}
+	if len(reqs) == 0 {
+		b.WriteString(common.HelpStyle.Render("No requests yet"))
+	}
+	for i := len(reqs) - 1; i >= 0; i-- {
+		r := reqs[i]
+		status := "-"
+		if r.Status != 0 {
+			status = fmt.Sprintf("%d", r.Status)
+		}
+		line := fmt.Sprintf("%-8s  %-34s  %6s  %7s  %6s  %s",
+			r.At.Format("15:04:05"),
+			common.Truncate(r.Operation, 34),
+			formatMillis(r.Wait),
+			formatMillis(r.Latency),
+			status,
+			r.Err,
+		)
+		line = common.Truncate(line, w-4)
+		if r.Err != "" {
+			b.WriteString(common.ErrorStyle.Render(line))
+		} else {
+			b.WriteString(common.ValueStyle.Render(line))
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		b.String(),
+		"",
+		common.HelpStyle.Render("f12 close"),
+	)
+	return common.RenderActivePanel("Debug", content, w)
+}
+
+// durationSummary formats the last, average and maximum of ds.
+func durationSummary(ds []time.Duration) string {
+	if len(ds) == 0 {
+		return "-"
+	}
+	var total time.Duration
+	for _, d := range ds {
+		total += d
+	}
+	return fmt.Sprintf("last %s  avg %s  max %s",
+		formatMillis(ds[len(ds)-1]),
+		formatMillis(total/time.Duration(len(ds))),
+		formatMillis(slices.Max(ds)),
+	)
+}
+
+func formatMillis(d time.Duration) string {
+	if d < 10*time.Millisecond {
+		return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
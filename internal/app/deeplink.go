@@ -0,0 +1,113 @@
+package app
+
+import (
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/ui/bookdetail"
+	"github.com/NotMugil/hardcover-tui/internal/ui/lists"
+	"github.com/NotMugil/hardcover-tui/internal/ui/profile"
+)
+
+// listsTab is the index of the Lists tab in navTabs.
+const listsTab = 2
+
+// deepLinkMsg carries the book or list a deep link's slug resolved to.
+type deepLinkMsg struct {
+	link common.DeepLink
+	book *api.Book
+	list *api.List
+	err  error
+}
+
+// Open makes the app start on link once signed in, instead of where the
+// last session left off.
+func (m Model) Open(link common.DeepLink) Model {
+	m.deepLink = &link
+	return m
+}
+
+// openDeepLink shows the deep link given at launch, looking slugs up
+// first. Home must already be shown, so back returns there.
+func (m Model) openDeepLink() (Model, tea.Cmd) {
+	link := *m.deepLink
+	m.deepLink = nil
+
+	owner := link.Username
+	if owner == "" || strings.EqualFold(owner, m.user.Username) {
+		owner = m.user.Username
+	}
+	switch {
+	case link.BookID != 0:
+		return m.openBookScreen(link.BookID, "Book")
+	case link.BookSlug != "":
+		// Looked up below.
+	case !link.IsList():
+		return m.openProfile(owner)
+	case owner != m.user.Username:
+		// The Lists tab only holds the user's own lists.
+		m, cmd := m.openProfile(owner)
+		return m, tea.Batch(cmd, common.NotifyCmd(common.NotifyWarning, "Only your own lists can be opened here"))
+	}
+
+	svc := m.svc
+	return m, func() tea.Msg {
+		ctx, cancel := makeContext()
+		defer cancel()
+		if link.IsList() {
+			l, err := svc.GetListBySlug(ctx, owner, link.ListSlug)
+			return deepLinkMsg{link: link, list: l, err: err}
+		}
+		b, err := svc.GetBookBySlug(ctx, link.BookSlug)
+		return deepLinkMsg{link: link, book: b, err: err}
+	}
+}
+
+// handleDeepLink opens what a deep link's slug resolved to.
+func (m Model) handleDeepLink(msg deepLinkMsg) (Model, tea.Cmd) {
+	switch {
+	case errors.Is(msg.err, api.ErrNotFound):
+		return m, common.NotifyCmd(common.NotifyWarning, "Nothing on Hardcover matches "+msg.link.String())
+	case msg.err != nil:
+		return m, common.NotifyCmd(common.NotifyError, "Could not open "+msg.link.String()+": "+msg.err.Error())
+	case msg.book != nil:
+		return m.openBookScreen(msg.book.ID, msg.book.Title)
+	}
+
+	m, cmd := m.switchTab(listsTab)
+	if root, ok := m.tabs[listsTab].screens[0].(*lists.Model); ok {
+		// Right after launch the tab has just been opened and its lists
+		// are still loading. Otherwise the list is selected on reload.
+		root.Restore(lists.State{ListID: msg.list.ID})
+	}
+	return m, cmd
+}
+
+// openBookScreen pushes a book's screen on the active tab.
+func (m Model) openBookScreen(bookID int, title string) (Model, tea.Cmd) {
+	return m.pushBookScreen(title, bookdetail.NewFromBookID(m.svc, m.user, bookID))
+}
+
+// pushBookScreen pushes screen on the active tab, showing the tab loader
+// until the book has loaded.
+func (m Model) pushBookScreen(title string, screen *bookdetail.Model) (Model, tea.Cmd) {
+	nm, pushCmd := m.pushScreen(title, screen)
+	if !screen.Loaded() && !nm.tabLoading {
+		nm.tabLoading = true
+		return nm, tea.Batch(pushCmd, nm.loader.Start())
+	}
+	return nm, pushCmd
+}
+
+// openProfile pushes a user's profile on the active tab.
+func (m Model) openProfile(username string) (Model, tea.Cmd) {
+	screen := profile.New(m.svc, m.user)
+	if username != m.user.Username {
+		screen = profile.NewForUser(m.svc, m.user, username)
+	}
+	return m.pushScreen("@"+username, screen)
+}
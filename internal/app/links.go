@@ -0,0 +1,63 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/common"
+)
+
+// screenLinks asks the top screen for the pages of what it shows. It
+// reports false if the screen has no links at all, so the key can go to
+// the screen instead.
+func (m Model) screenLinks() ([]common.Link, bool) {
+	top := m.nav.Top()
+	if top == nil {
+		return nil, false
+	}
+	l, ok := top.Model.(common.Linkable)
+	if !ok {
+		return nil, false
+	}
+	return l.Links(), true
+}
+
+// handleLink opens or copies one of msg's links, asking which first when
+// there are several.
+func (m Model) handleLink(msg common.LinkMsg) (Model, tea.Cmd) {
+	switch len(msg.Links) {
+	case 0:
+		return m, common.NotifyCmd(common.NotifyInfo, "Nothing here has a page on hardcover.app")
+	case 1:
+		return m.linkTo(msg.Links[0], msg.Copy)
+	}
+	labels := make([]string, len(msg.Links))
+	for i, l := range msg.Links {
+		labels[i] = l.Label
+	}
+	m.links = msg.Links
+	if msg.Copy {
+		m.linkPicker = common.NewPicker("Copy link", "copy-link", labels)
+	} else {
+		m.linkPicker = common.NewPicker("Open on hardcover.app", "open-link", labels)
+	}
+	return m, nil
+}
+
+// linkTo copies link, or asks before opening it in the browser.
+func (m Model) linkTo(link common.Link, copyLink bool) (Model, tea.Cmd) {
+	if copyLink {
+		return m, common.CopyCmd(link.URL, "Link")
+	}
+	m.linkURL = link.URL
+	m.confirm = common.NewConfirm("Open in browser?\n"+link.URL+"\n\nc: copy link instead", "open-link")
+	return m, nil
+}
+
+// updateLinkPicker handles a key while the link picker is open.
+func (m Model) updateLinkPicker(msg tea.KeyMsg) (Model, tea.Cmd) {
+	choice := m.linkPicker.HandleKey(msg.String())
+	if choice < 0 {
+		return m, nil
+	}
+	return m.linkTo(m.links[choice], m.linkPicker.Action == "copy-link")
+}
@@ -0,0 +1,247 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/storage"
+)
+
+// EnvNotificationHistory keeps the notification log between runs when set
+// to "1". Otherwise the log only lasts the session.
+const EnvNotificationHistory = "HARDCOVER_NOTIFICATION_HISTORY"
+
+const (
+	notificationsFile = "notifications.json"
+	maxNotifications  = 200
+	// notificationRows is how many notifications the center lists at once.
+	notificationRows = 12
+)
+
+// notification is one entry of the notification log.
+type notification struct {
+	At      time.Time          `json:"at"`
+	Level   common.NotifyLevel `json:"level"`
+	Message string             `json:"message"`
+	Origin  string             `json:"origin,omitempty"`
+	Retry   tea.Cmd            `json:"-"` // not kept between runs
+	Screen  tea.Model          `json:"-"` // screen that sent it, which gets the retry's result
+}
+
+// notificationCenter is the overlay that lists past notifications.
+type notificationCenter struct {
+	cursor   int  // selected entry, 0 being the newest
+	expanded bool // the selected entry's full text is shown
+}
+
+func keepNotifications() bool {
+	return os.Getenv(EnvNotificationHistory) == "1"
+}
+
+func loadNotifications() []notification {
+	if !keepNotifications() {
+		return nil
+	}
+	var n []notification
+	_ = storage.Load(notificationsFile, &n)
+	return n
+}
+
+// saveNotifications writes the log if it is kept between runs.
+func (m Model) saveNotifications() {
+	if keepNotifications() {
+		_ = storage.Save(notificationsFile, m.notifications)
+	}
+}
+
+// logNotification records a notification, dropping the oldest once the
+// log is full. Notifications not tagged by a screen are credited to the
+// one shown.
+func (m *Model) logNotification(level common.NotifyLevel, message, origin string, retry tea.Cmd) {
+	if origin == "" && m.nav.Top() != nil {
+		origin = m.origin(m.activeTab)
+	}
+	var screen tea.Model
+	if m.route != nil {
+		screen = m.route.Screen
+	}
+	m.notifications = append(m.notifications, notification{
+		At:      time.Now(),
+		Level:   level,
+		Message: message,
+		Origin:  origin,
+		Retry:   retry,
+		Screen:  screen,
+	})
+	if len(m.notifications) > maxNotifications {
+		m.notifications = m.notifications[len(m.notifications)-maxNotifications:]
+	}
+	if m.center != nil && len(m.notifications) > 1 {
+		// Keep the same entry selected as the new one is listed above it.
+		m.center.cursor = min(m.center.cursor+1, len(m.notifications)-1)
+	}
+}
+
+// origin names the screen on top of tab idx, as in "Home › Dune".
+func (m Model) origin(idx int) string {
+	return m.originAt(idx, len(m.tabs[idx].nav.StackSummary())-1)
+}
+
+// originAt names the screen at position pos of tab idx's stack.
+func (m Model) originAt(idx, pos int) string {
+	name := navTabs[idx].name
+	titles := m.tabs[idx].nav.StackSummary()
+	if pos < 0 || pos >= len(titles) || titles[pos] == "" || titles[pos] == name {
+		return name
+	}
+	return name + " › " + titles[pos]
+}
+
+// openNotificationCenter shows the log with the newest entry selected.
+func (m Model) openNotificationCenter() (Model, tea.Cmd) {
+	m.center = &notificationCenter{}
+	return m, nil
+}
+
+// selectedNotification returns the entry under the center's cursor.
+func (m Model) selectedNotification() (*notification, bool) {
+	i := len(m.notifications) - 1 - m.center.cursor
+	if i < 0 || i >= len(m.notifications) {
+		return nil, false
+	}
+	return &m.notifications[i], true
+}
+
+// updateNotificationCenter handles a key while the center is open.
+func (m Model) updateNotificationCenter(msg tea.KeyMsg) (Model, tea.Cmd) {
+	c := m.center
+	switch msg.String() {
+	case "esc", "q", "!":
+		if c.expanded {
+			c.expanded = false
+			return m, nil
+		}
+		m.center = nil
+	case "up", "k":
+		if c.cursor > 0 {
+			c.cursor--
+		}
+	case "down", "j":
+		if c.cursor < len(m.notifications)-1 {
+			c.cursor++
+		}
+	case "enter":
+		c.expanded = !c.expanded
+	case "c", "y":
+		if n, ok := m.selectedNotification(); ok {
+			return m, common.CopyCmd(n.Message, "Notification")
+		}
+	case "r":
+		n, ok := m.selectedNotification()
+		if !ok || n.Retry == nil {
+			return m, nil
+		}
+		// A retry that fails again logs a new entry with its own retry.
+		// Its result goes back to the screen that failed, which reports
+		// it even if it has been closed since.
+		retry := common.ForClosedScreen(n.Retry, n.Screen)
+		n.Retry = nil
+		m.center = nil
+		return m, retry
+	case "x":
+		m.notifications = nil
+		c.cursor = 0
+		c.expanded = false
+	}
+	return m, nil
+}
+
+// levelStyle colours a notification level like its toast.
+func levelStyle(level common.NotifyLevel) lipgloss.Style {
+	switch level {
+	case common.NotifyError:
+		return common.ErrorStyle
+	case common.NotifyWarning:
+		return common.BoldTextStyle.Foreground(common.ColorWarning)
+	case common.NotifySuccess:
+		return common.SuccessStyle
+	}
+	return common.BoldTextStyle.Foreground(common.ColorPrimary)
+}
+
+// renderNotificationCenter draws the log newest first, or the selected
+// entry in full.
+func (m Model) renderNotificationCenter() string {
+	c := m.center
+	w := min(max(m.width-10, 50), 100)
+	inner := w - 6
+	title := fmt.Sprintf("Notifications (%d)", len(m.notifications))
+
+	if n, ok := m.selectedNotification(); ok && c.expanded {
+		var b strings.Builder
+		b.WriteString(levelStyle(n.Level).Render(string(n.Level)))
+		b.WriteString(common.ValueStyle.Render("  " + n.At.Format("Jan 2 15:04:05")))
+		if n.Origin != "" {
+			b.WriteString(common.ValueStyle.Render("  " + n.Origin))
+		}
+		b.WriteString("\n\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(common.ColorText).Width(inner).Render(n.Message))
+		b.WriteString("\n\n")
+		help := "c: copy • enter/esc: back"
+		if n.Retry != nil {
+			help = "r: retry • " + help
+		}
+		b.WriteString(common.HelpStyle.Render(help))
+		return common.RenderActivePanel(title, b.String(), w)
+	}
+
+	var b strings.Builder
+	if len(m.notifications) == 0 {
+		b.WriteString(common.HelpStyle.Render("No notifications yet"))
+		b.WriteString("\n")
+	}
+	start := 0
+	if c.cursor >= notificationRows {
+		start = c.cursor - notificationRows + 1
+	}
+	end := min(start+notificationRows, len(m.notifications))
+	for i := start; i < end; i++ {
+		n := m.notifications[len(m.notifications)-1-i]
+		b.WriteString(renderNotificationRow(n, i == c.cursor, inner))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(common.HelpStyle.Render("↑/↓ select • enter: details • c: copy • r: retry • x: clear • esc: close"))
+	return common.RenderActivePanel(title, b.String(), w)
+}
+
+// renderNotificationRow draws one entry on a line: time, level, origin and
+// as much of the message as fits. ↻ marks entries that can be retried.
+func renderNotificationRow(n notification, selected bool, w int) string {
+	base := common.ValueStyle
+	if selected {
+		base = lipgloss.NewStyle().Foreground(common.ColorText).Background(common.ColorHighlight)
+	}
+	stamp := n.At.Format("15:04:05")
+	if time.Since(n.At) > 24*time.Hour {
+		stamp = n.At.Format("Jan 02  ")
+	}
+	retry := "  "
+	if n.Retry != nil {
+		retry = "↻ "
+	}
+	level := levelStyle(n.Level).Inherit(base).Render(fmt.Sprintf("%-7s", n.Level))
+	origin := common.Truncate(n.Origin, w/4)
+	prefix := base.Render(stamp+"  ") + level + base.Render(retry)
+	msgW := max(w-lipgloss.Width(prefix)-lipgloss.Width(origin)-2, 8)
+	message := common.Truncate(strings.Join(strings.Fields(n.Message), " "), msgW)
+	gap := max(w-lipgloss.Width(prefix)-lipgloss.Width(message)-lipgloss.Width(origin), 1)
+	return prefix + base.Foreground(common.ColorText).Render(message) +
+		base.Render(strings.Repeat(" ", gap)) + base.Foreground(common.ColorMuted).Render(origin)
+}
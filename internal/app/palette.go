@@ -0,0 +1,350 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/ui/home"
+)
+
+const (
+	// paletteRows is how many matches the palette lists at once.
+	paletteRows = 10
+	// paletteBookPage is how many library books the palette fetches per
+	// request while paging through the library.
+	paletteBookPage = 100
+)
+
+type paletteKind int
+
+const (
+	paletteAction paletteKind = iota // press a key on the current screen
+	paletteTab                       // switch to a tab
+	paletteBook                      // open a book from the library
+)
+
+// paletteEntry is one thing the palette can do.
+type paletteEntry struct {
+	kind  paletteKind
+	title string // text matched against the query
+	hint  string // key or author shown beside the title
+	key   string // key pressed for actions
+	tab   int
+	book  api.UserBook
+}
+
+// palette is the ctrl+k overlay that finds and runs actions, tabs and
+// library books by fuzzy search.
+type palette struct {
+	input   textinput.Model
+	entries []paletteEntry
+	matches fuzzy.Matches
+	cursor  int
+}
+
+// paletteEntries lets fuzzy search the entry titles.
+type paletteEntries []paletteEntry
+
+func (e paletteEntries) String(i int) string { return e[i].title }
+func (e paletteEntries) Len() int            { return len(e) }
+
+// libraryLoadedMsg carries the user's books for the palette.
+type libraryLoadedMsg struct {
+	books []api.UserBook
+	err   error
+}
+
+func newPalette(entries []paletteEntry) *palette {
+	ti := textinput.New()
+	ti.Placeholder = "Type a command, tab or book..."
+	ti.Prompt = "> "
+	ti.Cursor.Style = common.CursorStyle
+	ti.Focus()
+	p := &palette{input: ti, entries: entries}
+	p.filter()
+	return p
+}
+
+// setEntries replaces what the palette offers, keeping the query.
+func (p *palette) setEntries(entries []paletteEntry) {
+	p.entries = entries
+	p.filter()
+}
+
+// filter matches the entries against the query. An empty query lists
+// every entry in order.
+func (p *palette) filter() {
+	q := strings.TrimSpace(p.input.Value())
+	if q == "" {
+		p.matches = make(fuzzy.Matches, len(p.entries))
+		for i := range p.entries {
+			p.matches[i] = fuzzy.Match{Str: p.entries[i].title, Index: i}
+		}
+	} else {
+		p.matches = fuzzy.FindFrom(q, paletteEntries(p.entries))
+		// Entries containing the query as typed beat scattered matches,
+		// so "rate" finds the rate action before a title like
+		// "The Remains of the Day".
+		lower := strings.ToLower(q)
+		contains := func(m fuzzy.Match) bool {
+			return strings.Contains(strings.ToLower(m.Str), lower)
+		}
+		slices.SortStableFunc(p.matches, func(a, b fuzzy.Match) int {
+			switch ca, cb := contains(a), contains(b); {
+			case ca && !cb:
+				return -1
+			case cb && !ca:
+				return 1
+			}
+			return 0
+		})
+	}
+	p.cursor = min(p.cursor, max(len(p.matches)-1, 0))
+}
+
+// selected returns the highlighted entry.
+func (p *palette) selected() (paletteEntry, bool) {
+	if p.cursor >= len(p.matches) {
+		return paletteEntry{}, false
+	}
+	return p.entries[p.matches[p.cursor].Index], true
+}
+
+// paletteEntries gathers the current screen's actions, the global keys,
+// the tabs and the cached library books.
+func (m Model) paletteEntries() []paletteEntry {
+	var entries []paletteEntry
+	addBindings := func(prefix string, bindings []key.Binding) {
+		for _, b := range bindings {
+			if !b.Enabled() || len(b.Keys()) == 0 {
+				continue
+			}
+			h := b.Help()
+			entries = append(entries, paletteEntry{
+				kind:  paletteAction,
+				title: prefix + h.Desc,
+				hint:  h.Key,
+				key:   b.Keys()[0],
+			})
+		}
+	}
+
+	if top := m.nav.Top(); top != nil {
+		prefix := top.Title + ": "
+		if hb, ok := top.Model.(common.HelpBindable); ok {
+			addBindings(prefix, hb.HelpBindings())
+		}
+		if fhb, ok := top.Model.(common.FullHelpBindable); ok {
+			addBindings(prefix, fhb.FullHelpBindings())
+		}
+	}
+	for i, t := range navTabs {
+		entries = append(entries, paletteEntry{
+			kind:  paletteTab,
+			title: "Go to " + t.name,
+			hint:  fmt.Sprintf("%d", i+1),
+			tab:   i,
+		})
+	}
+	if _, ok := m.screenLinks(); ok {
+		addBindings("", []key.Binding{m.keys.OpenLink, m.keys.CopyLink})
+	}
+	addBindings("", []key.Binding{m.keys.Refresh, m.keys.Undo, m.keys.Notifications, m.keys.Help, m.keys.Logout, m.keys.Quit})
+
+	for _, ub := range m.library {
+		entries = append(entries, paletteEntry{
+			kind:  paletteBook,
+			title: ub.Book.Title,
+			hint:  ub.Book.Authors(),
+			book:  ub,
+		})
+	}
+	return entries
+}
+
+// openPalette shows the palette, fetching the library in the background
+// if the cached copy is missing or stale.
+func (m Model) openPalette() (Model, tea.Cmd) {
+	m.palette = newPalette(m.paletteEntries())
+	if m.library != nil && (m.maxAge == 0 || time.Since(m.libraryAt) < m.maxAge) {
+		return m, textinput.Blink
+	}
+	return m, tea.Batch(textinput.Blink, m.loadLibrary())
+}
+
+// loadLibrary fetches every book in the library a page at a time, so
+// large libraries are offered in full.
+func (m Model) loadLibrary() tea.Cmd {
+	svc := m.svc
+	userID := m.user.ID
+	return func() tea.Msg {
+		var books []api.UserBook
+		for {
+			ctx, cancel := makeContext()
+			page, err := svc.GetUserBooks(ctx, userID, nil, paletteBookPage, len(books))
+			cancel()
+			if err != nil {
+				return libraryLoadedMsg{err: err}
+			}
+			books = append(books, page...)
+			if len(page) < paletteBookPage {
+				return libraryLoadedMsg{books: books}
+			}
+		}
+	}
+}
+
+// updatePalette handles a key while the palette is open.
+func (m Model) updatePalette(msg tea.KeyMsg) (Model, tea.Cmd) {
+	p := m.palette
+	switch msg.String() {
+	case "esc", "ctrl+k":
+		m.palette = nil
+		return m, nil
+	case "up", "ctrl+p":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return m, nil
+	case "down", "ctrl+n":
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+		return m, nil
+	case "enter":
+		e, ok := p.selected()
+		m.palette = nil
+		if !ok {
+			return m, nil
+		}
+		return m.runPaletteEntry(e)
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	p.filter()
+	return m, cmd
+}
+
+// runPaletteEntry carries out the chosen entry.
+func (m Model) runPaletteEntry(e paletteEntry) (Model, tea.Cmd) {
+	switch e.kind {
+	case paletteTab:
+		return m.switchTab(e.tab)
+	case paletteBook:
+		ub := e.book
+		return m, func() tea.Msg { return home.NavigateToBookMsg{UserBook: &ub} }
+	}
+	updated, cmd := m.Update(keyPress(e.key))
+	return updated.(Model), cmd
+}
+
+// keyTypes maps key names such as "enter" or "ctrl+r" to their key type.
+var keyTypes = func() map[string]tea.KeyType {
+	types := map[string]tea.KeyType{}
+	for t := tea.KeyType(-128); t <= 127; t++ {
+		if s := t.String(); s != "" {
+			types[s] = t
+		}
+	}
+	return types
+}()
+
+// keyPress builds the key message for a key binding name.
+func keyPress(s string) tea.KeyMsg {
+	alt := false
+	if rest, ok := strings.CutPrefix(s, "alt+"); ok && rest != "" {
+		alt, s = true, rest
+	}
+	if t, ok := keyTypes[s]; ok {
+		return tea.KeyMsg{Type: t, Alt: alt}
+	}
+	if rest, ok := strings.CutPrefix(s, "shift+"); ok && len(rest) == 1 {
+		s = strings.ToUpper(rest)
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s), Alt: alt}
+}
+
+// renderPalette draws the palette with the matches around the cursor.
+func (m Model) renderPalette() string {
+	p := m.palette
+	w := min(max(m.width-10, 40), 80)
+	p.input.Width = w - 8
+
+	var b strings.Builder
+	b.WriteString(p.input.View())
+	b.WriteString("\n\n")
+
+	start := 0
+	if p.cursor >= paletteRows {
+		start = p.cursor - paletteRows + 1
+	}
+	end := min(start+paletteRows, len(p.matches))
+	if len(p.matches) == 0 {
+		b.WriteString(common.HelpStyle.Render("No matches"))
+	}
+	for i := start; i < end; i++ {
+		match := p.matches[i]
+		e := p.entries[match.Index]
+		b.WriteString(renderPaletteRow(e, match.MatchedIndexes, i == p.cursor, w-4))
+		if i < end-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		b.String(),
+		"",
+		common.HelpStyle.Render("↑/↓ select • enter run • esc close"),
+	)
+	return common.RenderActivePanel("Command Palette", content, w)
+}
+
+// renderPaletteRow draws one entry, highlighting the matched characters.
+func renderPaletteRow(e paletteEntry, matched []int, selected bool, w int) string {
+	base := common.ValueStyle
+	if selected {
+		base = lipgloss.NewStyle().Foreground(common.ColorText).Background(common.ColorHighlight)
+	}
+	hit := base.Foreground(common.ColorPrimary).Bold(true)
+
+	kind := "   "
+	switch e.kind {
+	case paletteTab:
+		kind = "⇥  "
+	case paletteBook:
+		kind = "▤  "
+	}
+
+	hint := common.Truncate(e.hint, max(w/3, 8))
+	titleW := w - lipgloss.Width(kind) - lipgloss.Width(hint) - 2
+	title := common.Truncate(e.title, titleW)
+
+	// Matched indexes are byte offsets into the title.
+	isHit := make(map[int]bool, len(matched))
+	for _, i := range matched {
+		isHit[i] = true
+	}
+	var t strings.Builder
+	for i, r := range title {
+		if isHit[i] {
+			t.WriteString(hit.Render(string(r)))
+		} else {
+			t.WriteString(base.Render(string(r)))
+		}
+	}
+
+	gap := max(w-lipgloss.Width(kind)-lipgloss.Width(title)-lipgloss.Width(hint), 1)
+	return base.Render(kind) + t.String() + base.Render(strings.Repeat(" ", gap)) +
+		base.Foreground(common.ColorMuted).Render(hint)
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"cmp"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/storage"
+	"github.com/NotMugil/hardcover-tui/internal/ui/bookdetail"
+	"github.com/NotMugil/hardcover-tui/internal/ui/home"
+	"github.com/NotMugil/hardcover-tui/internal/ui/lists"
+	"github.com/NotMugil/hardcover-tui/internal/ui/search"
+)
+
+// sessionFile holds where the user left off, so the next launch can
+// return there.
+const sessionFile = "session.json"
+
+// session is the navigation state saved on quit.
+type session struct {
+	UserID int           `json:"user_id"`
+	Tab    int           `json:"tab"`
+	Books  []sessionBook `json:"books,omitempty"` // book screens open on Tab, bottom first
+	Home   home.State    `json:"home"`
+	Search search.State  `json:"search"`
+	Lists  lists.State   `json:"lists"`
+}
+
+type sessionBook struct {
+	ID    int    `json:"id"`
+	Title string `json:"title,omitempty"`
+}
+
+// loadSession returns the saved session, or nil if there is none.
+func loadSession() *session {
+	var s session
+	if err := storage.Load(sessionFile, &s); err != nil || s.UserID == 0 {
+		return nil
+	}
+	return &s
+}
+
+// sessionFor returns the saved session if it belongs to user.
+func (m Model) sessionFor(userID int) *session {
+	if m.session == nil || m.session.UserID != userID {
+		return nil
+	}
+	return m.session
+}
+
+// saveSession records the active tab, the books open on it and each tab's
+// selection. Tabs not opened since launch keep what was saved before.
+func (m Model) saveSession() {
+	if m.user == nil {
+		return
+	}
+	s := session{UserID: m.user.ID, Tab: m.activeTab}
+	if prev := m.sessionFor(m.user.ID); prev != nil {
+		s.Home, s.Search, s.Lists = prev.Home, prev.Search, prev.Lists
+	}
+	for _, t := range m.tabs {
+		if len(t.screens) == 0 {
+			continue
+		}
+		switch root := t.screens[0].(type) {
+		case *home.Model:
+			s.Home = root.State()
+		case *search.Model:
+			s.Search = root.State()
+		case *lists.Model:
+			s.Lists = root.State()
+		}
+	}
+
+	// Only book screens are reopened; review, progress and journal
+	// screens sit on top of one and are left closed.
+	titles := m.nav.StackSummary()
+	for i, screen := range m.tabs[m.activeTab].screens {
+		b, ok := screen.(*bookdetail.Model)
+		if !ok || b.BookID() == 0 {
+			continue
+		}
+		var title string
+		if i < len(titles) {
+			title = titles[i]
+		}
+		s.Books = append(s.Books, sessionBook{ID: b.BookID(), Title: title})
+	}
+	_ = storage.Save(sessionFile, s)
+}
+
+// restoreSession reopens the saved tab and the books that were open on
+// it. Home must already be shown.
+func (m Model) restoreSession() (Model, tea.Cmd) {
+	s := m.sessionFor(m.user.ID)
+	if s == nil {
+		return m, nil
+	}
+	var cmds []tea.Cmd
+	if s.Tab > 0 && s.Tab < len(navTabs) {
+		var cmd tea.Cmd
+		m, cmd = m.switchTab(s.Tab)
+		cmds = append(cmds, cmd)
+	}
+	for _, b := range s.Books {
+		screen := bookdetail.NewFromBookID(m.svc, m.user, b.ID)
+		var cmd tea.Cmd
+		m, cmd = m.pushScreen(cmp.Or(b.Title, "Book"), screen)
+		cmds = append(cmds, cmd)
+	}
+	if len(s.Books) > 0 && !m.tabLoading {
+		m.tabLoading = true
+		cmds = append(cmds, m.loader.Start())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// restoreTabScreen applies the saved selection to a tab's root screen.
+func (m Model) restoreTabScreen(screen Screen) {
+	if m.user == nil {
+		return
+	}
+	s := m.sessionFor(m.user.ID)
+	if s == nil {
+		return
+	}
+	switch screen := screen.(type) {
+	case *search.Model:
+		screen.Restore(s.Search)
+	case *lists.Model:
+		screen.Restore(s.Lists)
+	}
+}
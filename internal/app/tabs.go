@@ -0,0 +1,228 @@
+package app
+
+import (
+	"os"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kevm/bubbleo/navstack"
+	"github.com/kevm/bubbleo/window"
+
+	"github.com/NotMugil/hardcover-tui/internal/common"
+)
+
+// EnvTabMaxAge overrides how long a tab's data stays fresh, as a Go
+// duration such as "10m". "0" keeps data until it is refreshed by hand.
+const EnvTabMaxAge = "HARDCOVER_TAB_MAX_AGE"
+
+const defaultTabMaxAge = 5 * time.Minute
+
+// tabState is one tab's navigation stack. Screens stay alive while other
+// tabs are shown, so returning to a tab keeps its selection, scroll
+// position and results.
+type tabState struct {
+	nav      *navstack.Model
+	screens  []Screen  // the screens on nav, root first
+	loadedAt time.Time // when the top screen last loaded its data
+}
+
+func newTabs(w *window.Model) []tabState {
+	tabs := make([]tabState, len(navTabs))
+	for i := range tabs {
+		n := navstack.New(w)
+		tabs[i].nav = &n
+	}
+	return tabs
+}
+
+// tabMaxAge returns the configured freshness window for tab data.
+func tabMaxAge() time.Duration {
+	if v := os.Getenv(EnvTabMaxAge); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return defaultTabMaxAge
+}
+
+// stale reports whether the tab's data should be reloaded before it is
+// shown again.
+func (m Model) stale(idx int) bool {
+	t := m.tabs[idx]
+	if t.loadedAt.IsZero() {
+		return true
+	}
+	return m.maxAge > 0 && time.Since(t.loadedAt) > m.maxAge
+}
+
+// resetTabs closes every tab's screens and shows an empty Home tab.
+func (m *Model) resetTabs() {
+	for i := range m.tabs {
+		_ = m.tabs[i].nav.Clear()
+		m.tabs[i].screens = nil
+		m.tabs[i].loadedAt = time.Time{}
+	}
+	m.activeTab = 0
+	m.nav = m.tabs[0].nav
+}
+
+// invalidateTabs marks every tab but the active one as needing a reload
+// when it is next shown.
+func (m *Model) invalidateTabs() {
+	for i := range m.tabs {
+		if i != m.activeTab {
+			m.tabs[i].loadedAt = time.Time{}
+		}
+	}
+}
+
+// switchTab shows the given tab, creating its screen the first time.
+// Choosing the active tab again returns to its root screen, or reloads
+// it when already there.
+func (m Model) switchTab(idx int) (Model, tea.Cmd) {
+	if idx == m.activeTab {
+		if len(m.nav.StackSummary()) > 1 {
+			return m.popToRoot()
+		}
+		return m.refreshTab()
+	}
+
+	m.activeTab = idx
+	m.nav = m.tabs[idx].nav
+	if m.nav.Top() == nil {
+		screen := m.createTabScreen(idx)
+		m.tabs[idx].loadedAt = time.Now()
+		if l, ok := screen.(loadable); ok && l.Loaded() {
+			return m.pushScreen(navTabs[idx].name, screen)
+		}
+		m.tabLoading = true
+		loaderCmd := m.loader.Start()
+		nm, pushCmd := m.pushScreen(navTabs[idx].name, screen)
+		return nm, tea.Batch(pushCmd, loaderCmd)
+	}
+
+	m.resizeTop()
+	if m.stale(idx) {
+		return m.refreshTab()
+	}
+	return m, nil
+}
+
+// refreshTab reloads the screen on top of the active tab. The loader only
+// covers screens that report when they have loaded; it would never stop
+// for the others.
+func (m Model) refreshTab() (Model, tea.Cmd) {
+	cmd := m.updateTab(m.activeTab, navstack.ReloadCurrent{})
+	m.resizeTop()
+	m.tabs[m.activeTab].loadedAt = time.Now()
+	if top := m.nav.Top(); top != nil {
+		if l, ok := top.Model.(loadable); ok && !l.Loaded() {
+			m.tabLoading = true
+			return m, tea.Batch(cmd, m.loader.Start())
+		}
+	}
+	return m, cmd
+}
+
+// popToRoot pops the active tab back to its root screen.
+func (m Model) popToRoot() (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	for len(m.nav.StackSummary()) > 1 {
+		cmd = m.popScreen()
+	}
+	return m, cmd
+}
+
+// popScreen closes the active tab's top screen and fits the one below it
+// to the window.
+func (m Model) popScreen() tea.Cmd {
+	cmd := m.nav.Pop()
+	t := &m.tabs[m.activeTab]
+	if n := len(t.screens); n > 0 {
+		t.screens = t.screens[:n-1]
+	}
+	m.resizeTop()
+	if top := m.nav.Top(); top != nil {
+		cmd = common.ForScreen(cmd, top.Model)
+	}
+	return cmd
+}
+
+// removeScreen closes screen wherever it sits in its tab's stack and
+// reports whether it was found. A tab's root screen is never removed.
+// navstack can only pop its top, so a screen further down is dropped by
+// rebuilding the stack; the screens below the top load again when they
+// are next shown, as they do after any pop.
+func (m Model) removeScreen(screen tea.Model) (tea.Cmd, bool) {
+	for i := range m.tabs {
+		t := &m.tabs[i]
+		pos := slices.IndexFunc(t.screens, func(s Screen) bool { return s == screen })
+		if pos < 1 {
+			continue
+		}
+		var cmd tea.Cmd
+		if pos == len(t.screens)-1 {
+			cmd = t.nav.Pop()
+			t.screens = t.screens[:pos]
+		} else {
+			titles := slices.Delete(t.nav.StackSummary(), pos, pos+1)
+			t.screens = slices.Delete(t.screens, pos, pos+1)
+			_ = t.nav.Clear()
+			for j, s := range t.screens {
+				cmd = t.nav.Push(navstack.NavigationItem{Title: titles[j], Model: s})
+			}
+		}
+		if i == m.activeTab {
+			m.resizeTop()
+		}
+		top := t.screens[len(t.screens)-1]
+		return common.ForScreen(common.WithOrigin(cmd, m.origin(i)), top), true
+	}
+	return nil, false
+}
+
+// resizeTop fits the active tab's top screen to the window.
+func (m Model) resizeTop() {
+	if top := m.nav.Top(); top != nil {
+		if s, ok := top.Model.(sizable); ok && m.width > 0 {
+			s.SetSize(m.width, m.contentHeight())
+		}
+	}
+}
+
+// updateTab delivers msg to the top screen of tab idx. The notifications
+// it sends are tagged with that screen and its other messages come back
+// to it alone.
+func (m Model) updateTab(idx int, msg tea.Msg) tea.Cmd {
+	top := m.tabs[idx].nav.Top()
+	if top == nil {
+		return m.tabs[idx].nav.Update(msg)
+	}
+	screen, origin := top.Model, m.origin(idx)
+	return common.ForScreen(common.WithOrigin(m.tabs[idx].nav.Update(msg), origin), screen)
+}
+
+// updateOwner delivers a message to the screen whose command produced it:
+// through its tab when the screen is on top, directly when it is further
+// down the stack. Messages for screens that have been closed are dropped,
+// unless r.Closed asks for them to be handled all the same.
+func (m Model) updateOwner(r common.ScreenMsg) tea.Cmd {
+	for i, t := range m.tabs {
+		for j, s := range t.screens {
+			if s != r.Screen {
+				continue
+			}
+			if j == len(t.screens)-1 {
+				return m.updateTab(i, r.Msg)
+			}
+			_, cmd := s.Update(r.Msg)
+			return common.ForScreen(common.WithOrigin(cmd, m.originAt(i, j)), s)
+		}
+	}
+	if r.Closed {
+		_, cmd := r.Screen.Update(r.Msg)
+		return common.ForScreen(cmd, r.Screen)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/common"
+)
+
+// plainScreen is a screen that does not report when it has loaded, like
+// the review and journal screens.
+type plainScreen struct{}
+
+func (s *plainScreen) Init() tea.Cmd                       { return nil }
+func (s *plainScreen) Update(tea.Msg) (tea.Model, tea.Cmd) { return s, nil }
+func (s *plainScreen) View() string                        { return "" }
+
+// loadingScreen reports loaded, like Home and the book screen.
+type loadingScreen struct {
+	loaded bool
+}
+
+func (s *loadingScreen) Init() tea.Cmd                       { return nil }
+func (s *loadingScreen) Update(tea.Msg) (tea.Model, tea.Cmd) { return s, nil }
+func (s *loadingScreen) View() string                        { return "" }
+func (s *loadingScreen) Loaded() bool                        { return s.loaded }
+
+// testModel returns an app showing screen on the first tab, with its
+// saved state kept out of the user's config.
+func testModel(t *testing.T, screen Screen) Model {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	m := New()
+	m.setupMode = false
+	m.loading = false
+	m, _ = m.pushScreen("Tab", screen)
+	return m
+}
+
+func TestRefreshTabLoader(t *testing.T) {
+	tests := []struct {
+		name    string
+		screen  Screen
+		loading bool
+	}{
+		{"screen without Loaded", &plainScreen{}, false},
+		{"loaded screen", &loadingScreen{loaded: true}, false},
+		{"loading screen", &loadingScreen{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testModel(t, tt.screen)
+			m, _ = m.refreshTab()
+			if m.tabLoading != tt.loading {
+				t.Errorf("tabLoading = %v, want %v", m.tabLoading, tt.loading)
+			}
+			if m.tabs[m.activeTab].loadedAt.IsZero() {
+				t.Error("loadedAt not set")
+			}
+		})
+	}
+}
+
+func TestLoaderStopsOnceScreenLoads(t *testing.T) {
+	screen := &loadingScreen{}
+	m := testModel(t, screen)
+	m, _ = m.refreshTab()
+
+	frame := common.LoaderFrameMsg(time.Now())
+	updated, _ := m.Update(frame)
+	if m = updated.(Model); !m.tabLoading {
+		t.Fatal("loader stopped before the screen loaded")
+	}
+	screen.loaded = true
+	updated, _ = m.Update(frame)
+	if m = updated.(Model); m.tabLoading {
+		t.Error("loader still running after the screen loaded")
+	}
+}
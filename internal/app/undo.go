@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/common"
+)
+
+const (
+	// maxUndo is how many changes can be undone, newest first.
+	maxUndo = 20
+	// undoTimeout allows for undoing a bulk action, which sends one
+	// request per book.
+	undoTimeout = 2 * time.Minute
+)
+
+// undoneMsg reports the result of undoing a change.
+type undoneMsg struct {
+	desc string
+	err  error
+}
+
+// pushUndo records a change that can be undone, dropping the oldest once
+// the stack is full.
+func (m *Model) pushUndo(u common.Undo) {
+	m.undo = append(m.undo, u)
+	if len(m.undo) > maxUndo {
+		m.undo = m.undo[len(m.undo)-maxUndo:]
+	}
+}
+
+// runUndo reverses the latest change.
+func (m Model) runUndo() (Model, tea.Cmd) {
+	if len(m.undo) == 0 {
+		return m, common.NotifyCmd(common.NotifyInfo, "Nothing to undo")
+	}
+	u := m.undo[len(m.undo)-1]
+	m.undo = m.undo[:len(m.undo)-1]
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), undoTimeout)
+		defer cancel()
+		return undoneMsg{desc: u.Desc, err: u.Run(ctx)}
+	}
+}
+
+// undone reports an undo and reloads what it changed: the current screen
+// now, other tabs when next shown.
+func (m Model) undone(msg undoneMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, common.NotifyCmd(common.NotifyError, "Undo failed: "+msg.err.Error())
+	}
+	m.library = nil
+	for i := range m.tabs {
+		m.tabs[i].loadedAt = time.Time{}
+	}
+	m, cmd := m.refreshTab()
+	return m, tea.Batch(cmd, common.NotifyCmd(common.NotifySuccess, msg.desc))
+}
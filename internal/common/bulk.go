@@ -0,0 +1,219 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+)
+
+// BulkOp is one step of a bulk action, such as updating one book.
+type BulkOp struct {
+	ID    int    // the item's ID, to keep failed items selected
+	Label string // names the item in the failure summary
+	Run   func(ctx context.Context) error
+	Undo  func(ctx context.Context) error // reverses Run; nil if it cannot be
+}
+
+// BulkFailure is an operation that returned an error.
+type BulkFailure struct {
+	ID    int
+	Label string
+	Err   error
+}
+
+// BulkStepMsg reports that one operation of a bulk run finished.
+type BulkStepMsg struct {
+	run   int
+	index int
+	err   error
+}
+
+// bulkRuns numbers runs so a stopped run's last step is ignored.
+var bulkRuns int
+
+// Bulk runs operations one after another, so they queue behind the API
+// rate limiter like any other request, and keeps count of the failures.
+type Bulk struct {
+	Title     string
+	ops       []BulkOp
+	run       int
+	done      int
+	failures  []BulkFailure
+	succeeded []int // indexes of the operations that worked
+	cancelled bool
+	finished  bool
+}
+
+func NewBulk(title string, ops []BulkOp) *Bulk {
+	bulkRuns++
+	return &Bulk{Title: title, ops: ops, run: bulkRuns}
+}
+
+// Start runs the first operation.
+func (b *Bulk) Start() tea.Cmd {
+	if len(b.ops) == 0 {
+		b.finished = true
+		return nil
+	}
+	return b.step(0)
+}
+
+func (b *Bulk) step(i int) tea.Cmd {
+	op := b.ops[i]
+	run := b.run
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		// A long run should not hold up single edits or the screens
+		// loaded meanwhile.
+		ctx = api.WithPriority(ctx, api.PriorityBackground)
+		return BulkStepMsg{run: run, index: i, err: op.Run(ctx)}
+	}
+}
+
+// Update records a finished operation and starts the next one. It
+// reports true once the run is over, either because every operation ran
+// or because it was stopped.
+func (b *Bulk) Update(msg BulkStepMsg) (tea.Cmd, bool) {
+	if msg.run != b.run || b.finished {
+		return nil, false
+	}
+	b.done++
+	if msg.err != nil {
+		b.failures = append(b.failures, BulkFailure{ID: b.ops[msg.index].ID, Label: b.ops[msg.index].Label, Err: msg.err})
+	} else {
+		b.succeeded = append(b.succeeded, msg.index)
+	}
+	if b.cancelled || b.done == len(b.ops) {
+		b.finished = true
+		return nil, true
+	}
+	return b.step(msg.index + 1), false
+}
+
+// Cancel stops the run once the operation in flight returns.
+func (b *Bulk) Cancel() {
+	b.cancelled = true
+}
+
+// Finished reports whether the run is over.
+func (b *Bulk) Finished() bool {
+	return b.finished
+}
+
+// Failures returns the operations that failed so far.
+func (b *Bulk) Failures() []BulkFailure {
+	return b.failures
+}
+
+// Undo reverses the operations that succeeded, newest first. It reports
+// false if none of them can be undone.
+func (b *Bulk) Undo() (Undo, bool) {
+	var undos []func(ctx context.Context) error
+	for i := len(b.succeeded) - 1; i >= 0; i-- {
+		if u := b.ops[b.succeeded[i]].Undo; u != nil {
+			undos = append(undos, u)
+		}
+	}
+	if len(undos) == 0 {
+		return Undo{}, false
+	}
+	return Undo{
+		Desc: fmt.Sprintf("%s undone for %d books", b.Title, len(undos)),
+		Run: func(ctx context.Context) error {
+			var errs []error
+			for _, u := range undos {
+				if err := u(ctx); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return errors.Join(errs...)
+		},
+	}, true
+}
+
+// Notify reports a finished run, with its undo if it has one.
+func (b *Bulk) Notify() tea.Cmd {
+	level, result := b.Result()
+	if undo, ok := b.Undo(); ok {
+		return NotifyUndoableCmd(level, result, undo)
+	}
+	return NotifyCmd(level, result)
+}
+
+// Result describes a finished run as a notification.
+func (b *Bulk) Result() (NotifyLevel, string) {
+	total := len(b.ops)
+	switch {
+	case b.cancelled:
+		return NotifyWarning, fmt.Sprintf("%s: stopped after %d of %d", b.Title, b.done, total)
+	case len(b.failures) > 0:
+		return NotifyWarning, fmt.Sprintf("%s: %d of %d failed", b.Title, len(b.failures), total)
+	}
+	return NotifySuccess, fmt.Sprintf("%s: %d done", b.Title, total)
+}
+
+// bulkFailureRows caps the failures listed in the summary.
+const bulkFailureRows = 8
+
+// RenderBulkOverlay draws a running bulk action's progress, or the
+// failures of a finished one.
+func RenderBulkOverlay(b *Bulk, w int) string {
+	if w < 40 {
+		w = 40
+	}
+	if w > 70 {
+		w = 70
+	}
+	inner := w - 6
+	total := len(b.ops)
+
+	var content strings.Builder
+	if !b.finished {
+		pct := 0.0
+		if total > 0 {
+			pct = float64(b.done) / float64(total)
+		}
+		content.WriteString(RenderBar(pct, inner-10, fmt.Sprintf("%d/%d", b.done, total)))
+		content.WriteString("\n\n")
+		if b.done < total {
+			content.WriteString(ValueStyle.Render(Truncate(b.ops[b.done].Label, inner)))
+			content.WriteString("\n")
+		}
+		if len(b.failures) > 0 {
+			content.WriteString(ErrorStyle.Render(fmt.Sprintf("%d failed", len(b.failures))))
+			content.WriteString("\n")
+		}
+		content.WriteString("\n")
+		if b.cancelled {
+			content.WriteString(HelpStyle.Render("Stopping..."))
+		} else {
+			content.WriteString(HelpStyle.Render("esc: stop"))
+		}
+		return RenderActivePanel(b.Title, content.String(), w)
+	}
+
+	_, result := b.Result()
+	content.WriteString(LabelStyle.Render(Truncate(result, inner)))
+	content.WriteString("\n\n")
+	for i, f := range b.failures {
+		if i == bulkFailureRows {
+			content.WriteString(HelpStyle.Render(fmt.Sprintf("...and %d more", len(b.failures)-i)))
+			content.WriteString("\n")
+			break
+		}
+		label := lipgloss.NewStyle().Foreground(ColorText).Render(Truncate(f.Label, inner/2))
+		content.WriteString(label + " " + ErrorStyle.Render(Truncate(f.Err.Error(), inner-lipgloss.Width(label)-1)))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(HelpStyle.Render("enter/esc: close"))
+	return RenderActivePanel(b.Title, content.String(), w)
+}
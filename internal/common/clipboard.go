@@ -0,0 +1,30 @@
+package common
+
+import (
+	"os"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CopyCmd puts text on the clipboard with an OSC 52 escape sequence, which
+// most terminals support, also over SSH. what names the text in the
+// notification, as in "Error copied".
+func CopyCmd(text, what string) tea.Cmd {
+	return func() tea.Msg {
+		seq := osc52.New(text)
+		switch {
+		case os.Getenv("TMUX") != "":
+			seq = seq.Tmux()
+		case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+			seq = seq.Screen()
+		}
+		// Stderr is the same terminal but not the renderer's stream, so
+		// the sequence is not split by a frame being drawn.
+		if _, err := seq.WriteTo(os.Stderr); err != nil {
+			return NotifyMsg{Level: NotifyError, Message: "Copy failed: " + err.Error()}
+		}
+		return NotifyMsg{Level: NotifySuccess, Message: what + " copied"}
+	}
+}
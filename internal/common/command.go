@@ -0,0 +1,101 @@
+package common
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Command is a command typed on the ":" command line, such as
+// ":rate 4.5" or ":list add \"Sci-fi Faves\"".
+type Command struct {
+	Name  string
+	Usage string // arguments, e.g. "<0.5-5>"
+	Help  string
+
+	// Complete returns candidates for the last word of args, which holds
+	// the words typed after the command name. It may be nil.
+	Complete func(args []string) []string
+
+	// Run executes the command with the words after its name. A returned
+	// error is shown to the user.
+	Run func(args []string) (tea.Cmd, error)
+}
+
+// Commandable is implemented by screens that accept ":" commands.
+type Commandable interface {
+	Commands() []Command
+}
+
+// CommandLineOpenedMsg is sent to the current screen when the ":" command
+// line opens, so it can fetch what its completions need.
+type CommandLineOpenedMsg struct{}
+
+// ErrUsage is returned by Command.Run when the arguments do not match the
+// command's usage.
+var ErrUsage = errors.New("usage")
+
+// ParseCommand splits a command line into words. Double quotes group
+// words containing spaces and a backslash escapes the next character.
+func ParseCommand(line string) ([]string, error) {
+	var (
+		words   []string
+		cur     strings.Builder
+		inWord  bool
+		quoted  bool
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped, inWord = true, true
+		case r == '"':
+			quoted = !quoted
+			inWord = true
+		case !quoted && (r == ' ' || r == '\t'):
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quoted {
+		return nil, errors.New("missing closing quote")
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
+
+// QuoteWord quotes s for a command line if it contains spaces or quotes.
+func QuoteWord(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\"\\") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// CompletePrefix returns the candidates starting with prefix, ignoring
+// case, sorted.
+func CompletePrefix(prefix string, candidates []string) []string {
+	var out []string
+	lower := strings.ToLower(prefix)
+	for _, c := range candidates {
+		if strings.HasPrefix(strings.ToLower(c), lower) {
+			out = append(out, c)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,76 @@
+package common
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"", nil},
+		{"   ", nil},
+		{"rate 4.5", []string{"rate", "4.5"}},
+		{"  status\tread  ", []string{"status", "read"}},
+		{`list add "Sci-fi Faves"`, []string{"list", "add", "Sci-fi Faves"}},
+		{`list add Sci-fi" "Faves`, []string{"list", "add", "Sci-fi Faves"}},
+		{`list add ""`, []string{"list", "add", ""}},
+		{`say \"hi\"`, []string{"say", `"hi"`}},
+		{`path a\ b`, []string{"path", "a b"}},
+		{`x "a \" b"`, []string{"x", `a " b`}},
+		{`x \\`, []string{"x", `\`}},
+	}
+	for _, tt := range tests {
+		got, err := ParseCommand(tt.line)
+		if err != nil {
+			t.Errorf("ParseCommand(%q): %v", tt.line, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ParseCommand(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParseCommandUnclosedQuote(t *testing.T) {
+	for _, line := range []string{`list add "Sci-fi`, `"`, `a "b" "c`} {
+		if got, err := ParseCommand(line); err == nil {
+			t.Errorf("ParseCommand(%q) = %q, want an error", line, got)
+		}
+	}
+}
+
+func TestQuoteWordRoundTrip(t *testing.T) {
+	for _, w := range []string{"read", "", "Sci-fi Faves", `say "hi"`, `back\slash`, "tab\there"} {
+		got, err := ParseCommand("cmd " + QuoteWord(w))
+		if err != nil {
+			t.Errorf("QuoteWord(%q) = %s: %v", w, QuoteWord(w), err)
+			continue
+		}
+		if len(got) != 2 || got[1] != w {
+			t.Errorf("QuoteWord(%q) = %s, parsed back as %q", w, QuoteWord(w), got)
+		}
+	}
+}
+
+func TestCompletePrefix(t *testing.T) {
+	candidates := []string{"reading", "Read", "want", "paused", "rate"}
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"", []string{"Read", "paused", "rate", "reading", "want"}},
+		{"re", []string{"Read", "reading"}},
+		{"RE", []string{"Read", "reading"}},
+		{"r", []string{"Read", "rate", "reading"}},
+		{"reading", []string{"reading"}},
+		{"x", nil},
+	}
+	for _, tt := range tests {
+		if got := CompletePrefix(tt.prefix, candidates); !slices.Equal(got, tt.want) {
+			t.Errorf("CompletePrefix(%q) = %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DeepLink is what `hardcover-tui open` was asked to show: a book by ID or
+// slug, a list or a user. Exactly one of BookID, BookSlug, ListSlug and
+// Username is the target; Username also names a list's owner.
+type DeepLink struct {
+	BookID   int
+	BookSlug string
+	ListSlug string
+	Username string // empty for a list means the signed-in user's
+}
+
+// IsList reports whether the link is to a list rather than a user.
+func (d DeepLink) IsList() bool { return d.ListSlug != "" }
+
+// String returns the link as it would be typed, for messages.
+func (d DeepLink) String() string {
+	switch {
+	case d.BookID != 0:
+		return strconv.Itoa(d.BookID)
+	case d.BookSlug != "":
+		return d.BookSlug
+	case d.ListSlug != "" && d.Username != "":
+		return "@" + d.Username + "/lists/" + d.ListSlug
+	case d.ListSlug != "":
+		return "lists/" + d.ListSlug
+	}
+	return "@" + d.Username
+}
+
+// ParseDeepLink understands hardcover.app URLs of books, lists and users,
+// with or without the scheme, as well as a bare book ID, a book slug or
+// an @username:
+//
+//	https://hardcover.app/books/dune
+//	hardcover.app/@frank/lists/favourites
+//	/lists/favourites (one of your own lists)
+//	@frank
+//	12345
+func ParseDeepLink(s string) (DeepLink, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return DeepLink{}, errors.New("nothing to open")
+	}
+	if id, err := strconv.Atoi(s); err == nil {
+		if id <= 0 {
+			return DeepLink{}, fmt.Errorf("%q is not a book ID", s)
+		}
+		return DeepLink{BookID: id}, nil
+	}
+	if name, ok := strings.CutPrefix(s, "@"); ok && !strings.Contains(name, "/") {
+		return DeepLink{Username: name}, nil
+	}
+	if !strings.ContainsAny(s, "/.:") {
+		return DeepLink{BookSlug: s}, nil
+	}
+
+	path := s
+	if !strings.HasPrefix(s, "/") {
+		if !strings.Contains(s, "://") {
+			s = "https://" + s
+		}
+		u, err := url.Parse(s)
+		if err != nil {
+			return DeepLink{}, fmt.Errorf("%q is not a link: %w", s, err)
+		}
+		if host := strings.TrimPrefix(u.Hostname(), "www."); host != "hardcover.app" {
+			return DeepLink{}, fmt.Errorf("%s is not a hardcover.app link", u.Hostname())
+		}
+		path = u.EscapedPath()
+	}
+
+	var parts []string
+	for _, p := range strings.Split(path, "/") {
+		if p == "" {
+			continue
+		}
+		p, err := url.PathUnescape(p)
+		if err != nil {
+			return DeepLink{}, fmt.Errorf("%q is not a link: %w", s, err)
+		}
+		parts = append(parts, p)
+	}
+
+	// Pages below these, like a book's editions or a user's books, open
+	// the book or the user.
+	switch {
+	case len(parts) >= 2 && parts[0] == "books":
+		return DeepLink{BookSlug: parts[1]}, nil
+	case len(parts) >= 2 && parts[0] == "lists":
+		return DeepLink{ListSlug: parts[1]}, nil
+	case len(parts) >= 1 && strings.HasPrefix(parts[0], "@") && len(parts[0]) > 1:
+		d := DeepLink{Username: parts[0][1:]}
+		if len(parts) >= 3 && parts[1] == "lists" {
+			d.ListSlug = parts[2]
+		}
+		return d, nil
+	}
+	return DeepLink{}, fmt.Errorf("%s is not a link to a book, a list or a user", s)
+}
@@ -0,0 +1,69 @@
+package common
+
+import "testing"
+
+func TestParseDeepLink(t *testing.T) {
+	tests := []struct {
+		in   string
+		want DeepLink
+	}{
+		{"12345", DeepLink{BookID: 12345}},
+		{"  42\n", DeepLink{BookID: 42}},
+		{"dune", DeepLink{BookSlug: "dune"}},
+		{"@frank", DeepLink{Username: "frank"}},
+		{"https://hardcover.app/books/dune", DeepLink{BookSlug: "dune"}},
+		{"hardcover.app/books/dune/editions", DeepLink{BookSlug: "dune"}},
+		{"https://www.hardcover.app/books/the-left-hand-of-darkness", DeepLink{BookSlug: "the-left-hand-of-darkness"}},
+		{"http://hardcover.app/@frank", DeepLink{Username: "frank"}},
+		{"hardcover.app/@frank/books", DeepLink{Username: "frank"}},
+		{"hardcover.app/@frank/lists/favourites", DeepLink{Username: "frank", ListSlug: "favourites"}},
+		{"/lists/favourites", DeepLink{ListSlug: "favourites"}},
+		{"hardcover.app/books/caf%C3%A9", DeepLink{BookSlug: "café"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseDeepLink(tt.in)
+		if err != nil {
+			t.Errorf("ParseDeepLink(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDeepLink(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseDeepLinkErrors(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"   ",
+		"0",
+		"-3",
+		"https://example.com/books/dune",
+		"hardcover.app",
+		"hardcover.app/books",
+		"hardcover.app/@",
+		"/authors/frank-herbert",
+	} {
+		if got, err := ParseDeepLink(in); err == nil {
+			t.Errorf("ParseDeepLink(%q) = %+v, want an error", in, got)
+		}
+	}
+}
+
+func TestDeepLinkString(t *testing.T) {
+	tests := []struct {
+		link DeepLink
+		want string
+	}{
+		{DeepLink{BookID: 7}, "7"},
+		{DeepLink{BookSlug: "dune"}, "dune"},
+		{DeepLink{Username: "frank"}, "@frank"},
+		{DeepLink{Username: "frank", ListSlug: "faves"}, "@frank/lists/faves"},
+		{DeepLink{ListSlug: "faves"}, "lists/faves"},
+	}
+	for _, tt := range tests {
+		if got := tt.link.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.link, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EditorFinishedMsg is sent when an external editor opened by OpenEditor
+// exits. ID echoes the id passed to OpenEditor so a screen can tell its own
+// edits apart when several long-text inputs share the same Update.
+type EditorFinishedMsg struct {
+	ID      string
+	Content string
+	Err     error
+}
+
+// editorCommand resolves the user's editor from $VISUAL, then $EDITOR,
+// falling back to a platform default. Arguments in the variable are kept,
+// so values like "code --wait" work.
+func editorCommand() []string {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if fields := strings.Fields(editor); len(fields) > 0 {
+		return fields
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// OpenEditor suspends the program and opens content in the user's editor
+// using a temp file with the given extension (e.g. ".md"). The edited text
+// is delivered as an EditorFinishedMsg once the editor exits.
+func OpenEditor(id, content, ext string) tea.Cmd {
+	f, err := os.CreateTemp("", "hardcover-*"+ext)
+	if err != nil {
+		return editorErr(id, err)
+	}
+	path := f.Name()
+	_, err = f.WriteString(content)
+	f.Close()
+	if err != nil {
+		os.Remove(path)
+		return editorErr(id, err)
+	}
+
+	args := append(editorCommand(), path)
+	cmd := exec.Command(args[0], args[1:]...)
+
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return EditorFinishedMsg{ID: id, Err: fmt.Errorf("editor: %w", err)}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return EditorFinishedMsg{ID: id, Err: fmt.Errorf("read edited file: %w", err)}
+		}
+		return EditorFinishedMsg{ID: id, Content: strings.TrimRight(string(data), "\n")}
+	})
+}
+
+func editorErr(id string, err error) tea.Cmd {
+	return func() tea.Msg {
+		return EditorFinishedMsg{ID: id, Err: err}
+	}
+}
+
+// draftPath returns the file used to keep an unsaved draft for key.
+func draftPath(key string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "hardcover-tui", "drafts", key+".md"), nil
+}
+
+// SaveDraft keeps text that failed to save so it can be recovered the next
+// time the same input is opened. Keys identify the input, e.g. "review-42".
+func SaveDraft(key, text string) error {
+	path, err := draftPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(text), 0o600)
+}
+
+// LoadDraft returns a previously saved draft for key, if any.
+func LoadDraft(key string) (string, bool) {
+	path, err := draftPath(key)
+	if err != nil {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) == 0 {
+		return "", false
+	}
+	return string(data), true
+}
+
+// ClearDraft removes the saved draft for key once it has been saved.
+func ClearDraft(key string) {
+	if path, err := draftPath(key); err == nil {
+		os.Remove(path)
+	}
+}
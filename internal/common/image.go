@@ -1,6 +1,7 @@
 package common
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	_ "image/gif"
@@ -10,8 +11,14 @@ import (
 	"time"
 
 	"github.com/blacktop/go-termimg"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
 )
 
+// errReplaying is returned for images while replaying a recording, which
+// holds API traffic only and must not reach the network.
+var errReplaying = errors.New("images are not fetched when replaying")
+
 // RenderImage fetches an image from a URL and returns a terminal-renderable string.
 // maxWidth and maxHeight are the maximum bounds in character cells.
 // The image is scaled to fit within those bounds while preserving aspect ratio.
@@ -19,8 +26,11 @@ func RenderImage(url string, maxWidth, maxHeight int) (string, error) {
 	if url == "" {
 		return "", fmt.Errorf("empty URL")
 	}
+	if api.Replaying() {
+		return "", errReplaying
+	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := &http.Client{Timeout: 10 * time.Second, Transport: api.Transport()}
 	resp, err := client.Get(url)
 	if err != nil {
 		return "", fmt.Errorf("fetch image: %w", err)
@@ -0,0 +1,358 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+)
+
+// JournalKind groups journal events into the types the UI can create and filter.
+type JournalKind int
+
+const (
+	JournalAll JournalKind = iota - 1
+	JournalNote
+	JournalQuote
+	JournalProgress
+	JournalStatus
+	JournalOther
+)
+
+func (k JournalKind) String() string {
+	switch k {
+	case JournalAll:
+		return "All"
+	case JournalNote:
+		return "Note"
+	case JournalQuote:
+		return "Quote"
+	case JournalProgress:
+		return "Progress"
+	case JournalStatus:
+		return "Status"
+	default:
+		return "Other"
+	}
+}
+
+// NextJournalFilter cycles All -> Note -> Quote -> Progress -> Status ->
+// Other -> All.
+func NextJournalFilter(k JournalKind) JournalKind {
+	if k >= JournalOther {
+		return JournalAll
+	}
+	return k + 1
+}
+
+// JournalKindOf classifies a journal entry by its event.
+func JournalKindOf(j api.ReadingJournal) JournalKind {
+	switch {
+	case j.Event == api.JournalEventNote:
+		return JournalNote
+	case j.Event == api.JournalEventQuote:
+		return JournalQuote
+	case j.Event == api.JournalEventProgress:
+		return JournalProgress
+	case j.IsStatusEvent():
+		return JournalStatus
+	default:
+		return JournalOther
+	}
+}
+
+// FilterJournals returns the entries matching kind. JournalAll keeps everything.
+func FilterJournals(journals []api.ReadingJournal, kind JournalKind) []api.ReadingJournal {
+	if kind == JournalAll {
+		return journals
+	}
+	var out []api.ReadingJournal
+	for _, j := range journals {
+		if JournalKindOf(j) == kind {
+			out = append(out, j)
+		}
+	}
+	return out
+}
+
+// JournalTitle renders the one-line title for a journal entry in a list,
+// e.g. "2024-05-01 ❝ Quote · p. 42". The book title is appended when
+// withBook is true.
+func JournalTitle(j api.ReadingJournal, withBook bool) string {
+	date := j.ActionAt
+	if len(date) > 10 {
+		date = date[:10]
+	}
+	out := date + " " + JournalLabel(j)
+	if withBook && j.Book != nil {
+		out += " - " + j.Book.Title
+	}
+	return out
+}
+
+// JournalLabel renders the type-specific part of a journal title without
+// the date, e.g. "▸ Progress · p. 212 of 400 (53%)".
+func JournalLabel(j api.ReadingJournal) string {
+	meta := j.ParseMetadata()
+	switch JournalKindOf(j) {
+	case JournalNote:
+		return "✎ Note"
+	case JournalQuote:
+		label := "❝ Quote"
+		if meta.Page != nil {
+			label += fmt.Sprintf(" · p. %d", *meta.Page)
+		}
+		return label
+	case JournalProgress:
+		label := "▸ Progress"
+		if meta.Page != nil {
+			label += fmt.Sprintf(" · p. %d", *meta.Page)
+			if meta.Pages != nil && *meta.Pages > 0 {
+				label += fmt.Sprintf(" of %d", *meta.Pages)
+			}
+		}
+		if meta.Percent != nil {
+			label += fmt.Sprintf(" (%.0f%%)", *meta.Percent)
+		}
+		return label
+	case JournalStatus:
+		return "● Status → " + j.Status().String()
+	default:
+		return "[" + j.Event + "]"
+	}
+}
+
+// JournalDescription renders the second line for a journal entry in a list.
+// Quotes are shown in quotation marks; other types show their note text.
+func JournalDescription(j api.ReadingJournal) string {
+	if j.Entry == nil || *j.Entry == "" {
+		return ""
+	}
+	entry := strings.Join(strings.Fields(*j.Entry), " ")
+	limit := 80
+	if JournalKindOf(j) == JournalQuote {
+		limit = 78
+	}
+	if r := []rune(entry); len(r) > limit {
+		entry = string(r[:limit-3]) + "..."
+	}
+	if JournalKindOf(j) == JournalQuote {
+		return "“" + entry + "”"
+	}
+	return entry
+}
+
+// JournalForm is the input form for a new typed journal entry. Quotes and
+// progress updates take a page number; status changes take a status.
+type JournalForm struct {
+	Kind         JournalKind
+	Entry        textarea.Model
+	Page         textinput.Model
+	statusCursor int
+	fieldFocus   bool // true when the page/status field has focus
+	pages        int  // total pages of the book, for progress percentages
+}
+
+// NewJournalForm creates an empty journal form.
+func NewJournalForm() JournalForm {
+	ta := textarea.New()
+	ta.Placeholder = "Write a journal entry..."
+	ta.SetWidth(60)
+	ta.SetHeight(6)
+	ta.Cursor.Style = CursorStyle
+
+	pi := textinput.New()
+	pi.Placeholder = "page"
+	pi.CharLimit = 6
+	pi.Width = 8
+	pi.Validate = func(s string) error {
+		if s == "" {
+			return nil
+		}
+		_, err := strconv.Atoi(s)
+		return err
+	}
+
+	return JournalForm{Kind: JournalNote, Entry: ta, Page: pi}
+}
+
+// Start prepares the form for a new entry of the given kind. Entry text is
+// kept so a recovered draft is not lost. pages is the book's page count,
+// used to compute progress percentages, or 0 if unknown.
+func (f *JournalForm) Start(kind JournalKind, pages int) tea.Cmd {
+	f.Kind = kind
+	f.pages = pages
+	f.Page.SetValue("")
+	f.statusCursor = 0
+	switch kind {
+	case JournalQuote:
+		f.Entry.Placeholder = "Quote text..."
+	case JournalProgress, JournalStatus:
+		f.Entry.Placeholder = "Optional note..."
+	default:
+		f.Entry.Placeholder = "Write a journal entry..."
+	}
+	// Progress and status entries are mostly about the field, so start there.
+	f.fieldFocus = kind == JournalProgress || kind == JournalStatus
+	return f.focus()
+}
+
+// Reset clears the form after a successful save.
+func (f *JournalForm) Reset() {
+	f.Entry.SetValue("")
+	f.Page.SetValue("")
+	f.Entry.Blur()
+	f.Page.Blur()
+}
+
+// Blur removes focus from every input.
+func (f *JournalForm) Blur() {
+	f.Entry.Blur()
+	f.Page.Blur()
+}
+
+// SetWidth sets the width of the entry textarea.
+func (f *JournalForm) SetWidth(w int) {
+	if w > 10 {
+		f.Entry.SetWidth(w)
+	}
+}
+
+func (f *JournalForm) hasField() bool {
+	return f.Kind == JournalQuote || f.Kind == JournalProgress || f.Kind == JournalStatus
+}
+
+func (f *JournalForm) focus() tea.Cmd {
+	if f.fieldFocus && f.hasField() {
+		f.Entry.Blur()
+		if f.Kind == JournalStatus {
+			return nil
+		}
+		return f.Page.Focus()
+	}
+	f.Page.Blur()
+	return f.Entry.Focus()
+}
+
+// Update handles a key press in the form. Tab moves between the page or
+// status field and the entry text.
+func (f *JournalForm) Update(msg tea.KeyMsg) tea.Cmd {
+	if msg.String() == "tab" && f.hasField() {
+		f.fieldFocus = !f.fieldFocus
+		return f.focus()
+	}
+
+	if f.fieldFocus && f.Kind == JournalStatus {
+		statuses := api.AllStatuses()
+		switch msg.String() {
+		case "left", "h", "up", "k":
+			f.statusCursor = (f.statusCursor + len(statuses) - 1) % len(statuses)
+		case "right", "l", "down", "j":
+			f.statusCursor = (f.statusCursor + 1) % len(statuses)
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if f.fieldFocus && f.hasField() {
+		f.Page, cmd = f.Page.Update(msg)
+	} else {
+		f.Entry, cmd = f.Entry.Update(msg)
+	}
+	return cmd
+}
+
+// Build validates the form and returns the event, entry text and metadata
+// to pass to mutations.InsertReadingJournalEvent.
+func (f *JournalForm) Build() (event, entry string, meta api.JournalMetadata, err error) {
+	entry = strings.TrimSpace(f.Entry.Value())
+
+	var page *int
+	if v := strings.TrimSpace(f.Page.Value()); v != "" {
+		n, convErr := strconv.Atoi(v)
+		if convErr != nil || n <= 0 {
+			return "", "", meta, fmt.Errorf("page must be a positive number")
+		}
+		page = &n
+	}
+
+	switch f.Kind {
+	case JournalQuote:
+		if entry == "" {
+			return "", "", meta, fmt.Errorf("quote text is required")
+		}
+		meta.Page = page
+		return api.JournalEventQuote, entry, meta, nil
+	case JournalProgress:
+		if page == nil {
+			return "", "", meta, fmt.Errorf("page is required")
+		}
+		meta.Page = page
+		if f.pages > 0 {
+			pages := f.pages
+			pct := float64(*page) / float64(pages) * 100
+			if pct > 100 {
+				pct = 100
+			}
+			meta.Pages = &pages
+			meta.Percent = &pct
+		}
+		return api.JournalEventProgress, entry, meta, nil
+	case JournalStatus:
+		status := api.AllStatuses()[f.statusCursor]
+		id := int(status)
+		meta.StatusID = &id
+		return api.JournalStatusEvent(status), entry, meta, nil
+	default:
+		if entry == "" {
+			return "", "", meta, fmt.Errorf("entry is empty")
+		}
+		return api.JournalEventNote, entry, meta, nil
+	}
+}
+
+// View renders the form fields for the current kind.
+func (f *JournalForm) View() string {
+	var b strings.Builder
+	switch f.Kind {
+	case JournalQuote, JournalProgress:
+		label := "Page: "
+		if f.Kind == JournalProgress && f.pages > 0 {
+			label = fmt.Sprintf("Page (of %d): ", f.pages)
+		}
+		b.WriteString(LabelStyle.Render(label) + f.Page.View())
+		b.WriteString("\n\n")
+	case JournalStatus:
+		b.WriteString(LabelStyle.Render("Status: "))
+		for i, s := range api.AllStatuses() {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			if i == f.statusCursor {
+				style := ValueStyle.Bold(true).Underline(true)
+				if f.fieldFocus {
+					style = style.Foreground(ColorPrimary)
+				}
+				b.WriteString(style.Render(s.String()))
+			} else {
+				b.WriteString(HelpStyle.Render(s.String()))
+			}
+		}
+		b.WriteString("\n\n")
+	}
+	b.WriteString(f.Entry.View())
+	return b.String()
+}
+
+// HelpText returns the key hints for the form.
+func (f *JournalForm) HelpText() string {
+	if f.hasField() {
+		return "tab: switch field | ctrl+s: save | ctrl+o: $EDITOR | esc: cancel"
+	}
+	return "ctrl+s: save | ctrl+o: $EDITOR | esc: cancel"
+}
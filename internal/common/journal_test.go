@@ -0,0 +1,49 @@
+package common
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+)
+
+func TestNextJournalFilterVisitsEveryKind(t *testing.T) {
+	want := []JournalKind{JournalNote, JournalQuote, JournalProgress, JournalStatus, JournalOther, JournalAll}
+	k := JournalAll
+	for _, w := range want {
+		k = NextJournalFilter(k)
+		if k != w {
+			t.Fatalf("cycle reached %v, want %v", k, w)
+		}
+	}
+}
+
+func TestFilterJournals(t *testing.T) {
+	journals := []api.ReadingJournal{
+		{ID: 1, Event: api.JournalEventNote},
+		{ID: 2, Event: api.JournalEventQuote},
+		{ID: 3, Event: api.JournalEventProgress},
+		{ID: 4, Event: api.JournalStatusEvent(api.StatusRead)},
+		{ID: 5, Event: "rated"},
+	}
+	tests := []struct {
+		kind JournalKind
+		want []int
+	}{
+		{JournalAll, []int{1, 2, 3, 4, 5}},
+		{JournalNote, []int{1}},
+		{JournalQuote, []int{2}},
+		{JournalProgress, []int{3}},
+		{JournalStatus, []int{4}},
+		{JournalOther, []int{5}},
+	}
+	for _, tt := range tests {
+		var got []int
+		for _, j := range FilterJournals(journals, tt.kind) {
+			got = append(got, j.ID)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%v: got %v, want %v", tt.kind, got, tt.want)
+		}
+	}
+}
@@ -11,17 +11,28 @@ type FullHelpBindable interface {
 }
 
 type KeyMap struct {
-	Help   key.Binding
-	Back   key.Binding
-	Quit   key.Binding
-	Logout key.Binding
+	Help    key.Binding
+	Back    key.Binding
+	Quit    key.Binding
+	Logout  key.Binding
+	Refresh key.Binding
+	Undo    key.Binding
+	Palette key.Binding
+	Command key.Binding
+
+	Notifications key.Binding
+	OpenLink      key.Binding
+	CopyLink      key.Binding
 
 	Library key.Binding
 	Search  key.Binding
 	Lists   key.Binding
 	Stats   key.Binding
+	Journal key.Binding
 	NextTab key.Binding
 	PrevTab key.Binding
+
+	Debug key.Binding
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
@@ -31,7 +42,9 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.NextTab, k.PrevTab},
-		{k.Help, k.Back, k.Logout, k.Quit},
+		{k.Palette, k.Command, k.Notifications, k.Help, k.Back, k.Refresh, k.Undo, k.Logout, k.Quit},
+		{k.OpenLink, k.CopyLink},
+		{k.Debug},
 	}
 }
 
@@ -52,6 +65,34 @@ var Keys = KeyMap{
 		key.WithKeys("ctrl+q"),
 		key.WithHelp("ctrl+q", "logout"),
 	),
+	Refresh: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "refresh"),
+	),
+	Undo: key.NewBinding(
+		key.WithKeys("u"),
+		key.WithHelp("u", "undo"),
+	),
+	Palette: key.NewBinding(
+		key.WithKeys("ctrl+k"),
+		key.WithHelp("ctrl+k", "command palette"),
+	),
+	Command: key.NewBinding(
+		key.WithKeys(":"),
+		key.WithHelp(":", "command line"),
+	),
+	Notifications: key.NewBinding(
+		key.WithKeys("!"),
+		key.WithHelp("!", "notifications"),
+	),
+	OpenLink: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "open on hardcover.app"),
+	),
+	CopyLink: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "copy link"),
+	),
 	Library: key.NewBinding(
 		key.WithKeys("1"),
 		key.WithHelp("1", "home"),
@@ -68,6 +109,10 @@ var Keys = KeyMap{
 		key.WithKeys("4"),
 		key.WithHelp("4", "stats"),
 	),
+	Journal: key.NewBinding(
+		key.WithKeys("5"),
+		key.WithHelp("5", "journal"),
+	),
 	NextTab: key.NewBinding(
 		key.WithKeys("tab"),
 		key.WithHelp("tab", "next tab"),
@@ -78,4 +123,10 @@ var Keys = KeyMap{
 		key.WithHelp("shift+tab", "prev tab"),
 		key.WithDisabled(),
 	),
+	// Debug is enabled by the app when running with --debug.
+	Debug: key.NewBinding(
+		key.WithKeys("f12"),
+		key.WithHelp("f12", "debug overlay"),
+		key.WithDisabled(),
+	),
 }
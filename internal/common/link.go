@@ -0,0 +1,138 @@
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+)
+
+// SiteURL is the Hardcover website, which links point into.
+const SiteURL = "https://hardcover.app"
+
+// Link is a page on hardcover.app.
+type Link struct {
+	Label string // names the page in the picker, as in "Book: Dune"
+	URL   string
+}
+
+// Linkable is implemented by screens that show something with a page on
+// hardcover.app. Links returns the pages of what is selected, the most
+// specific first; a book's page comes before its authors'.
+type Linkable interface {
+	Links() []Link
+}
+
+// LinkMsg asks the app to open one of Links in the browser, or to copy
+// it. Screens that handle every key themselves send it for the open and
+// copy link keys; for other screens the app asks Links directly.
+type LinkMsg struct {
+	Links []Link
+	Copy  bool
+}
+
+// LinkCmd sends a LinkMsg for links.
+func LinkCmd(links []Link, copyLink bool) tea.Cmd {
+	return func() tea.Msg {
+		return LinkMsg{Links: links, Copy: copyLink}
+	}
+}
+
+// HandleLinkKey sends a LinkMsg for l's links if msg is the open or copy
+// link key. Screens call it in the modes where they take every key.
+func HandleLinkKey(msg tea.KeyMsg, l Linkable) (tea.Cmd, bool) {
+	if !key.Matches(msg, Keys.OpenLink, Keys.CopyLink) {
+		return nil, false
+	}
+	return LinkCmd(l.Links(), key.Matches(msg, Keys.CopyLink)), true
+}
+
+// BookLink returns a book's page. Books fetched without a slug have none.
+func BookLink(b api.Book) (Link, bool) {
+	if b.Slug == nil || *b.Slug == "" {
+		return Link{}, false
+	}
+	return Link{Label: "Book: " + b.Title, URL: SiteURL + "/books/" + url.PathEscape(*b.Slug)}, true
+}
+
+// BookLinks returns the pages of a book and of its authors, as far as
+// their slugs were fetched.
+func BookLinks(b api.Book) []Link {
+	var links []Link
+	if l, ok := BookLink(b); ok {
+		links = append(links, l)
+	}
+	for _, c := range b.Contributions {
+		if c.Author.Slug != "" {
+			links = append(links, AuthorLink(c.Author.Name, c.Author.Slug))
+		}
+	}
+	return links
+}
+
+func AuthorLink(name, slug string) Link {
+	return Link{Label: "Author: " + name, URL: SiteURL + "/authors/" + url.PathEscape(slug)}
+}
+
+func SeriesLink(name, slug string) Link {
+	return Link{Label: "Series: " + name, URL: SiteURL + "/series/" + url.PathEscape(slug)}
+}
+
+func UserLink(username string) Link {
+	return Link{Label: "User: @" + username, URL: SiteURL + "/@" + url.PathEscape(username)}
+}
+
+// ListLink returns the page of a list owned by username.
+func ListLink(username, name, slug string) Link {
+	return Link{
+		Label: "List: " + name,
+		URL:   fmt.Sprintf("%s/@%s/lists/%s", SiteURL, url.PathEscape(username), url.PathEscape(slug)),
+	}
+}
+
+// ReviewLink returns the page of username's review of a book.
+func ReviewLink(b api.Book, username string) (Link, bool) {
+	book, ok := BookLink(b)
+	if !ok {
+		return Link{}, false
+	}
+	return Link{Label: "Review by @" + username, URL: book.URL + "/reviews/@" + url.PathEscape(username)}, true
+}
+
+func ActivityLink(username string, id int) Link {
+	return Link{Label: "Activity", URL: fmt.Sprintf("%s/@%s/activity/%d", SiteURL, url.PathEscape(username), id)}
+}
+
+// OpenBrowser opens the given URL in the system's default browser.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("cmd", "/c", "start", url)
+	case "darwin":
+		cmd = exec.Command("open", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// OpenBrowserCmd opens url, reporting if no browser could be started, as
+// on a server reached over SSH.
+func OpenBrowserCmd(url string) tea.Cmd {
+	return func() tea.Msg {
+		if err := OpenBrowser(url); err != nil {
+			return NotifyMsg{Level: NotifyError, Message: "Could not open a browser: " + err.Error()}
+		}
+		return nil
+	}
+}
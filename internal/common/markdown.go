@@ -0,0 +1,148 @@
+package common
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/yuin/goldmark"
+)
+
+// Spoilers are written inline as ||hidden text||, matching the syntax most
+// Markdown-flavoured review sites use. Hardcover stores them as spoiler spans.
+// A spoiler may wrap onto the next line but not into the next paragraph.
+var (
+	spoilerRe     = regexp.MustCompile(`\|\|((?:[^\n]|\n[^\n])+?)\|\|`)
+	spoilerHTMLRe = regexp.MustCompile(`(?is)<span[^>]*class="[^"]*spoiler[^"]*"[^>]*>(.*?)</span>|<spoiler>(.*?)</spoiler>`)
+)
+
+// HasSpoilers reports whether the Markdown contains any ||spoiler|| blocks.
+func HasSpoilers(md string) bool {
+	return spoilerRe.MatchString(md)
+}
+
+// MaskSpoilers replaces the contents of every spoiler block with shade
+// characters of the same length so the surrounding layout stays stable.
+func MaskSpoilers(md string) string {
+	return spoilerRe.ReplaceAllStringFunc(md, func(s string) string {
+		inner := spoilerRe.FindStringSubmatch(s)[1]
+		n := len([]rune(strings.TrimSpace(inner)))
+		if n < 3 {
+			n = 3
+		}
+		return "`" + strings.Repeat("░", n) + "`"
+	})
+}
+
+// RevealSpoilers removes the spoiler markers, marking each block as a spoiler.
+func RevealSpoilers(md string) string {
+	return spoilerRe.ReplaceAllString(md, "*[spoiler: $1]*")
+}
+
+// RenderMarkdown renders Markdown for the terminal, wrapped to width.
+// Spoilers are masked unless reveal is true.
+func RenderMarkdown(md string, width int, reveal bool) (string, error) {
+	var r MarkdownRenderer
+	return r.Render(md, width, reveal)
+}
+
+// MarkdownRenderer renders Markdown like RenderMarkdown, but keeps its
+// glamour renderer between calls, as building one is too slow to do on
+// every keystroke. It is rebuilt when the width changes.
+type MarkdownRenderer struct {
+	r     *glamour.TermRenderer
+	width int
+}
+
+// Render renders md wrapped to width, masking spoilers unless reveal is
+// true.
+func (mr *MarkdownRenderer) Render(md string, width int, reveal bool) (string, error) {
+	if reveal {
+		md = RevealSpoilers(md)
+	} else {
+		md = MaskSpoilers(md)
+	}
+	if width < 20 {
+		width = 20
+	}
+	if mr.r == nil || mr.width != width {
+		r, err := glamour.NewTermRenderer(
+			glamour.WithStandardStyle("dark"),
+			glamour.WithWordWrap(width),
+		)
+		if err != nil {
+			return "", err
+		}
+		mr.r, mr.width = r, width
+	}
+	out, err := mr.r.Render(md)
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(out, "\n"), nil
+}
+
+// reviewMarkdown renders reviews with ||spoiler|| spans. HTML typed into a
+// review is kept as text rather than published as markup.
+var reviewMarkdown = goldmark.New(goldmark.WithExtensions(spoilers{}))
+
+// MarkdownToHTML converts review Markdown to the HTML Hardcover stores in
+// review_html, turning ||spoiler|| blocks into spoiler spans.
+func MarkdownToHTML(md string) string {
+	var buf bytes.Buffer
+	if err := reviewMarkdown.Convert([]byte(md), &buf); err != nil {
+		return html.EscapeString(md)
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// htmlToMarkdown rules, applied in order. Anything left over is stripped.
+var htmlRules = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`(?i)<br\s*/?>`), "\n"},
+	{regexp.MustCompile(`(?i)</p>\s*`), "\n\n"},
+	{regexp.MustCompile(`(?is)<(strong|b)>(.*?)</(strong|b)>`), "**$2**"},
+	{regexp.MustCompile(`(?is)<(em|i)>(.*?)</(em|i)>`), "*$2*"},
+	{regexp.MustCompile(`(?is)<(s|del|strike)>(.*?)</(s|del|strike)>`), "~~$2~~"},
+	{regexp.MustCompile(`(?is)<code>(.*?)</code>`), "`$1`"},
+	{regexp.MustCompile(`(?is)<a[^>]*href="([^"]*)"[^>]*>(.*?)</a>`), "[$2]($1)"},
+	{regexp.MustCompile(`(?is)<h1[^>]*>(.*?)</h1>\s*`), "# $1\n\n"},
+	{regexp.MustCompile(`(?is)<h2[^>]*>(.*?)</h2>\s*`), "## $1\n\n"},
+	{regexp.MustCompile(`(?is)<h[3-6][^>]*>(.*?)</h[3-6]>\s*`), "### $1\n\n"},
+	{regexp.MustCompile(`(?is)<li[^>]*>\s*(.*?)\s*</li>\s*`), "- $1\n"},
+	{regexp.MustCompile(`(?i)</(ul|ol)>\s*`), "\n"},
+	{regexp.MustCompile(`(?i)<hr\s*/?>\s*`), "---\n\n"},
+}
+
+var (
+	blockquoteRe = regexp.MustCompile(`(?is)<blockquote[^>]*>(.*?)</blockquote>\s*`)
+	anyTagRe     = regexp.MustCompile(`<[^>]*>`)
+	blankLinesRe = regexp.MustCompile(`\n{3,}`)
+)
+
+// HTMLToMarkdown converts the limited HTML produced by Hardcover's review
+// editor back into Markdown so it can be edited without losing formatting.
+func HTMLToMarkdown(s string) string {
+	s = spoilerHTMLRe.ReplaceAllString(s, "||$1$2||")
+	for _, r := range htmlRules {
+		s = r.re.ReplaceAllString(s, r.repl)
+	}
+	s = blockquoteRe.ReplaceAllStringFunc(s, func(q string) string {
+		inner := strings.TrimSpace(blockquoteRe.FindStringSubmatch(q)[1])
+		inner = strings.TrimSpace(anyTagRe.ReplaceAllString(inner, ""))
+		lines := strings.Split(inner, "\n")
+		for i, l := range lines {
+			lines[i] = "> " + l
+		}
+		return strings.Join(lines, "\n") + "\n\n"
+	})
+	s = anyTagRe.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+	s = blankLinesRe.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,94 @@
+package common
+
+import "testing"
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		md   string
+		want string
+	}{
+		{"", ""},
+		{"Loved it.", "<p>Loved it.</p>"},
+		{"**bold** and *italic*", "<p><strong>bold</strong> and <em>italic</em></p>"},
+		{"One.\n\nTwo.", "<p>One.</p>\n<p>Two.</p>"},
+		{"The twist: ||he was the ghost||.", `<p>The twist: <span class="spoiler">he was the ghost</span>.</p>`},
+		{"- one\n- two", "<ul>\n<li>one</li>\n<li>two</li>\n</ul>"},
+		{"> quoted", "<blockquote>\n<p>quoted</p>\n</blockquote>"},
+		{"[site](https://hardcover.app)", `<p><a href="https://hardcover.app">site</a></p>`},
+		{"a < b & c", "<p>a &lt; b &amp; c</p>"},
+		{"|| the *twist* ||", `<p><span class="spoiler"> the <em>twist</em> </span></p>`},
+		{"||two\nlines||", "<p><span class=\"spoiler\">two\nlines</span></p>"},
+		{"||one.\n\ntwo.||", "<p>||one.</p>\n<p>two.||</p>"},
+		{"`a || b` and ||c||", `<p><code>a || b</code> and <span class="spoiler">c</span></p>`},
+		{"a ||| b |||", "<p>a ||| b |||</p>"},
+		{"unclosed ||spoiler", "<p>unclosed ||spoiler</p>"},
+		{`<b onclick="x()">hi</b>`, `<p>&lt;b onclick=&quot;x()&quot;&gt;hi&lt;/b&gt;</p>`},
+		{"<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{"||<img src=x onerror=y>||", `<p><span class="spoiler">&lt;img src=x onerror=y&gt;</span></p>`},
+	}
+	for _, tt := range tests {
+		if got := MarkdownToHTML(tt.md); got != tt.want {
+			t.Errorf("MarkdownToHTML(%q) = %q, want %q", tt.md, got, tt.want)
+		}
+	}
+}
+
+func TestHTMLToMarkdown(t *testing.T) {
+	tests := []struct {
+		html string
+		want string
+	}{
+		{"", ""},
+		{"<p>Loved it.</p>", "Loved it."},
+		{"<p>One.</p><p>Two.</p>", "One.\n\nTwo."},
+		{"line<br>break<br/>again", "line\nbreak\nagain"},
+		{"<b>bold</b> <strong>strong</strong> <i>it</i> <em>em</em>", "**bold** **strong** *it* *em*"},
+		{"<del>gone</del> <code>x := 1</code>", "~~gone~~ `x := 1`"},
+		{`<a href="https://hardcover.app">site</a>`, "[site](https://hardcover.app)"},
+		{"<h1>Title</h1><h2>Sub</h2><h4>Small</h4>", "# Title\n\n## Sub\n\n### Small"},
+		{"<ul><li>one</li><li>two</li></ul>", "- one\n- two"},
+		{"<blockquote><p>one</p>\n<p>two</p></blockquote>", "> one\n> \n> two"},
+		{`<p>It was <span class="spoiler">the butler</span>.</p>`, "It was ||the butler||."},
+		{"<spoiler>hidden</spoiler>", "||hidden||"},
+		{"a &lt; b &amp; c&nbsp;d", "a < b & c d"},
+		{"<div><span>kept</span></div>", "kept"},
+		{"<p>a</p>\n\n\n\n<p>b</p>", "a\n\nb"},
+	}
+	for _, tt := range tests {
+		if got := HTMLToMarkdown(tt.html); got != tt.want {
+			t.Errorf("HTMLToMarkdown(%q) = %q, want %q", tt.html, got, tt.want)
+		}
+	}
+}
+
+func TestSpoilersStayInParagraph(t *testing.T) {
+	tests := []struct {
+		md   string
+		want bool
+	}{
+		{"||hidden||", true},
+		{"||wraps\nonto the next line||", true},
+		{"||one paragraph\n\nand the next||", false},
+		{"no spoiler", false},
+	}
+	for _, tt := range tests {
+		if got := HasSpoilers(tt.md); got != tt.want {
+			t.Errorf("HasSpoilers(%q) = %v, want %v", tt.md, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	for _, md := range []string{
+		"Loved it.",
+		"**bold** and *italic*",
+		"One.\n\nTwo.",
+		"The twist: ||he was the ghost||.",
+		"- one\n- two",
+		"[site](https://hardcover.app)",
+	} {
+		if got := HTMLToMarkdown(MarkdownToHTML(md)); got != md {
+			t.Errorf("round trip of %q gave %q", md, got)
+		}
+	}
+}
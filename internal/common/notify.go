@@ -17,6 +17,8 @@ const (
 type NotifyMsg struct {
 	Level   NotifyLevel
 	Message string
+	Origin  string  // screen that sent it, filled in by the app
+	Retry   tea.Cmd // runs the failed action again; nil if it cannot be
 }
 
 // NotifyCmd creates a tea.Cmd that produces a NotifyMsg.
@@ -25,3 +27,42 @@ func NotifyCmd(level NotifyLevel, message string) tea.Cmd {
 		return NotifyMsg{Level: level, Message: message}
 	}
 }
+
+// NotifyRetryCmd reports a failed action that retry runs again from the
+// notification center. A nil retry makes it a plain NotifyCmd.
+func NotifyRetryCmd(level NotifyLevel, message string, retry tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return NotifyMsg{Level: level, Message: message, Retry: retry}
+	}
+}
+
+// WithOrigin tags the notifications cmd sends, including those of batched
+// commands, with the screen they came from. Notifications already tagged
+// keep their origin.
+func WithOrigin(cmd tea.Cmd, origin string) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		switch msg := cmd().(type) {
+		case NotifyMsg:
+			if msg.Origin == "" {
+				msg.Origin = origin
+			}
+			return msg
+		case UndoableMsg:
+			if msg.Origin == "" {
+				msg.Origin = origin
+			}
+			return msg
+		case tea.BatchMsg:
+			tagged := make(tea.BatchMsg, len(msg))
+			for i, c := range msg {
+				tagged[i] = WithOrigin(c, origin)
+			}
+			return tagged
+		default:
+			return msg
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PickerState is a small overlay for choosing one of a few options, like
+// a status or a list.
+type PickerState struct {
+	Active  bool
+	Title   string
+	Options []string
+	Action  string
+	Cursor  int
+}
+
+func NewPicker(title, action string, options []string) PickerState {
+	return PickerState{
+		Active:  true,
+		Title:   title,
+		Options: options,
+		Action:  action,
+	}
+}
+
+// HandleKey moves the cursor or closes the picker. It returns the chosen
+// option's index once enter is pressed, or -1 if the picker was closed
+// without a choice or is still open.
+func (p *PickerState) HandleKey(key string) (choice int) {
+	switch key {
+	case "esc":
+		p.Active = false
+	case "up", "k":
+		if p.Cursor > 0 {
+			p.Cursor--
+		}
+	case "down", "j":
+		if p.Cursor < len(p.Options)-1 {
+			p.Cursor++
+		}
+	case "enter":
+		p.Active = false
+		if p.Cursor < len(p.Options) {
+			return p.Cursor
+		}
+	}
+	return -1
+}
+
+func RenderPickerOverlay(p PickerState, w int) string {
+	var sel strings.Builder
+	if len(p.Options) == 0 {
+		sel.WriteString(ValueStyle.Render("Nothing to choose from") + "\n")
+	}
+	for i, o := range p.Options {
+		cursor := "  "
+		style := ValueStyle
+		if i == p.Cursor {
+			cursor = lipgloss.NewStyle().Foreground(ColorPrimary).Render("> ")
+			style = lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true)
+		}
+		sel.WriteString(cursor + style.Render(Truncate(o, w-8)) + "\n")
+	}
+	sel.WriteString("\n")
+	sel.WriteString(HelpStyle.Render("j/k: navigate | enter: select | esc: cancel"))
+
+	return RenderActivePanel(p.Title, sel.String(), w)
+}
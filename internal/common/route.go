@@ -0,0 +1,70 @@
+package common
+
+import (
+	"reflect"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ScreenMsg carries a message back to the screen whose command produced
+// it. The app delivers Msg to that screen only, whichever tab it is on and
+// wherever it sits in the tab's stack, and drops it once the screen has
+// been closed unless Closed is set. Messages the app itself handles, such as notifications and
+// navigation, are still handled by the app.
+type ScreenMsg struct {
+	Screen tea.Model // the screen that started the command
+	Msg    tea.Msg
+	// Closed delivers Msg even if the screen has been closed meanwhile,
+	// so the outcome of a retried change is still reported.
+	Closed bool
+}
+
+var cmdType = reflect.TypeOf(tea.Cmd(nil))
+
+// ForScreen addresses the messages cmd sends, including those of batched
+// and sequenced commands, to screen. Bubble Tea's own messages, such as
+// the ones that quit or run an editor, are left for the runtime.
+func ForScreen(cmd tea.Cmd, screen tea.Model) tea.Cmd {
+	return forScreen(cmd, screen, false)
+}
+
+// ForClosedScreen is ForScreen for commands whose result must reach
+// screen even after it has been closed.
+func ForClosedScreen(cmd tea.Cmd, screen tea.Model) tea.Cmd {
+	return forScreen(cmd, screen, true)
+}
+
+func forScreen(cmd tea.Cmd, screen tea.Model, closed bool) tea.Cmd {
+	if cmd == nil || screen == nil {
+		return cmd
+	}
+	return func() tea.Msg {
+		msg := cmd()
+		switch m := msg.(type) {
+		case nil:
+			return nil
+		case ScreenMsg:
+			return m
+		case tea.BatchMsg:
+			addressed := make(tea.BatchMsg, len(m))
+			for i, c := range m {
+				addressed[i] = forScreen(c, screen, closed)
+			}
+			return addressed
+		}
+		t := reflect.TypeOf(msg)
+		if t.Kind() == reflect.Slice && t.Elem() == cmdType {
+			// tea.Sequence's message type is unexported.
+			v := reflect.ValueOf(msg)
+			cmds := make([]tea.Cmd, v.Len())
+			for i := range cmds {
+				cmds[i] = forScreen(v.Index(i).Interface().(tea.Cmd), screen, closed)
+			}
+			return tea.Sequence(cmds...)()
+		}
+		if t.PkgPath() == reflect.TypeOf(tea.BatchMsg(nil)).PkgPath() {
+			return msg
+		}
+		return ScreenMsg{Screen: screen, Msg: msg, Closed: closed}
+	}
+}
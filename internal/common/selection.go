@@ -0,0 +1,104 @@
+package common
+
+// Selection is the set of items marked in a list for a bulk action, kept
+// by ID so it survives filtering and reloads.
+type Selection struct {
+	marked map[int]bool
+	anchor int // item last toggled, where a range starts
+}
+
+// Toggle marks or unmarks id and makes it the start of the next range.
+func (s *Selection) Toggle(id int) {
+	if s.marked == nil {
+		s.marked = map[int]bool{}
+	}
+	if s.marked[id] {
+		delete(s.marked, id)
+	} else {
+		s.marked[id] = true
+	}
+	s.anchor = id
+}
+
+// MarkRange marks the items between the range start and id, in the order
+// given by ids (the items as listed). Without a start only id is marked.
+func (s *Selection) MarkRange(ids []int, id int) {
+	if s.marked == nil {
+		s.marked = map[int]bool{}
+	}
+	from, to := -1, -1
+	for i, v := range ids {
+		if v == s.anchor {
+			from = i
+		}
+		if v == id {
+			to = i
+		}
+	}
+	if to < 0 {
+		return
+	}
+	if from < 0 {
+		from = to
+	}
+	if from > to {
+		from, to = to, from
+	}
+	for _, v := range ids[from : to+1] {
+		s.marked[v] = true
+	}
+	s.anchor = id
+}
+
+// ToggleAll marks every item in ids, or unmarks them if all already are.
+func (s *Selection) ToggleAll(ids []int) {
+	if s.marked == nil {
+		s.marked = map[int]bool{}
+	}
+	all := true
+	for _, id := range ids {
+		if !s.marked[id] {
+			all = false
+			break
+		}
+	}
+	for _, id := range ids {
+		if all {
+			delete(s.marked, id)
+		} else {
+			s.marked[id] = true
+		}
+	}
+}
+
+// Marked reports whether id is marked.
+func (s Selection) Marked(id int) bool {
+	return s.marked[id]
+}
+
+// Len returns how many items are marked.
+func (s Selection) Len() int {
+	return len(s.marked)
+}
+
+// Keep drops marks for items that are no longer listed.
+func (s *Selection) Keep(ids []int) {
+	listed := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		listed[id] = true
+	}
+	for id := range s.marked {
+		if !listed[id] {
+			delete(s.marked, id)
+		}
+	}
+}
+
+// Clear unmarks everything.
+func (s *Selection) Clear() {
+	s.marked = nil
+	s.anchor = 0
+}
+
+// MarkPrefix is drawn before the title of a marked item.
+var MarkPrefix = BoldTextStyle.Foreground(ColorAccent).Render("●") + " "
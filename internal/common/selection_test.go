@@ -0,0 +1,109 @@
+package common
+
+import (
+	"slices"
+	"testing"
+)
+
+// markedIDs returns the marked items of ids in list order.
+func markedIDs(s Selection, ids []int) []int {
+	var out []int
+	for _, id := range ids {
+		if s.Marked(id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func TestSelectionMarkRange(t *testing.T) {
+	ids := []int{10, 20, 30, 40, 50}
+	tests := []struct {
+		name   string
+		toggle []int // items toggled first, the last one starting the range
+		to     int
+		want   []int
+	}{
+		{"no start marks only the item", nil, 30, []int{30}},
+		{"forward", []int{20}, 40, []int{20, 30, 40}},
+		{"backward", []int{50}, 20, []int{20, 30, 40, 50}},
+		{"same item", []int{30}, 30, []int{30}},
+		{"keeps earlier marks", []int{10, 40}, 50, []int{10, 40, 50}},
+		{"from an unmarked start", []int{20, 20}, 30, []int{20, 30}},
+		{"start no longer listed", []int{99}, 40, []int{40}},
+		{"item not listed", []int{20}, 99, []int{20}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Selection
+			for _, id := range tt.toggle {
+				s.Toggle(id)
+			}
+			s.MarkRange(ids, tt.to)
+			if got := markedIDs(s, ids); !slices.Equal(got, tt.want) {
+				t.Errorf("marked %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectionMarkRangeMovesStart(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5, 6}
+	var s Selection
+	s.Toggle(2)
+	s.MarkRange(ids, 3)
+	s.MarkRange(ids, 5)
+	if got, want := markedIDs(s, ids), []int{2, 3, 4, 5}; !slices.Equal(got, want) {
+		t.Errorf("marked %v, want %v", got, want)
+	}
+}
+
+func TestSelectionToggleAll(t *testing.T) {
+	ids := []int{1, 2, 3}
+	tests := []struct {
+		name   string
+		marked []int
+		want   []int
+		len    int
+	}{
+		{"none marked", nil, []int{1, 2, 3}, 3},
+		{"some marked", []int{2}, []int{1, 2, 3}, 3},
+		{"all marked", []int{1, 2, 3}, nil, 0},
+		{"all marked and more", []int{1, 2, 3, 9}, nil, 1},
+		{"others marked", []int{9}, []int{1, 2, 3}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Selection
+			for _, id := range tt.marked {
+				s.Toggle(id)
+			}
+			s.ToggleAll(ids)
+			if got := markedIDs(s, ids); !slices.Equal(got, tt.want) {
+				t.Errorf("marked %v, want %v", got, tt.want)
+			}
+			if s.Len() != tt.len {
+				t.Errorf("Len() = %d, want %d", s.Len(), tt.len)
+			}
+		})
+	}
+}
+
+func TestSelectionKeepAndClear(t *testing.T) {
+	var s Selection
+	for _, id := range []int{1, 2, 3} {
+		s.Toggle(id)
+	}
+	s.Keep([]int{2, 3, 4})
+	if got, want := markedIDs(s, []int{1, 2, 3, 4}), []int{2, 3}; !slices.Equal(got, want) {
+		t.Errorf("after Keep marked %v, want %v", got, want)
+	}
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("after Clear Len() = %d, want 0", s.Len())
+	}
+	s.MarkRange([]int{1, 2, 3}, 3)
+	if got, want := markedIDs(s, []int{1, 2, 3}), []int{3}; !slices.Equal(got, want) {
+		t.Errorf("range after Clear marked %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// spoilerKind is the AST node kind of a ||spoiler|| span.
+var spoilerKind = ast.NewNodeKind("Spoiler")
+
+// spoilerNode is an inline ||spoiler|| span. Being inline, it never
+// reaches past its paragraph, and code spans keep their pipes.
+type spoilerNode struct {
+	ast.BaseInline
+}
+
+func (n *spoilerNode) Kind() ast.NodeKind { return spoilerKind }
+
+func (n *spoilerNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// spoilerDelimiters pairs up || delimiters within a paragraph.
+type spoilerDelimiters struct{}
+
+func (spoilerDelimiters) IsDelimiter(b byte) bool { return b == '|' }
+
+func (spoilerDelimiters) CanOpenCloser(opener, closer *parser.Delimiter) bool {
+	return opener.Length == 2 && closer.Length == 2
+}
+
+func (spoilerDelimiters) OnMatch(consumes int) ast.Node { return &spoilerNode{} }
+
+// spoilerParser finds || delimiters. Unlike emphasis, a spoiler may start
+// or end next to a space, as in "|| the twist ||".
+type spoilerParser struct{}
+
+func (spoilerParser) Trigger() []byte { return []byte{'|'} }
+
+func (spoilerParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	if block.PrecendingCharacter() == '|' {
+		return nil
+	}
+	line, segment := block.PeekLine()
+	n := 0
+	for n < len(line) && line[n] == '|' {
+		n++
+	}
+	if n != 2 {
+		return nil
+	}
+	d := parser.NewDelimiter(true, true, n, '|', spoilerDelimiters{})
+	d.Segment = segment.WithStop(segment.Start + n)
+	block.Advance(n)
+	pc.PushDelimiter(d)
+	return d
+}
+
+// spoilerRenderer writes spoilers as the spans Hardcover stores, and any
+// HTML typed into a review as text.
+type spoilerRenderer struct{}
+
+func (spoilerRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(spoilerKind, renderSpoiler)
+	reg.Register(ast.KindRawHTML, renderRawHTMLAsText)
+	reg.Register(ast.KindHTMLBlock, renderHTMLBlockAsText)
+}
+
+func renderSpoiler(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString(`<span class="spoiler">`)
+	} else {
+		_, _ = w.WriteString("</span>")
+	}
+	return ast.WalkContinue, nil
+}
+
+func renderRawHTMLAsText(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		segs := n.(*ast.RawHTML).Segments
+		for i := 0; i < segs.Len(); i++ {
+			seg := segs.At(i)
+			html.DefaultWriter.RawWrite(w, seg.Value(source))
+		}
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func renderHTMLBlockAsText(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	b := n.(*ast.HTMLBlock)
+	_, _ = w.WriteString("<p>")
+	for i := 0; i < b.Lines().Len(); i++ {
+		line := b.Lines().At(i)
+		html.DefaultWriter.RawWrite(w, line.Value(source))
+	}
+	if b.HasClosure() {
+		html.DefaultWriter.RawWrite(w, b.ClosureLine.Value(source))
+	}
+	_, _ = w.WriteString("</p>\n")
+	return ast.WalkContinue, nil
+}
+
+// spoilers adds ||spoiler|| spans to a goldmark parser and renderer.
+type spoilers struct{}
+
+func (spoilers) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithInlineParsers(
+		util.Prioritized(spoilerParser{}, 500),
+	))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(spoilerRenderer{}, 100),
+	))
+}
@@ -0,0 +1,148 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/service"
+)
+
+// Undo reverses a change the user made, such as a deleted list or a new
+// status. The change is reversed by new requests, so a recreated list or
+// book gets a new ID.
+type Undo struct {
+	Desc string // what undoing did, shown once it succeeds
+	Run  func(ctx context.Context) error
+}
+
+// UndoableMsg is sent by a screen after a change that can be undone. The
+// app shows Message as a toast and keeps Undo for the undo key.
+type UndoableMsg struct {
+	Level   NotifyLevel
+	Message string
+	Origin  string // screen that sent it, filled in by the app
+	Undo    Undo
+}
+
+// NotifyUndoableCmd reports a change that undo can reverse, like NotifyCmd.
+func NotifyUndoableCmd(level NotifyLevel, message string, undo Undo) tea.Cmd {
+	return func() tea.Msg {
+		return UndoableMsg{Level: level, Message: message, Undo: undo}
+	}
+}
+
+// UndoStatus sets a user book's status back to prev.
+func UndoStatus(svc service.HardcoverService, userBookID int, prev api.StatusID) Undo {
+	return Undo{
+		Desc: "Status set back to " + prev.String(),
+		Run: func(ctx context.Context) error {
+			return svc.UpdateUserBookStatus(ctx, userBookID, int(prev))
+		},
+	}
+}
+
+// UndoRating sets a user book's rating back to prev, clearing it if the
+// book was not rated.
+func UndoRating(svc service.HardcoverService, userBookID int, prev *float64) Undo {
+	if prev == nil {
+		return Undo{
+			Desc: "Rating cleared",
+			Run: func(ctx context.Context) error {
+				return svc.ClearUserBookRating(ctx, userBookID)
+			},
+		}
+	}
+	rating := *prev
+	return Undo{
+		Desc: fmt.Sprintf("Rating set back to %.1f", rating),
+		Run: func(ctx context.Context) error {
+			return svc.UpdateUserBookRating(ctx, userBookID, rating)
+		},
+	}
+}
+
+// UndoListBookRemoval puts a book back on a list, at the end.
+func UndoListBookRemoval(svc service.HardcoverService, listID int, listName string, bookID int) Undo {
+	return Undo{
+		Desc: "Book put back on " + listName,
+		Run: func(ctx context.Context) error {
+			return svc.InsertListBook(ctx, listID, bookID)
+		},
+	}
+}
+
+// UndoListDelete recreates a deleted list with its description, privacy
+// and books.
+func UndoListDelete(svc service.HardcoverService, l api.List, bookIDs []int) Undo {
+	return Undo{
+		Desc: fmt.Sprintf("List %q restored", l.Name),
+		Run: func(ctx context.Context) error {
+			desc := ""
+			if l.Description != nil {
+				desc = *l.Description
+			}
+			created, err := svc.InsertList(ctx, l.Name, desc)
+			if err != nil {
+				return err
+			}
+			// New lists start public.
+			if l.PrivacySettingID != 0 && l.PrivacySettingID != int(api.PrivacyPublic) {
+				if err := svc.UpdateList(ctx, created.ID, l.Name, desc, l.PrivacySettingID); err != nil {
+					return err
+				}
+			}
+			var errs []error
+			for _, id := range bookIDs {
+				if err := svc.InsertListBook(ctx, created.ID, id); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			if len(errs) > 0 {
+				return fmt.Errorf("list restored without %d books: %w", len(errs), errors.Join(errs...))
+			}
+			return nil
+		},
+	}
+}
+
+// UndoUserBookDelete adds a deleted book back to the library with its
+// status and rating. Reads and reviews are not restored.
+func UndoUserBookDelete(svc service.HardcoverService, ub api.UserBook) Undo {
+	return Undo{
+		Desc: fmt.Sprintf("%q back in your library", ub.Book.Title),
+		Run: func(ctx context.Context) error {
+			created, err := svc.InsertUserBook(ctx, ub.BookID, ub.StatusID)
+			if err != nil {
+				return err
+			}
+			if ub.Rating != nil && created != nil {
+				return svc.UpdateUserBookRating(ctx, created.ID, *ub.Rating)
+			}
+			return nil
+		},
+	}
+}
+
+// UndoJournalDelete writes a deleted journal entry again, keeping its
+// date. It reports false for entries without a book, which cannot be
+// recreated.
+func UndoJournalDelete(svc service.HardcoverService, j api.ReadingJournal) (Undo, bool) {
+	if j.BookID == nil {
+		return Undo{}, false
+	}
+	bookID := *j.BookID
+	entry := ""
+	if j.Entry != nil {
+		entry = *j.Entry
+	}
+	return Undo{
+		Desc: "Journal entry restored",
+		Run: func(ctx context.Context) error {
+			return svc.InsertReadingJournalEvent(ctx, bookID, j.Event, entry, j.ParseMetadata(), j.ActionAt)
+		},
+	}, true
+}
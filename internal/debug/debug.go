@@ -0,0 +1,170 @@
+// Package debug writes a structured log of API calls and app messages when
+// the app runs with --debug, and keeps recent samples in memory for the
+// developer overlay.
+package debug
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Sample buffer sizes for the overlay.
+const (
+	maxRequests = 50
+	maxRenders  = 120
+
+	// slowRender is the frame time above which a render is logged.
+	slowRender = 50 * time.Millisecond
+)
+
+// Request is one API round trip.
+type Request struct {
+	At        time.Time
+	Operation string // "query user_books", "mutation update_user_book", ...
+	Variables map[string]any
+	Wait      time.Duration // time spent in the rate limiter before sending
+	Latency   time.Duration
+	Status    int // HTTP status, 0 if no response arrived
+	Err       string
+}
+
+// Snapshot is a copy of the recent samples.
+type Snapshot struct {
+	Requests []Request // oldest first
+	Renders  []time.Duration
+	Messages int
+	Startup  time.Duration // 0 until the first screen has loaded
+}
+
+// quietMsgs are animation and pointer messages that arrive many times a
+// second and would drown out everything else in the log.
+var quietMsgs = map[string]bool{
+	"spinner.TickMsg":        true,
+	"cursor.BlinkMsg":        true,
+	"cursor.initialBlinkMsg": true,
+	"progress.FrameMsg":      true,
+	"bubbleup.tickMsg":       true,
+	"common.LoaderFrameMsg":  true,
+	"tea.MouseMsg":           true,
+	"home.clockTickMsg":      true,
+	"app.debugTickMsg":       true,
+}
+
+var (
+	mu       sync.Mutex
+	logger   *slog.Logger
+	requests []Request
+	renders  []time.Duration
+	messages int
+	startup  time.Duration
+)
+
+// Start enables debugging and writes JSON log lines to path until the
+// returned closer is closed.
+func Start(path string) (io.Closer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("debug log: %w", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	logger = slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	logger.Info("debug log started", "pid", os.Getpid())
+	return f, nil
+}
+
+// Enabled reports whether Start has been called.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return logger != nil
+}
+
+// LogRequest records an API round trip.
+func LogRequest(r Request) {
+	mu.Lock()
+	defer mu.Unlock()
+	if logger == nil {
+		return
+	}
+	requests = appendBounded(requests, r, maxRequests)
+
+	attrs := []any{
+		"operation", r.Operation,
+		"variables", r.Variables,
+		"wait_ms", r.Wait.Milliseconds(),
+		"latency_ms", r.Latency.Milliseconds(),
+		"status", r.Status,
+	}
+	if r.Err != "" {
+		logger.Error("api request", append(attrs, "error", r.Err)...)
+		return
+	}
+	logger.Debug("api request", attrs...)
+}
+
+// LogMsg records the type of a message passing through the root model's
+// Update. Only the type is logged, never the contents, so typed tokens
+// and text stay out of the file.
+func LogMsg(msg any) {
+	mu.Lock()
+	defer mu.Unlock()
+	if logger == nil {
+		return
+	}
+	messages++
+	name := fmt.Sprintf("%T", msg)
+	if quietMsgs[name] {
+		return
+	}
+	logger.Debug("msg", "type", name)
+}
+
+// LogStartup records how long the app took from launch until the first
+// screen finished loading.
+func LogStartup(d time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	if logger == nil {
+		return
+	}
+	startup = d
+	logger.Info("startup", "ms", d.Milliseconds())
+}
+
+// LogRender records how long a full frame took to render.
+func LogRender(d time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	if logger == nil {
+		return
+	}
+	renders = appendBounded(renders, d, maxRenders)
+	if d > slowRender {
+		logger.Warn("slow render", "ms", d.Milliseconds())
+	}
+}
+
+// Recent returns a copy of the samples kept for the overlay.
+func Recent() Snapshot {
+	mu.Lock()
+	defer mu.Unlock()
+	return Snapshot{
+		Requests: append([]Request(nil), requests...),
+		Renders:  append([]time.Duration(nil), renders...),
+		Messages: messages,
+		Startup:  startup,
+	}
+}
+
+func appendBounded[T any](s []T, v T, limit int) []T {
+	s = append(s, v)
+	if len(s) > limit {
+		s = s[len(s)-limit:]
+	}
+	return s
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/api/mutations"
+	"github.com/NotMugil/hardcover-tui/internal/api/queries"
+)
+
+// GraphQL implements HardcoverService against the Hardcover GraphQL API.
+type GraphQL struct {
+	client *api.Client
+}
+
+var _ HardcoverService = (*GraphQL)(nil)
+
+// NewGraphQL returns a service backed by client.
+func NewGraphQL(client *api.Client) *GraphQL {
+	return &GraphQL{client: client}
+}
+
+func (g *GraphQL) GetMe(ctx context.Context) (*api.User, error) {
+	return queries.GetMe(ctx, g.client)
+}
+
+func (g *GraphQL) GetUserByUsername(ctx context.Context, username string) (*api.User, error) {
+	return queries.GetUserByUsername(ctx, g.client, username)
+}
+
+func (g *GraphQL) GetDashboard(ctx context.Context, statusID *int, limit, offset, activityLimit int) (*api.Dashboard, error) {
+	return queries.GetDashboard(ctx, g.client, statusID, limit, offset, activityLimit)
+}
+
+func (g *GraphQL) GetUserBooks(ctx context.Context, userID int, statusID *int, limit, offset int) ([]api.UserBook, error) {
+	return queries.GetUserBooks(ctx, g.client, userID, statusID, limit, offset)
+}
+
+func (g *GraphQL) GetUserBookByPK(ctx context.Context, id int) (*api.UserBook, error) {
+	return queries.GetUserBookByPK(ctx, g.client, id)
+}
+
+func (g *GraphQL) GetUserBookByBookID(ctx context.Context, userID, bookID int) (*api.UserBook, error) {
+	return queries.GetUserBookByBookID(ctx, g.client, userID, bookID)
+}
+
+func (g *GraphQL) GetUserBookStats(ctx context.Context, userID int) (*api.UserBookAggregate, error) {
+	return queries.GetUserBookStats(ctx, g.client, userID)
+}
+
+func (g *GraphQL) GetUserBookStatusCounts(ctx context.Context, userID int) (map[api.StatusID]int, error) {
+	return queries.GetUserBookStatusCounts(ctx, g.client, userID)
+}
+
+func (g *GraphQL) GetUserBooksForStats(ctx context.Context, userID int) ([]api.StatsUserBook, error) {
+	return queries.GetUserBooksForStats(ctx, g.client, userID)
+}
+
+func (g *GraphQL) GetReadingHistory(ctx context.Context, userID int) ([]api.ReadingHistoryEntry, error) {
+	return queries.GetReadingHistory(ctx, g.client, userID)
+}
+
+func (g *GraphQL) InsertUserBook(ctx context.Context, bookID, statusID int) (*api.UserBook, error) {
+	return mutations.InsertUserBook(ctx, g.client, bookID, statusID)
+}
+
+func (g *GraphQL) UpdateUserBookStatus(ctx context.Context, userBookID, statusID int) error {
+	return mutations.UpdateUserBookStatus(ctx, g.client, userBookID, statusID)
+}
+
+func (g *GraphQL) UpdateUserBookRating(ctx context.Context, userBookID int, rating float64) error {
+	return mutations.UpdateUserBookRating(ctx, g.client, userBookID, rating)
+}
+
+func (g *GraphQL) ClearUserBookRating(ctx context.Context, userBookID int) error {
+	return mutations.ClearUserBookRating(ctx, g.client, userBookID)
+}
+
+func (g *GraphQL) UpdateUserBookPrivacy(ctx context.Context, userBookID, privacySettingID int) error {
+	return mutations.UpdateUserBookPrivacy(ctx, g.client, userBookID, privacySettingID)
+}
+
+func (g *GraphQL) UpdateUserBookReview(ctx context.Context, userBookID int, review, reviewHTML string, hasSpoilers bool) error {
+	return mutations.UpdateUserBookReview(ctx, g.client, userBookID, review, reviewHTML, hasSpoilers)
+}
+
+func (g *GraphQL) DeleteUserBook(ctx context.Context, userBookID int) error {
+	return mutations.DeleteUserBook(ctx, g.client, userBookID)
+}
+
+func (g *GraphQL) UpdateUserBookRead(ctx context.Context, readID int, progressPages *int) error {
+	return mutations.UpdateUserBookRead(ctx, g.client, readID, progressPages)
+}
+
+func (g *GraphQL) UpdateUserBookReadDates(ctx context.Context, readID int, startedAt, finishedAt *string) error {
+	return mutations.UpdateUserBookReadDates(ctx, g.client, readID, startedAt, finishedAt)
+}
+
+func (g *GraphQL) UpdateUserProfile(ctx context.Context, name, bio, location string) error {
+	return mutations.UpdateUserProfile(ctx, g.client, name, bio, location)
+}
+
+func (g *GraphQL) Search(ctx context.Context, query string) ([]api.Book, error) {
+	return queries.Search(ctx, g.client, query)
+}
+
+func (g *GraphQL) SearchPage(ctx context.Context, query string, queryType api.SearchType, page int) (*api.SearchPage, error) {
+	return queries.SearchPage(ctx, g.client, query, queryType, page)
+}
+
+func (g *GraphQL) GetBookByID(ctx context.Context, bookID int) (*api.Book, error) {
+	return queries.GetBookByID(ctx, g.client, bookID)
+}
+
+func (g *GraphQL) GetBookBySlug(ctx context.Context, slug string) (*api.Book, error) {
+	return queries.GetBookBySlug(ctx, g.client, slug)
+}
+
+func (g *GraphQL) GetBookTags(ctx context.Context, bookID int) ([]api.TagItem, []api.TagItem, []api.TagItem, error) {
+	return queries.GetBookTags(ctx, g.client, bookID)
+}
+
+func (g *GraphQL) GetBookReviews(ctx context.Context, bookID, limit int) ([]api.BookReview, error) {
+	return queries.GetBookReviews(ctx, g.client, bookID, limit)
+}
+
+func (g *GraphQL) GetBookSeries(ctx context.Context, bookID int) ([]api.BookSeries, error) {
+	return queries.GetBookSeries(ctx, g.client, bookID)
+}
+
+func (g *GraphQL) GetSeries(ctx context.Context, seriesID, userID int) (*api.SeriesDetail, error) {
+	return queries.GetSeries(ctx, g.client, seriesID, userID)
+}
+
+func (g *GraphQL) GetSeriesInProgress(ctx context.Context, userID, limit int) ([]api.SeriesDetail, error) {
+	return queries.GetSeriesInProgress(ctx, g.client, userID, limit)
+}
+
+func (g *GraphQL) GetActivities(ctx context.Context, userID int, limit int) ([]api.Activity, error) {
+	return queries.GetActivities(ctx, g.client, userID, limit)
+}
+
+func (g *GraphQL) GetForYouActivities(ctx context.Context, userID int, limit int) ([]api.Activity, error) {
+	return queries.GetForYouActivities(ctx, g.client, userID, limit)
+}
+
+func (g *GraphQL) GetGoals(ctx context.Context, userID int) ([]api.Goal, error) {
+	return queries.GetGoals(ctx, g.client, userID)
+}
+
+func (g *GraphQL) GetLists(ctx context.Context, userID int) ([]api.List, error) {
+	return queries.GetLists(ctx, g.client, userID)
+}
+
+func (g *GraphQL) GetListBySlug(ctx context.Context, username, slug string) (*api.List, error) {
+	return queries.GetListBySlug(ctx, g.client, username, slug)
+}
+
+func (g *GraphQL) GetListBooks(ctx context.Context, listID int) ([]api.ListBook, error) {
+	return queries.GetListBooks(ctx, g.client, listID)
+}
+
+func (g *GraphQL) InsertList(ctx context.Context, name, description string) (*api.List, error) {
+	return mutations.InsertList(ctx, g.client, name, description)
+}
+
+func (g *GraphQL) UpdateList(ctx context.Context, listID int, name, description string, privacySettingID int) error {
+	return mutations.UpdateList(ctx, g.client, listID, name, description, privacySettingID)
+}
+
+func (g *GraphQL) DeleteList(ctx context.Context, listID int) error {
+	return mutations.DeleteList(ctx, g.client, listID)
+}
+
+func (g *GraphQL) InsertListBook(ctx context.Context, listID, bookID int) error {
+	return mutations.InsertListBook(ctx, g.client, listID, bookID)
+}
+
+func (g *GraphQL) DeleteListBook(ctx context.Context, listBookID int) error {
+	return mutations.DeleteListBook(ctx, g.client, listBookID)
+}
+
+func (g *GraphQL) GetReadingJournals(ctx context.Context, userID int, limit int) ([]api.ReadingJournal, error) {
+	return queries.GetReadingJournals(ctx, g.client, userID, limit)
+}
+
+func (g *GraphQL) GetReadingJournalsPage(ctx context.Context, userID int, search string, limit, offset int) ([]api.ReadingJournal, error) {
+	return queries.GetReadingJournalsPage(ctx, g.client, userID, search, limit, offset)
+}
+
+func (g *GraphQL) InsertReadingJournalEvent(ctx context.Context, bookID int, event, entry string, meta api.JournalMetadata, actionAt string) error {
+	return mutations.InsertReadingJournalEvent(ctx, g.client, bookID, event, entry, meta, actionAt)
+}
+
+func (g *GraphQL) DeleteReadingJournal(ctx context.Context, journalID int) error {
+	return mutations.DeleteReadingJournal(ctx, g.client, journalID)
+}
@@ -0,0 +1,69 @@
+// Package service defines the operations the UI performs against Hardcover.
+// Screens depend on HardcoverService rather than on the GraphQL client, so
+// they can be driven by any implementation.
+package service
+
+import (
+	"context"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+)
+
+// HardcoverService is every read and write the app makes against Hardcover.
+type HardcoverService interface {
+	// User and library
+	GetMe(ctx context.Context) (*api.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*api.User, error)
+	GetDashboard(ctx context.Context, statusID *int, limit, offset, activityLimit int) (*api.Dashboard, error)
+	GetUserBooks(ctx context.Context, userID int, statusID *int, limit, offset int) ([]api.UserBook, error)
+	GetUserBookByPK(ctx context.Context, id int) (*api.UserBook, error)
+	GetUserBookByBookID(ctx context.Context, userID, bookID int) (*api.UserBook, error)
+	GetUserBookStats(ctx context.Context, userID int) (*api.UserBookAggregate, error)
+	GetUserBookStatusCounts(ctx context.Context, userID int) (map[api.StatusID]int, error)
+	GetUserBooksForStats(ctx context.Context, userID int) ([]api.StatsUserBook, error)
+	GetReadingHistory(ctx context.Context, userID int) ([]api.ReadingHistoryEntry, error)
+	InsertUserBook(ctx context.Context, bookID, statusID int) (*api.UserBook, error)
+	UpdateUserBookStatus(ctx context.Context, userBookID, statusID int) error
+	UpdateUserBookRating(ctx context.Context, userBookID int, rating float64) error
+	ClearUserBookRating(ctx context.Context, userBookID int) error
+	UpdateUserBookReview(ctx context.Context, userBookID int, review, reviewHTML string, hasSpoilers bool) error
+	UpdateUserBookPrivacy(ctx context.Context, userBookID, privacySettingID int) error
+	DeleteUserBook(ctx context.Context, userBookID int) error
+	UpdateUserBookRead(ctx context.Context, readID int, progressPages *int) error
+	UpdateUserBookReadDates(ctx context.Context, readID int, startedAt, finishedAt *string) error
+	UpdateUserProfile(ctx context.Context, name, bio, location string) error
+
+	// Books and search
+	Search(ctx context.Context, query string) ([]api.Book, error)
+	SearchPage(ctx context.Context, query string, queryType api.SearchType, page int) (*api.SearchPage, error)
+	GetBookByID(ctx context.Context, bookID int) (*api.Book, error)
+	GetBookBySlug(ctx context.Context, slug string) (*api.Book, error)
+	GetBookTags(ctx context.Context, bookID int) (genres, moods, contentWarnings []api.TagItem, err error)
+	GetBookReviews(ctx context.Context, bookID, limit int) ([]api.BookReview, error)
+
+	// Series
+	GetBookSeries(ctx context.Context, bookID int) ([]api.BookSeries, error)
+	GetSeries(ctx context.Context, seriesID, userID int) (*api.SeriesDetail, error)
+	GetSeriesInProgress(ctx context.Context, userID, limit int) ([]api.SeriesDetail, error)
+
+	// Activity and goals
+	GetActivities(ctx context.Context, userID int, limit int) ([]api.Activity, error)
+	GetForYouActivities(ctx context.Context, userID int, limit int) ([]api.Activity, error)
+	GetGoals(ctx context.Context, userID int) ([]api.Goal, error)
+
+	// Lists
+	GetLists(ctx context.Context, userID int) ([]api.List, error)
+	GetListBySlug(ctx context.Context, username, slug string) (*api.List, error)
+	GetListBooks(ctx context.Context, listID int) ([]api.ListBook, error)
+	InsertList(ctx context.Context, name, description string) (*api.List, error)
+	UpdateList(ctx context.Context, listID int, name, description string, privacySettingID int) error
+	DeleteList(ctx context.Context, listID int) error
+	InsertListBook(ctx context.Context, listID, bookID int) error
+	DeleteListBook(ctx context.Context, listBookID int) error
+
+	// Reading journal
+	GetReadingJournals(ctx context.Context, userID int, limit int) ([]api.ReadingJournal, error)
+	GetReadingJournalsPage(ctx context.Context, userID int, search string, limit, offset int) ([]api.ReadingJournal, error)
+	InsertReadingJournalEvent(ctx context.Context, bookID int, event, entry string, meta api.JournalMetadata, actionAt string) error
+	DeleteReadingJournal(ctx context.Context, journalID int) error
+}
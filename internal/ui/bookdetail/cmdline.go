@@ -0,0 +1,170 @@
+package bookdetail
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/common"
+)
+
+var errNotInLibrary = errors.New("add the book to your library first")
+
+// Commands returns the ":" commands for the book shown. They run the same
+// updates as the status, rating and list overlays.
+func (m *Model) Commands() []common.Command {
+	return []common.Command{
+		{
+			Name:     "status",
+			Usage:    "<status>",
+			Help:     "set the reading status",
+			Complete: completeStatus,
+			Run:      m.runStatus,
+		},
+		{
+			Name:  "rate",
+			Usage: "<0.5-5>",
+			Help:  "rate the book in half stars",
+			Run:   m.runRate,
+		},
+		{
+			Name:  "progress",
+			Usage: "<page>",
+			Help:  "set the current page",
+			Run:   m.runProgress,
+		},
+		{
+			Name:     "list",
+			Usage:    "add <list> | remove",
+			Help:     "add the book to a list, or remove it from the list it was opened from",
+			Complete: m.completeList,
+			Run:      m.runList,
+		},
+	}
+}
+
+func completeStatus(args []string) []string {
+	if len(args) != 1 {
+		return nil
+	}
+	var slugs []string
+	for _, s := range api.AllStatuses() {
+		slugs = append(slugs, s.Slug())
+	}
+	return common.CompletePrefix(args[0], slugs)
+}
+
+func (m *Model) completeList(args []string) []string {
+	if len(args) <= 1 {
+		return common.CompletePrefix(strings.Join(args, ""), []string{"add", "remove"})
+	}
+	if args[0] != "add" || len(args) > 2 {
+		return nil
+	}
+	var names []string
+	for _, l := range m.userLists {
+		names = append(names, l.Name)
+	}
+	return common.CompletePrefix(args[1], names)
+}
+
+func (m *Model) runStatus(args []string) (tea.Cmd, error) {
+	if len(args) != 1 {
+		return nil, common.ErrUsage
+	}
+	status, ok := api.ParseStatus(args[0])
+	if !ok {
+		return nil, fmt.Errorf("unknown status %q", args[0])
+	}
+	if m.loading {
+		return nil, errors.New("the book is still loading")
+	}
+	if m.userBook == nil {
+		bid := m.BookID()
+		if bid == 0 {
+			return nil, errors.New("the book is still loading")
+		}
+		m.loading = true
+		return tea.Batch(m.spinner.Tick, m.addToLibrary(bid, int(status))), nil
+	}
+	m.loading = true
+	return tea.Batch(m.spinner.Tick, m.updateStatus(int(status))), nil
+}
+
+func (m *Model) runRate(args []string) (tea.Cmd, error) {
+	if len(args) != 1 {
+		return nil, common.ErrUsage
+	}
+	rating, err := strconv.ParseFloat(args[0], 64)
+	if err != nil || rating < 0.5 || rating > 5 || math.Mod(rating, 0.5) != 0 {
+		return nil, errors.New("rating must be 0.5 to 5 in half stars")
+	}
+	if m.userBook == nil {
+		return nil, errNotInLibrary
+	}
+	if m.loading {
+		return nil, errors.New("the book is still loading")
+	}
+	m.loading = true
+	return tea.Batch(m.spinner.Tick, m.updateRating(rating)), nil
+}
+
+func (m *Model) runProgress(args []string) (tea.Cmd, error) {
+	if len(args) != 1 {
+		return nil, common.ErrUsage
+	}
+	pages, err := strconv.Atoi(args[0])
+	if err != nil || pages < 0 {
+		return nil, errors.New("page must be a whole number")
+	}
+	if m.userBook == nil {
+		return nil, errNotInLibrary
+	}
+	if len(m.userBook.UserBookReads) == 0 {
+		return nil, errors.New("no active read found")
+	}
+	if m.loading {
+		return nil, errors.New("the book is still loading")
+	}
+	m.loading = true
+	return tea.Batch(m.spinner.Tick, m.updateProgress(pages)), nil
+}
+
+func (m *Model) runList(args []string) (tea.Cmd, error) {
+	if len(args) == 0 {
+		return nil, common.ErrUsage
+	}
+	switch args[0] {
+	case "add":
+		name := strings.Join(args[1:], " ")
+		if name == "" {
+			return nil, common.ErrUsage
+		}
+		bid := m.BookID()
+		if bid == 0 {
+			return nil, errors.New("the book is still loading")
+		}
+		m.listLoading = true
+		for _, l := range m.userLists {
+			if strings.EqualFold(l.Name, name) {
+				return tea.Batch(m.spinner.Tick, m.addBookToList(l.ID, l.Name, bid)), nil
+			}
+		}
+		return tea.Batch(m.spinner.Tick, m.addBookToNamedList(name, bid)), nil
+	case "remove":
+		if len(args) != 1 {
+			return nil, common.ErrUsage
+		}
+		if m.listID == 0 {
+			return nil, errors.New("the book was not opened from a list")
+		}
+		m.loading = true
+		return tea.Batch(m.spinner.Tick, m.removeBookFromCurrentList()), nil
+	}
+	return nil, common.ErrUsage
+}
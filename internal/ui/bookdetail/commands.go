@@ -3,38 +3,38 @@ package bookdetail
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
-	"github.com/NotMugil/hardcover-tui/internal/api/mutations"
-	"github.com/NotMugil/hardcover-tui/internal/api/queries"
+	"github.com/NotMugil/hardcover-tui/internal/api"
 	"github.com/NotMugil/hardcover-tui/internal/common"
 )
 
 func (m *Model) loadBook() tea.Cmd {
-	client := m.client
+	svc := m.svc
 	id := m.bookID
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		ub, err := queries.GetUserBookByPK(ctx, client, id)
+		ub, err := svc.GetUserBookByPK(ctx, id)
 		return bookLoadedMsg{userBook: ub, err: err}
 	}
 }
 
 func (m *Model) loadBookByBookID() tea.Cmd {
-	client := m.client
+	svc := m.svc
 	user := m.user
 	bookID := m.bookID
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		book, err := queries.GetBookByID(ctx, client, bookID)
+		book, err := svc.GetBookByID(ctx, bookID)
 		if err != nil {
 			return bookFromBookIDMsg{err: err}
 		}
-		ub, _ := queries.GetUserBookByBookID(ctx, client, user.ID, bookID)
+		ub, _ := svc.GetUserBookByBookID(ctx, user.ID, bookID)
 		return bookFromBookIDMsg{book: book, userBook: ub}
 	}
 }
@@ -50,122 +50,204 @@ func (m *Model) loadCover(url string) tea.Cmd {
 }
 
 func (m *Model) loadTags(bookID int) tea.Cmd {
-	client := m.client
+	svc := m.svc
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
-		genres, _, _, err := queries.GetBookTags(ctx, client, bookID)
+		genres, _, _, err := svc.GetBookTags(ctx, bookID)
 		return tagsLoadedMsg{genres: genres, err: err}
 	}
 }
 
 func (m *Model) loadReviews(bookID int) tea.Cmd {
-	client := m.client
+	svc := m.svc
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
-		reviews, err := queries.GetBookReviews(ctx, client, bookID, 5)
+		reviews, err := svc.GetBookReviews(ctx, bookID, 5)
 		return reviewsLoadedMsg{reviews: reviews, err: err}
 	}
 }
 
+// loadSeries fetches the series the book belongs to, preferring one it is
+// numbered in. memberships are looked up when the book came without them.
+func (m *Model) loadSeries(bookID int, memberships []api.BookSeries) tea.Cmd {
+	svc := m.svc
+	userID := m.user.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		if memberships == nil {
+			var err error
+			memberships, err = svc.GetBookSeries(ctx, bookID)
+			if err != nil {
+				return seriesLoadedMsg{err: err}
+			}
+		}
+		if len(memberships) == 0 {
+			return seriesLoadedMsg{}
+		}
+		bs := memberships[0]
+		for _, s := range memberships {
+			if s.Position != nil {
+				bs = s
+				break
+			}
+		}
+		series, err := svc.GetSeries(ctx, bs.Series.ID, userID)
+		return seriesLoadedMsg{series: series, position: bs.Position, err: err}
+	}
+}
+
 func (m *Model) updateStatus(statusID int) tea.Cmd {
-	client := m.client
+	svc := m.svc
 	ubID := m.userBook.ID
-	return func() tea.Msg {
+	undo := common.UndoStatus(svc, ubID, m.userBook.Status())
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		err := mutations.UpdateUserBookStatus(ctx, client, ubID, statusID)
-		return statusUpdatedMsg{err: err}
+		err := svc.UpdateUserBookStatus(ctx, ubID, statusID)
+		return statusUpdatedMsg{undo: undo, retry: cmd, err: err}
 	}
+	return cmd
 }
 
 func (m *Model) updateRating(rating float64) tea.Cmd {
-	client := m.client
+	svc := m.svc
 	ubID := m.userBook.ID
-	return func() tea.Msg {
+	undo := common.UndoRating(svc, ubID, m.userBook.Rating)
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		err := svc.UpdateUserBookRating(ctx, ubID, rating)
+		return ratingUpdatedMsg{undo: undo, retry: cmd, err: err}
+	}
+	return cmd
+}
+
+func (m *Model) updateProgress(pages int) tea.Cmd {
+	svc := m.svc
+	readID := m.userBook.UserBookReads[0].ID
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		err := mutations.UpdateUserBookRating(ctx, client, ubID, rating)
-		return ratingUpdatedMsg{err: err}
+		err := svc.UpdateUserBookRead(ctx, readID, &pages)
+		return progressUpdatedMsg{retry: cmd, err: err}
 	}
+	return cmd
 }
 
 func (m *Model) addToLibrary(bookID int, statusID int) tea.Cmd {
-	client := m.client
-	return func() tea.Msg {
+	svc := m.svc
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		ub, err := mutations.InsertUserBook(ctx, client, bookID, statusID)
-		return bookAddedMsg{userBook: ub, err: err}
+		ub, err := svc.InsertUserBook(ctx, bookID, statusID)
+		return bookAddedMsg{userBook: ub, retry: cmd, err: err}
 	}
+	return cmd
 }
 
 func (m *Model) loadJournals() tea.Cmd {
-	client := m.client
+	svc := m.svc
 	user := m.user
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		journals, err := queries.GetReadingJournals(ctx, client, user.ID, 20)
+		journals, err := svc.GetReadingJournals(ctx, user.ID, 20)
 		return journalsLoadedMsg{journals: journals, err: err}
 	}
 }
 
-func (m *Model) saveJournalEntry(entry string) tea.Cmd {
-	client := m.client
+func (m *Model) saveJournalEntry(event, entry string, meta api.JournalMetadata) tea.Cmd {
+	svc := m.svc
 	ub := m.userBook
 	return func() tea.Msg {
 		if ub == nil {
-			return journalSavedMsg{err: fmt.Errorf("no book selected")}
+			return journalSavedMsg{entry: entry, err: fmt.Errorf("no book selected")}
 		}
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		now := time.Now().Format("2006-01-02")
-		err := mutations.InsertReadingJournal(ctx, client, ub.BookID, "note", entry, now)
-		return journalSavedMsg{err: err}
+		err := svc.InsertReadingJournalEvent(ctx, ub.BookID, event, entry, meta, "")
+		return journalSavedMsg{entry: entry, err: err}
 	}
 }
 
-func (m *Model) deleteJournalEntry(id int) tea.Cmd {
-	client := m.client
-	user := m.user
+func (m *Model) deleteJournalEntry(j api.ReadingJournal) tea.Cmd {
+	svc := m.svc
+	undo, _ := common.UndoJournalDelete(svc, j)
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		err := mutations.DeleteReadingJournal(ctx, client, id)
-		if err != nil {
-			return journalDeletedMsg{err: err}
-		}
-		journals, err := queries.GetReadingJournals(ctx, client, user.ID, 20)
-		return journalsLoadedMsg{journals: journals, err: err}
+		err := svc.DeleteReadingJournal(ctx, j.ID)
+		return journalDeletedMsg{undo: undo, err: err}
 	}
 }
 
 func (m *Model) loadUserLists() tea.Cmd {
-	client := m.client
+	svc := m.svc
 	user := m.user
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		lists, err := queries.GetLists(ctx, client, user.ID)
+		lists, err := svc.GetLists(ctx, user.ID)
 		return userListsLoadedMsg{lists: lists, err: err}
 	}
 }
 
+func (m *Model) loadListNames() tea.Cmd {
+	svc := m.svc
+	user := m.user
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		lists, err := svc.GetLists(ctx, user.ID)
+		return listNamesLoadedMsg{lists: lists, err: err}
+	}
+}
+
 func (m *Model) addBookToList(listID int, listName string, bookID int) tea.Cmd {
-	client := m.client
+	svc := m.svc
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		err := svc.InsertListBook(ctx, listID, bookID)
+		return bookAddedToListMsg{listName: listName, retry: cmd, err: err}
+	}
+	return cmd
+}
+
+// addBookToNamedList adds the book to the user's list called name,
+// ignoring case.
+func (m *Model) addBookToNamedList(name string, bookID int) tea.Cmd {
+	svc := m.svc
+	user := m.user
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		err := mutations.InsertListBook(ctx, client, listID, bookID)
-		return bookAddedToListMsg{listName: listName, err: err}
+		lists, err := svc.GetLists(ctx, user.ID)
+		if err != nil {
+			return bookAddedToListMsg{listName: name, err: err}
+		}
+		for _, l := range lists {
+			if strings.EqualFold(l.Name, name) {
+				err := svc.InsertListBook(ctx, l.ID, bookID)
+				return bookAddedToListMsg{listName: l.Name, err: err}
+			}
+		}
+		return bookAddedToListMsg{listName: name, err: fmt.Errorf("no list named %q", name)}
 	}
 }
 
 func (m *Model) removeBookFromCurrentList() tea.Cmd {
-	client := m.client
+	svc := m.svc
 	listID := m.listID
+	listName := m.listName
 	bookID := m.bookID
 	if m.book != nil {
 		bookID = m.book.ID
@@ -175,14 +257,14 @@ func (m *Model) removeBookFromCurrentList() tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		books, err := queries.GetListBooks(ctx, client, listID)
+		books, err := svc.GetListBooks(ctx, listID)
 		if err != nil {
 			return bookRemovedFromListMsg{err: err}
 		}
 		for _, lb := range books {
 			if lb.BookID == bookID {
-				err = mutations.DeleteListBook(ctx, client, lb.ID)
-				return bookRemovedFromListMsg{err: err}
+				err = svc.DeleteListBook(ctx, lb.ID)
+				return bookRemovedFromListMsg{undo: common.UndoListBookRemoval(svc, listID, listName, bookID), err: err}
 			}
 		}
 		return bookRemovedFromListMsg{err: fmt.Errorf("book not found in list")}
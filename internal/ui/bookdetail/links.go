@@ -0,0 +1,42 @@
+package bookdetail
+
+import (
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/common"
+)
+
+// Links returns the pages of the review being read or selected, then of
+// the book, its authors and its series.
+func (m *Model) Links() []common.Link {
+	var book api.Book
+	switch {
+	case m.userBook != nil:
+		book = m.userBook.Book
+	case m.book != nil:
+		book = *m.book
+	default:
+		return nil
+	}
+
+	var review *api.BookReview
+	switch {
+	case m.mode == modeReviewRead:
+		review = m.selectedReview
+	case m.reviewMode:
+		if item, ok := m.reviewList.SelectedItem().(reviewItem); ok {
+			review = &item.data
+		}
+	}
+	var links []common.Link
+	if review != nil {
+		if l, ok := common.ReviewLink(book, review.User.Username); ok {
+			links = append(links, l)
+		}
+		links = append(links, common.UserLink(review.User.Username))
+	}
+	links = append(links, common.BookLinks(book)...)
+	if m.series != nil && m.series.Slug != "" {
+		links = append(links, common.SeriesLink(m.series.Name, m.series.Slug))
+	}
+	return links
+}
@@ -8,12 +8,12 @@ import (
 	"github.com/76creates/stickers/flexbox"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
-	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
 	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/service"
 )
 
 // Navigation messages for app.go to catch.
@@ -29,6 +29,13 @@ type NavigateToJournalMsg struct {
 	UserBook *api.UserBook
 }
 
+// NavigateToSeriesMsg opens the series the book belongs to.
+type NavigateToSeriesMsg struct {
+	SeriesID int
+	Name     string
+	BookID   int
+}
+
 type bookLoadedMsg struct {
 	userBook *api.UserBook
 	err      error
@@ -41,16 +48,28 @@ type bookFromBookIDMsg struct {
 	err      error
 }
 
+// Messages for changes the user makes carry the command that made them, so
+// a failure can be retried from the notification center.
 type statusUpdatedMsg struct {
-	err error
+	undo  common.Undo
+	retry tea.Cmd
+	err   error
 }
 
 type ratingUpdatedMsg struct {
-	err error
+	undo  common.Undo
+	retry tea.Cmd
+	err   error
+}
+
+type progressUpdatedMsg struct {
+	retry tea.Cmd
+	err   error
 }
 
 type bookAddedMsg struct {
 	userBook *api.UserBook
+	retry    tea.Cmd
 	err      error
 }
 
@@ -68,18 +87,33 @@ type reviewsLoadedMsg struct {
 	err     error
 }
 
+// seriesLoadedMsg carries the series the book belongs to, nil if none.
+type seriesLoadedMsg struct {
+	series   *api.SeriesDetail
+	position *float64 // the book's position in series
+	err      error
+}
+
 type userListsLoadedMsg struct {
 	lists []api.List
 	err   error
 }
 
+// listNamesLoadedMsg carries the user's lists for command completion.
+type listNamesLoadedMsg struct {
+	lists []api.List
+	err   error
+}
+
 type bookAddedToListMsg struct {
 	listName string
+	retry    tea.Cmd // nil when added by list name
 	err      error
 }
 
 type bookRemovedFromListMsg struct {
-	err error
+	undo common.Undo
+	err  error
 }
 
 type viewMode int
@@ -102,11 +136,13 @@ type journalsLoadedMsg struct {
 }
 
 type journalSavedMsg struct {
-	err error
+	entry string
+	err   error
 }
 
 type journalDeletedMsg struct {
-	err error
+	undo common.Undo // zero if the entry cannot be restored
+	err  error
 }
 
 // journalItem implements list.DefaultItem for journal entries in the inline view.
@@ -115,22 +151,11 @@ type journalItem struct {
 }
 
 func (i journalItem) Title() string {
-	date := i.data.ActionAt
-	if len(date) > 10 {
-		date = date[:10]
-	}
-	return fmt.Sprintf("%s [%s]", date, i.data.Event)
+	return common.JournalTitle(i.data, false)
 }
 
 func (i journalItem) Description() string {
-	if i.data.Entry != nil && *i.data.Entry != "" {
-		entry := *i.data.Entry
-		if len(entry) > 80 {
-			entry = entry[:77] + "..."
-		}
-		return entry
-	}
-	return ""
+	return common.JournalDescription(i.data)
 }
 
 func (i journalItem) FilterValue() string {
@@ -196,9 +221,15 @@ type ListBookEntry struct {
 	Title  string
 }
 
+// BookNotFoundMsg reports that the book a screen was opened for no longer
+// exists.
+type BookNotFoundMsg struct {
+	BookID int
+}
+
 // Model is the book detail screen model.
 type Model struct {
-	client         *api.Client
+	svc            service.HardcoverService
 	user           *api.User
 	userBook       *api.UserBook
 	book           *api.Book // standalone book (when loaded from book_id without user_book)
@@ -210,6 +241,7 @@ type Model struct {
 	coverLoading   bool // true while cover art is being fetched
 	tagsLoading    bool // true while tags/genres are being fetched
 	reviewsLoading bool // true while reviews are being fetched
+	seriesLoading  bool // true while the book's series is being fetched
 	err            error
 	mode           viewMode
 	cursor         int // for status/rating selection
@@ -218,17 +250,21 @@ type Model struct {
 	descExpanded   bool // whether the description panel is fully expanded
 	genres         []api.TagItem
 	reviews        []api.BookReview
+	series         *api.SeriesDetail
+	seriesPosition *float64
 	reviewList     list.Model
 	reviewMode     bool // true when browsing reviews list
 	reviewViewport viewport.Model
 	selectedReview *api.BookReview
+	revealSpoilers bool // show spoiler text in the review overlay
 	listBooks      []ListBookEntry
 	listIndex      int
 	listID         int
 	listName       string
 	journals       []api.ReadingJournal
 	journalList    list.Model
-	journalTA      textarea.Model
+	journalForm    common.JournalForm
+	journalFilter  common.JournalKind
 	journalLoading bool
 	journalErr     error
 	journalSuccess bool
@@ -244,18 +280,19 @@ type Model struct {
 }
 
 // NewFromUserBook creates a book detail screen from an existing UserBook.
-func NewFromUserBook(client *api.Client, user *api.User, ub *api.UserBook) *Model {
+func NewFromUserBook(svc service.HardcoverService, user *api.User, ub *api.UserBook) *Model {
 	s := spinner.New(
 		spinner.WithSpinner(spinner.Dot),
 		spinner.WithStyle(common.SpinnerStyle),
 	)
 	m := &Model{
-		client:         client,
+		svc:            svc,
 		user:           user,
 		userBook:       ub,
 		spinner:        s,
 		tagsLoading:    true,
 		reviewsLoading: true,
+		seriesLoading:  true,
 		flexBox:        newDetailFlexBox(),
 		reviewList:     newReviewList(),
 	}
@@ -267,13 +304,13 @@ func NewFromUserBook(client *api.Client, user *api.User, ub *api.UserBook) *Mode
 }
 
 // NewFromID creates a book detail screen that loads from a user_book ID.
-func NewFromID(client *api.Client, user *api.User, id int) *Model {
+func NewFromID(svc service.HardcoverService, user *api.User, id int) *Model {
 	s := spinner.New(
 		spinner.WithSpinner(spinner.Dot),
 		spinner.WithStyle(common.SpinnerStyle),
 	)
 	m := &Model{
-		client:         client,
+		svc:            svc,
 		user:           user,
 		bookID:         id,
 		spinner:        s,
@@ -281,6 +318,7 @@ func NewFromID(client *api.Client, user *api.User, id int) *Model {
 		coverLoading:   true,
 		tagsLoading:    true,
 		reviewsLoading: true,
+		seriesLoading:  true,
 		flexBox:        newDetailFlexBox(),
 		reviewList:     newReviewList(),
 	}
@@ -290,13 +328,13 @@ func NewFromID(client *api.Client, user *api.User, id int) *Model {
 
 // NewFromBookID creates a book detail screen that loads from a book ID.
 // This first fetches the book, then tries to find the user's relationship.
-func NewFromBookID(client *api.Client, user *api.User, bookID int) *Model {
+func NewFromBookID(svc service.HardcoverService, user *api.User, bookID int) *Model {
 	s := spinner.New(
 		spinner.WithSpinner(spinner.Dot),
 		spinner.WithStyle(common.SpinnerStyle),
 	)
 	m := &Model{
-		client:         client,
+		svc:            svc,
 		user:           user,
 		bookID:         bookID,
 		loadByBook:     true,
@@ -305,6 +343,7 @@ func NewFromBookID(client *api.Client, user *api.User, bookID int) *Model {
 		coverLoading:   true,
 		tagsLoading:    true,
 		reviewsLoading: true,
+		seriesLoading:  true,
 		flexBox:        newDetailFlexBox(),
 		reviewList:     newReviewList(),
 	}
@@ -314,8 +353,8 @@ func NewFromBookID(client *api.Client, user *api.User, bookID int) *Model {
 
 // NewFromListBook creates a book detail screen from a list context.
 // It loads the book by ID and stores the list of books for next/prev navigation.
-func NewFromListBook(client *api.Client, user *api.User, bookID int, listBooks []ListBookEntry, listIndex int, listID int, listName string) *Model {
-	m := NewFromBookID(client, user, bookID)
+func NewFromListBook(svc service.HardcoverService, user *api.User, bookID int, listBooks []ListBookEntry, listIndex int, listID int, listName string) *Model {
+	m := NewFromBookID(svc, user, bookID)
 	m.listBooks = listBooks
 	m.listIndex = listIndex
 	m.listID = listID
@@ -323,8 +362,21 @@ func NewFromListBook(client *api.Client, user *api.User, bookID int, listBooks [
 	return m
 }
 
+// BookID returns the ID of the book shown, or 0 if it is not known yet.
+func (m *Model) BookID() int {
+	switch {
+	case m.userBook != nil:
+		return m.userBook.BookID
+	case m.book != nil:
+		return m.book.ID
+	case m.loadByBook:
+		return m.bookID
+	}
+	return 0
+}
+
 // Loaded reports whether the book detail screen has finished its initial load
-// and all sub-resources (cover, tags, reviews) are ready.
+// and all sub-resources (cover, tags, reviews, series) are ready.
 func (m *Model) Loaded() bool {
 	if m.loading {
 		return false
@@ -332,7 +384,7 @@ func (m *Model) Loaded() bool {
 	if m.err != nil {
 		return true
 	}
-	return !m.coverLoading && !m.tagsLoading && !m.reviewsLoading
+	return !m.coverLoading && !m.tagsLoading && !m.reviewsLoading && !m.seriesLoading
 }
 
 // SetGenres pre-populates the genres from search results so they display
@@ -388,14 +440,10 @@ func (m *Model) initJournal() {
 	l.DisableQuitKeybindings()
 	l.Styles.NoItems = common.ValueStyle
 
-	ta := textarea.New()
-	ta.Placeholder = "Write a journal entry..."
-	ta.SetWidth(50)
-	ta.SetHeight(6)
-	ta.Cursor.Style = common.CursorStyle
-
 	m.journalList = l
-	m.journalTA = ta
+	m.journalForm = common.NewJournalForm()
+	m.journalForm.SetWidth(50)
+	m.journalFilter = common.JournalAll
 }
 
 func (m *Model) Init() tea.Cmd {
@@ -412,6 +460,7 @@ func (m *Model) Init() tea.Cmd {
 		}
 		cmds = append(cmds, m.loadTags(m.userBook.Book.ID))
 		cmds = append(cmds, m.loadReviews(m.userBook.Book.ID))
+		cmds = append(cmds, m.loadSeries(m.userBook.Book.ID, nil))
 	}
 	if len(cmds) > 0 {
 		return tea.Batch(cmds...)
@@ -450,6 +499,8 @@ func (m *Model) switchToListBook(idx int) {
 	m.genres = nil
 
 	m.reviews = nil
+	m.series = nil
+	m.seriesPosition = nil
 	m.reviewList.SetItems(nil)
 	m.reviewMode = false
 	m.descExpanded = false
@@ -458,4 +509,5 @@ func (m *Model) switchToListBook(idx int) {
 	m.coverLoading = true
 	m.tagsLoading = true
 	m.reviewsLoading = true
+	m.seriesLoading = true
 }
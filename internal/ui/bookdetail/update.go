@@ -1,12 +1,13 @@
 package bookdetail
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
-	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -24,6 +25,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.coverLoading = false
 			m.tagsLoading = false
 			m.reviewsLoading = false
+			m.seriesLoading = false
 			return m, nil
 		}
 		m.userBook = msg.userBook
@@ -36,10 +38,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			cmds = append(cmds, m.loadTags(msg.userBook.Book.ID))
 			cmds = append(cmds, m.loadReviews(msg.userBook.Book.ID))
+			cmds = append(cmds, m.loadSeries(msg.userBook.Book.ID, nil))
 		} else {
 			m.coverLoading = false
 			m.tagsLoading = false
 			m.reviewsLoading = false
+			m.seriesLoading = false
 		}
 		if len(cmds) > 0 {
 			return m, tea.Batch(cmds...)
@@ -53,6 +57,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.coverLoading = false
 			m.tagsLoading = false
 			m.reviewsLoading = false
+			m.seriesLoading = false
+			if errors.Is(msg.err, api.ErrNotFound) {
+				bookID := m.bookID
+				return m, func() tea.Msg { return BookNotFoundMsg{BookID: bookID} }
+			}
 			return m, nil
 		}
 		m.book = msg.book
@@ -77,9 +86,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if bid > 0 {
 			cmds = append(cmds, m.loadTags(bid))
 			cmds = append(cmds, m.loadReviews(bid))
+			var memberships []api.BookSeries
+			if msg.book != nil {
+				// Fetched with the book; empty rather than nil when it is
+				// in no series.
+				memberships = append([]api.BookSeries{}, msg.book.BookSeries...)
+			}
+			cmds = append(cmds, m.loadSeries(bid, memberships))
 		} else {
 			m.tagsLoading = false
 			m.reviewsLoading = false
+			m.seriesLoading = false
 		}
 		if len(cmds) > 0 {
 			return m, tea.Batch(cmds...)
@@ -98,6 +115,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.tagsLoading = false
 		return m, nil
 
+	case seriesLoadedMsg:
+		if msg.err == nil {
+			m.series = msg.series
+			m.seriesPosition = msg.position
+		}
+		m.seriesLoading = false
+		return m, nil
+
 	case reviewsLoadedMsg:
 		if msg.err == nil {
 			m.reviews = msg.reviews
@@ -115,34 +140,44 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mode = modeDetail
 		if msg.err != nil {
 			m.err = msg.err
-			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
+			return m, common.NotifyRetryCmd(common.NotifyError, msg.err.Error(), msg.retry)
 		}
 		if m.userBook != nil {
 			m.bookID = m.userBook.ID
 			m.loading = true
-			return m, tea.Batch(m.spinner.Tick, m.loadBook(), common.NotifyCmd(common.NotifySuccess, "Status updated"))
+			return m, tea.Batch(m.spinner.Tick, m.loadBook(), common.NotifyUndoableCmd(common.NotifySuccess, "Status updated", msg.undo))
 		}
-		return m, common.NotifyCmd(common.NotifySuccess, "Status updated")
+		return m, common.NotifyUndoableCmd(common.NotifySuccess, "Status updated", msg.undo)
 
 	case ratingUpdatedMsg:
 		m.loading = false
 		m.mode = modeDetail
 		if msg.err != nil {
 			m.err = msg.err
-			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
+			return m, common.NotifyRetryCmd(common.NotifyError, msg.err.Error(), msg.retry)
 		}
 		if m.userBook != nil {
 			m.bookID = m.userBook.ID
 			m.loading = true
-			return m, tea.Batch(m.spinner.Tick, m.loadBook(), common.NotifyCmd(common.NotifySuccess, "Rating updated"))
+			return m, tea.Batch(m.spinner.Tick, m.loadBook(), common.NotifyUndoableCmd(common.NotifySuccess, "Rating updated", msg.undo))
 		}
-		return m, common.NotifyCmd(common.NotifySuccess, "Rating updated")
+		return m, common.NotifyUndoableCmd(common.NotifySuccess, "Rating updated", msg.undo)
+
+	case progressUpdatedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, common.NotifyRetryCmd(common.NotifyError, msg.err.Error(), msg.retry)
+		}
+		m.bookID = m.userBook.ID
+		m.loading = true
+		return m, tea.Batch(m.spinner.Tick, m.loadBook(), common.NotifyCmd(common.NotifySuccess, "Progress updated"))
 
 	case bookAddedMsg:
 		m.loading = false
 		if msg.err != nil {
 			m.err = msg.err
-			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
+			return m, common.NotifyRetryCmd(common.NotifyError, msg.err.Error(), msg.retry)
 		}
 		m.userBook = msg.userBook
 		return m, common.NotifyCmd(common.NotifySuccess, "Book added to library")
@@ -161,32 +196,42 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.journals = msg.journals
-		items := make([]list.Item, len(m.journals))
-		for i, j := range m.journals {
-			items[i] = journalItem{data: j}
-		}
-		m.journalList.SetItems(items)
+		m.applyJournalFilter()
 		return m, nil
 
 	case journalSavedMsg:
 		m.journalLoading = false
 		if msg.err != nil {
 			m.journalErr = msg.err
-			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
+			common.SaveDraft(m.journalDraftKey(), msg.entry)
+			return m, common.NotifyCmd(common.NotifyError, msg.err.Error()+" (draft kept)")
 		}
+		common.ClearDraft(m.journalDraftKey())
 		m.journalSuccess = true
-		m.journalTA.SetValue("")
+		m.journalForm.Reset()
 		m.mode = modeJournal
-		m.journalTA.Blur()
 		return m, tea.Batch(m.loadJournals(), common.NotifyCmd(common.NotifySuccess, "Journal entry saved"))
 
+	case common.EditorFinishedMsg:
+		if msg.Err != nil {
+			return m, common.NotifyCmd(common.NotifyError, msg.Err.Error())
+		}
+		if msg.ID == "journal" && m.mode == modeJournalWrite {
+			m.journalForm.Entry.SetValue(msg.Content)
+		}
+		return m, nil
+
 	case journalDeletedMsg:
 		m.journalLoading = false
 		if msg.err != nil {
 			m.journalErr = msg.err
 			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
 		}
-		return m, tea.Batch(m.loadJournals(), common.NotifyCmd(common.NotifySuccess, "Journal entry deleted"))
+		notify := common.NotifyCmd(common.NotifySuccess, "Journal entry deleted")
+		if msg.undo.Run != nil {
+			notify = common.NotifyUndoableCmd(common.NotifySuccess, "Journal entry deleted", msg.undo)
+		}
+		return m, tea.Batch(m.loadJournals(), notify)
 
 	case userListsLoadedMsg:
 		m.listLoading = false
@@ -199,11 +244,24 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mode = modeListSelect
 		return m, nil
 
+	case common.CommandLineOpenedMsg:
+		// Fetch list names so ":list add" can complete them.
+		if m.userLists == nil {
+			return m, m.loadListNames()
+		}
+		return m, nil
+
+	case listNamesLoadedMsg:
+		if msg.err == nil && m.userLists == nil {
+			m.userLists = msg.lists
+		}
+		return m, nil
+
 	case bookAddedToListMsg:
 		m.listLoading = false
 		if msg.err != nil {
 			m.listErr = msg.err
-			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
+			return m, common.NotifyRetryCmd(common.NotifyError, msg.err.Error(), msg.retry)
 		}
 		m.listSuccess = true
 		m.mode = modeDetail
@@ -218,14 +276,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.listIndex < len(m.listBooks) {
 			m.listBooks = append(m.listBooks[:m.listIndex], m.listBooks[m.listIndex+1:]...)
 		}
+		notify := common.NotifyUndoableCmd(common.NotifySuccess, "Removed from "+m.listName, msg.undo)
 		if len(m.listBooks) == 0 {
-			return m, common.NotifyCmd(common.NotifySuccess, "Removed from "+m.listName)
+			return m, notify
 		}
 		if m.listIndex >= len(m.listBooks) {
 			m.listIndex = len(m.listBooks) - 1
 		}
 		m.switchToListBook(m.listIndex)
-		return m, tea.Batch(m.spinner.Tick, m.loadBookByBookID(), common.NotifyCmd(common.NotifySuccess, "Removed from "+m.listName))
+		return m, tea.Batch(m.spinner.Tick, m.loadBookByBookID(), notify)
 
 	case tea.KeyMsg:
 		if m.loading {
@@ -240,8 +299,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					switch m.confirm.Action {
 					case "delete-journal":
 						m.mode = modeJournal
-						m.journalLoading = true
-						return m, tea.Batch(m.spinner.Tick, m.deleteJournalEntry(m.confirmItemID))
+						if i := slices.IndexFunc(m.journals, func(j api.ReadingJournal) bool { return j.ID == m.confirmItemID }); i >= 0 {
+							m.journalLoading = true
+							return m, tea.Batch(m.spinner.Tick, m.deleteJournalEntry(m.journals[i]))
+						}
 					case "remove-from-list":
 						m.mode = modeDetail
 						m.loading = true
@@ -273,6 +334,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *Model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.reviewMode {
+		if cmd, ok := common.HandleLinkKey(msg, m); ok {
+			return m, cmd
+		}
 		k := strings.ToLower(msg.String())
 		switch k {
 		case "esc", "v":
@@ -284,24 +348,8 @@ func (m *Model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.mode = modeReviewRead
 				m.reviewViewport = viewport.New(m.getWidth()-10, m.height-12)
 				m.reviewViewport.Style = common.ValueStyle
-				var content strings.Builder
-				content.WriteString(common.LabelStyle.Render("@" + item.data.User.Username))
-				if item.data.Rating != nil {
-					content.WriteString("  " + common.RenderRatingBar(*item.data.Rating, 15))
-				}
-				if item.data.LikesCount > 0 {
-					content.WriteString(common.ValueStyle.Render(fmt.Sprintf("  %d likes", item.data.LikesCount)))
-				}
-				content.WriteString("\n\n")
-				if item.data.Review != nil && *item.data.Review != "" {
-					text := stripHTML(*item.data.Review)
-					if item.data.ReviewHasSpoilers {
-						text = "[SPOILER]\n\n" + text
-					}
-					wrapped := lipgloss.NewStyle().Width(m.getWidth() - 14).Render(text)
-					content.WriteString(wrapped)
-				}
-				m.reviewViewport.SetContent(content.String())
+				m.revealSpoilers = false
+				m.renderSelectedReview()
 				return m, nil
 			}
 		}
@@ -345,6 +393,11 @@ func (m *Model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return NavigateToProgressMsg{UserBook: m.userBook}
 			}
 		}
+	case "e":
+		if m.series != nil {
+			msg := NavigateToSeriesMsg{SeriesID: m.series.ID, Name: m.series.Name, BookID: m.BookID()}
+			return m, func() tea.Msg { return msg }
+		}
 	case "j":
 		if m.userBook != nil && !m.journalLoading {
 			m.mode = modeJournal
@@ -461,10 +514,17 @@ func (m *Model) updateJournal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.mode = modeDetail
 		return m, nil
 	case "n":
-		m.mode = modeJournalWrite
-		m.journalSuccess = false
-		m.journalTA.Focus()
-		return m, textarea.Blink
+		return m, m.startJournalEntry(common.JournalNote)
+	case "\"":
+		return m, m.startJournalEntry(common.JournalQuote)
+	case "g":
+		return m, m.startJournalEntry(common.JournalProgress)
+	case "s":
+		return m, m.startJournalEntry(common.JournalStatus)
+	case "t":
+		m.journalFilter = common.NextJournalFilter(m.journalFilter)
+		m.applyJournalFilter()
+		return m, nil
 	case "d":
 		if item, ok := m.journalList.SelectedItem().(journalItem); ok {
 			m.confirm = common.NewConfirm("Delete this journal entry?", "delete-journal")
@@ -482,37 +542,120 @@ func (m *Model) updateJournal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *Model) updateJournalWrite(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+s":
-		entry := strings.TrimSpace(m.journalTA.Value())
-		if entry == "" {
+		event, entry, meta, err := m.journalForm.Build()
+		if err != nil {
+			m.journalErr = err
 			return m, nil
 		}
 		m.journalLoading = true
 		m.journalErr = nil
 		m.journalSuccess = false
-		return m, tea.Batch(m.spinner.Tick, m.saveJournalEntry(entry))
+		return m, tea.Batch(m.spinner.Tick, m.saveJournalEntry(event, entry, meta))
+	case "ctrl+o":
+		return m, common.OpenEditor("journal", m.journalForm.Entry.Value(), ".md")
 	case "esc":
 		m.mode = modeJournal
-		m.journalTA.Blur()
+		m.journalForm.Blur()
 		return m, nil
 	}
-	var cmd tea.Cmd
-	m.journalTA, cmd = m.journalTA.Update(msg)
-	return m, cmd
+	return m, m.journalForm.Update(msg)
+}
+
+// startJournalEntry opens the write panel for a new entry of the given kind,
+// restoring any unsaved draft text.
+func (m *Model) startJournalEntry(kind common.JournalKind) tea.Cmd {
+	m.mode = modeJournalWrite
+	m.journalSuccess = false
+	m.journalErr = nil
+	if m.journalForm.Entry.Value() == "" {
+		if draft, ok := common.LoadDraft(m.journalDraftKey()); ok {
+			m.journalForm.Entry.SetValue(draft)
+		}
+	}
+	pages := 0
+	if m.userBook != nil && m.userBook.Book.Pages != nil {
+		pages = *m.userBook.Book.Pages
+	}
+	return m.journalForm.Start(kind, pages)
+}
+
+// applyJournalFilter rebuilds the journal list using the event filter.
+func (m *Model) applyJournalFilter() {
+	journals := common.FilterJournals(m.journals, m.journalFilter)
+	items := make([]list.Item, len(journals))
+	for i, j := range journals {
+		items[i] = journalItem{data: j}
+	}
+	m.journalList.SetItems(items)
+}
+
+// journalDraftKey identifies the unsaved journal entry for this book on disk.
+// It matches the journal screen's key so a draft follows the book.
+func (m *Model) journalDraftKey() string {
+	if m.userBook == nil {
+		return "journal"
+	}
+	return fmt.Sprintf("journal-%d", m.userBook.BookID)
 }
 
 func (m *Model) updateReviewRead(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if cmd, ok := common.HandleLinkKey(msg, m); ok {
+		return m, cmd
+	}
 	k := strings.ToLower(msg.String())
 	switch k {
 	case "esc", "q":
 		m.mode = modeDetail
 		m.selectedReview = nil
 		return m, nil
+	case "r":
+		m.revealSpoilers = !m.revealSpoilers
+		m.renderSelectedReview()
+		return m, nil
 	}
 	var cmd tea.Cmd
 	m.reviewViewport, cmd = m.reviewViewport.Update(msg)
 	return m, cmd
 }
 
+// renderSelectedReview fills the review viewport with the selected review
+// rendered as Markdown. Spoilers stay masked until revealed with r.
+func (m *Model) renderSelectedReview() {
+	r := m.selectedReview
+	if r == nil {
+		return
+	}
+	var content strings.Builder
+	content.WriteString(common.LabelStyle.Render("@" + r.User.Username))
+	if r.Rating != nil {
+		content.WriteString("  " + common.RenderRatingBar(*r.Rating, 15))
+	}
+	if r.LikesCount > 0 {
+		content.WriteString(common.ValueStyle.Render(fmt.Sprintf("  %d likes", r.LikesCount)))
+	}
+	content.WriteString("\n\n")
+
+	var md string
+	switch {
+	case r.ReviewHTML != nil && *r.ReviewHTML != "":
+		md = common.HTMLToMarkdown(*r.ReviewHTML)
+	case r.Review != nil:
+		md = *r.Review
+	}
+	if md != "" {
+		if r.ReviewHasSpoilers && !m.revealSpoilers {
+			content.WriteString(common.ErrorStyle.Render("Contains spoilers - press r to reveal") + "\n\n")
+		}
+		width := m.getWidth() - 14
+		rendered, err := common.RenderMarkdown(md, width, m.revealSpoilers)
+		if err != nil {
+			rendered = lipgloss.NewStyle().Width(width).Render(stripHTML(md))
+		}
+		content.WriteString(rendered)
+	}
+	m.reviewViewport.SetContent(content.String())
+}
+
 func (m *Model) updateListSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	k := strings.ToLower(msg.String())
 	switch k {
@@ -173,6 +173,10 @@ func (m *Model) View() string {
 		}
 	}
 
+	if m.series != nil && len(m.series.Entries) > 0 {
+		leftPanels = append(leftPanels, common.RenderPanel("Series", m.renderSeries(book.ID, leftW-4), leftW))
+	}
+
 	leftCol := lipgloss.JoinVertical(lipgloss.Left, leftPanels...)
 	leftCell.SetContent(leftCol)
 
@@ -182,11 +186,11 @@ func (m *Model) View() string {
 	if m.mode == modeJournal || m.mode == modeJournalWrite {
 		if m.mode == modeJournalWrite {
 			var write strings.Builder
-			write.WriteString(common.LabelStyle.Render("New Journal Entry"))
+			write.WriteString(common.LabelStyle.Render("New " + m.journalForm.Kind.String()))
 			write.WriteString("\n\n")
-			write.WriteString(m.journalTA.View())
+			write.WriteString(m.journalForm.View())
 			write.WriteString("\n\n")
-			write.WriteString(common.HelpStyle.Render("ctrl+s: save | esc: cancel"))
+			write.WriteString(common.HelpStyle.Render(m.journalForm.HelpText()))
 			rightPanels = append(rightPanels, common.RenderActivePanel("Write Entry", write.String(), rightW))
 		}
 		if m.journalErr != nil {
@@ -200,9 +204,13 @@ func (m *Model) View() string {
 				fmt.Sprintf("  %s Loading...\n", m.spinner.View()), rightW))
 		} else if m.mode == modeJournal {
 			m.journalList.SetSize(rightInner, m.height-12)
-			rightPanels = append(rightPanels, common.RenderActivePanel("Journal", m.journalList.View(), rightW))
+			journalTitle := "Journal"
+			if m.journalFilter != common.JournalAll {
+				journalTitle = "Journal · " + m.journalFilter.String()
+			}
+			rightPanels = append(rightPanels, common.RenderActivePanel(journalTitle, m.journalList.View(), rightW))
 			rightPanels = append(rightPanels, common.RenderPanel("Help",
-				common.HelpStyle.Render("n: new entry | d: delete | j/k: navigate | esc: back"), rightW))
+				common.HelpStyle.Render("n: note | \": quote | g: progress | s: status | t: filter | d: delete | esc: back"), rightW))
 		}
 	} else {
 		if book.Description != nil && *book.Description != "" {
@@ -339,6 +347,52 @@ func (m *Model) View() string {
 	return common.AppStyle.Render(body)
 }
 
+// renderSeries lists the numbered books of the book's series with the
+// user's status for each, a few either side of the book when it is long.
+func (m *Model) renderSeries(bookID, innerW int) string {
+	const maxEntries = 7
+
+	var b strings.Builder
+	header := m.series.Name
+	if m.seriesPosition != nil {
+		header += fmt.Sprintf(" #%s", api.FormatPosition(*m.seriesPosition))
+		if total := m.series.Total(); total > 0 {
+			header += fmt.Sprintf(" of %d", total)
+		}
+	}
+	b.WriteString(common.LabelStyle.Render(common.Truncate(header, innerW)))
+	b.WriteString("\n" + common.ValueStyle.Render(fmt.Sprintf("%d of %d read", m.series.Read(), len(m.series.Entries))))
+
+	entries := m.series.Entries
+	current := 0
+	for i, e := range entries {
+		if e.Book.ID == bookID {
+			current = i
+			break
+		}
+	}
+	start := 0
+	if len(entries) > maxEntries {
+		start = min(max(current-maxEntries/2, 0), len(entries)-maxEntries)
+		entries = entries[start : start+maxEntries]
+	}
+	for _, e := range entries {
+		sq := lipgloss.NewStyle().Foreground(common.StatusColor(e.StatusID)).Bold(true).Render("■")
+		line := common.Truncate(fmt.Sprintf("#%s %s", api.FormatPosition(e.Position), e.Book.Title), innerW-2)
+		if e.Book.ID == bookID {
+			line = common.TitleStyle.Render(line)
+		} else {
+			line = common.ValueStyle.Render(line)
+		}
+		b.WriteString("\n" + sq + " " + line)
+	}
+	if hidden := len(m.series.Entries) - len(entries); hidden > 0 {
+		b.WriteString("\n" + common.HelpStyle.Render(fmt.Sprintf("  +%d more", hidden)))
+	}
+	b.WriteString("\n" + common.HelpStyle.Render("[e] open series"))
+	return b.String()
+}
+
 // renderStatusOverlay renders the status selection as an overlay panel.
 func (m *Model) renderStatusOverlay(maxW int) string {
 	w := 40
@@ -407,7 +461,7 @@ func (m *Model) renderReviewOverlay(maxW int) string {
 	var content strings.Builder
 	content.WriteString(m.reviewViewport.View())
 	content.WriteString("\n\n")
-	content.WriteString(common.HelpStyle.Render("j/k: scroll | esc: close"))
+	content.WriteString(common.HelpStyle.Render("j/k: scroll | r: reveal spoilers | esc: close"))
 
 	return common.RenderActivePanel("Review", content.String(), w)
 }
@@ -417,12 +471,17 @@ func (m *Model) HelpBindings() []key.Binding {
 	switch m.mode {
 	case modeJournal:
 		return []key.Binding{
-			key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new entry")),
+			key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "note")),
+			key.NewBinding(key.WithKeys("\""), key.WithHelp("\"", "quote")),
+			key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "progress")),
+			key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "status")),
+			key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "filter")),
 			key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
 		}
 	case modeJournalWrite:
 		return []key.Binding{
 			key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+			key.NewBinding(key.WithKeys("ctrl+o"), key.WithHelp("ctrl+o", "$EDITOR")),
 		}
 	case modeStatusSelect, modeRatingSelect, modeListSelect:
 		return []key.Binding{
@@ -431,6 +490,7 @@ func (m *Model) HelpBindings() []key.Binding {
 	case modeReviewRead:
 		return []key.Binding{
 			key.NewBinding(key.WithKeys("j"), key.WithHelp("j/k", "scroll")),
+			key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reveal spoilers")),
 		}
 	default:
 		bindings := []key.Binding{}
@@ -450,6 +510,11 @@ func (m *Model) HelpBindings() []key.Binding {
 		bindings = append(bindings,
 			key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "add to list")),
 		)
+		if m.series != nil {
+			bindings = append(bindings,
+				key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "series")),
+			)
+		}
 		if len(m.listBooks) > 0 {
 			bindings = append(bindings,
 				key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "remove from list")),
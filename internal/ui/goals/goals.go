@@ -11,8 +11,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
-	"github.com/NotMugil/hardcover-tui/internal/api/queries"
 	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/service"
 )
 
 type goalsLoadedMsg struct {
@@ -47,7 +47,7 @@ func (i goalItem) FilterValue() string {
 
 // Model is the goals screen model.
 type Model struct {
-	client  *api.Client
+	svc     service.HardcoverService
 	user    *api.User
 	goals   []api.Goal
 	list    list.Model
@@ -59,7 +59,7 @@ type Model struct {
 }
 
 // New creates a new goals screen.
-func New(client *api.Client, user *api.User) *Model {
+func New(svc service.HardcoverService, user *api.User) *Model {
 	s := spinner.New(
 		spinner.WithSpinner(spinner.Dot),
 		spinner.WithStyle(common.SpinnerStyle),
@@ -82,7 +82,7 @@ func New(client *api.Client, user *api.User) *Model {
 	l.Styles.NoItems = common.ValueStyle
 
 	return &Model{
-		client:  client,
+		svc:     svc,
 		user:    user,
 		list:    l,
 		spinner: s,
@@ -106,12 +106,12 @@ func (m *Model) Init() tea.Cmd {
 }
 
 func (m *Model) loadGoals() tea.Cmd {
-	client := m.client
+	svc := m.svc
 	user := m.user
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		goals, err := queries.GetGoals(ctx, client, user.ID)
+		goals, err := svc.GetGoals(ctx, user.ID)
 		return goalsLoadedMsg{goals: goals, err: err}
 	}
 }
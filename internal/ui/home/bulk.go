@@ -0,0 +1,303 @@
+package home
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/common"
+)
+
+// Bulk actions offered for the marked books, in menu order.
+var bulkActions = []string{
+	"Change status",
+	"Add to a list",
+	"Remove from a list",
+	"Set privacy",
+	"Delete from library",
+}
+
+type bulkListsLoadedMsg struct {
+	action string // picker action to open with the lists
+	lists  []api.List
+	err    error
+}
+
+type bulkListBooksLoadedMsg struct {
+	list  api.List
+	books []api.ListBook
+	err   error
+}
+
+// updateSelection handles the keys that mark books. It reports whether
+// the key was one of them.
+func (m *Model) updateSelection(key string) (tea.Cmd, bool) {
+	item, ok := m.list.SelectedItem().(bookItem)
+	switch key {
+	case " ":
+		if ok {
+			m.selection.Toggle(item.userBook.ID)
+		}
+	case "V":
+		if ok {
+			m.selection.MarkRange(m.visibleBookIDs(), item.userBook.ID)
+		}
+	case "*":
+		m.selection.ToggleAll(m.visibleBookIDs())
+	case "esc":
+		if m.selection.Len() == 0 {
+			return nil, false
+		}
+		m.selection.Clear()
+	case "b":
+		if m.selection.Len() == 0 {
+			return nil, false
+		}
+		m.picker = common.NewPicker(fmt.Sprintf("%d books", m.selection.Len()), "bulk", bulkActions)
+		return nil, true
+	default:
+		return nil, false
+	}
+	return m.refreshMarks(), true
+}
+
+// visibleBookIDs returns the user book IDs the list shows, narrowed by
+// its filter.
+func (m *Model) visibleBookIDs() []int {
+	items := m.list.VisibleItems()
+	ids := make([]int, 0, len(items))
+	for _, it := range items {
+		if bi, ok := it.(bookItem); ok {
+			ids = append(ids, bi.userBook.ID)
+		}
+	}
+	return ids
+}
+
+// refreshMarks redraws the items whose mark changed.
+func (m *Model) refreshMarks() tea.Cmd {
+	var cmds []tea.Cmd
+	for i, it := range m.list.Items() {
+		bi, ok := it.(bookItem)
+		if !ok || bi.marked == m.selection.Marked(bi.userBook.ID) {
+			continue
+		}
+		bi.marked = !bi.marked
+		cmds = append(cmds, m.list.SetItem(i, bi))
+	}
+	return tea.Batch(cmds...)
+}
+
+// markedBooks returns the marked books in list order.
+func (m *Model) markedBooks() []api.UserBook {
+	var books []api.UserBook
+	for _, ub := range m.books {
+		if m.selection.Marked(ub.ID) {
+			books = append(books, ub)
+		}
+	}
+	return books
+}
+
+// updatePicker handles a key while the bulk action picker is open.
+func (m *Model) updatePicker(key string) tea.Cmd {
+	choice := m.picker.HandleKey(key)
+	if choice < 0 {
+		return nil
+	}
+	switch m.picker.Action {
+	case "bulk":
+		switch bulkActions[choice] {
+		case "Change status":
+			var names []string
+			for _, s := range api.AllStatuses() {
+				names = append(names, s.String())
+			}
+			m.picker = common.NewPicker("Change status", "bulk-status", names)
+		case "Add to a list":
+			return m.loadBulkLists("bulk-add-list")
+		case "Remove from a list":
+			return m.loadBulkLists("bulk-remove-list")
+		case "Set privacy":
+			var names []string
+			for _, p := range api.AllPrivacySettings() {
+				names = append(names, p.String())
+			}
+			m.picker = common.NewPicker("Set privacy", "bulk-privacy", names)
+		case "Delete from library":
+			m.confirm = common.NewConfirm(
+				fmt.Sprintf("Delete %d books from your library?", m.selection.Len()),
+				"bulk-delete",
+			)
+		}
+	case "bulk-status":
+		return m.runStatusBulk(int(api.AllStatuses()[choice]))
+	case "bulk-privacy":
+		return m.runPrivacyBulk(int(api.AllPrivacySettings()[choice]))
+	case "bulk-add-list":
+		return m.runAddToListBulk(m.bulkLists[choice])
+	case "bulk-remove-list":
+		return m.loadBulkListBooks(m.bulkLists[choice])
+	}
+	return nil
+}
+
+// updateBulk handles a key while a bulk run or its summary is shown.
+func (m *Model) updateBulk(key string) tea.Cmd {
+	if !m.bulk.Finished() {
+		if key == "esc" {
+			m.bulk.Cancel()
+		}
+		return nil
+	}
+	if key == "enter" || key == "esc" {
+		m.bulk = nil
+	}
+	return nil
+}
+
+// startBulk runs ops over the marked books with a progress overlay.
+func (m *Model) startBulk(title string, ops []common.BulkOp) tea.Cmd {
+	m.bulk = common.NewBulk(title, ops)
+	return m.bulk.Start()
+}
+
+// finishBulk reports a finished run and reloads the library. Books that
+// failed stay marked so the action can be tried again.
+func (m *Model) finishBulk() tea.Cmd {
+	notify := m.bulk.Notify()
+	m.selection.Clear()
+	for _, f := range m.bulk.Failures() {
+		m.selection.Toggle(f.ID)
+	}
+	if len(m.bulk.Failures()) == 0 {
+		m.bulk = nil
+	}
+	m.booksLoading = true
+	return tea.Batch(
+		m.refreshMarks(),
+		m.spinner.Tick,
+		m.loadInitial(),
+		notify,
+	)
+}
+
+func (m *Model) runStatusBulk(statusID int) tea.Cmd {
+	svc := m.svc
+	var ops []common.BulkOp
+	for _, ub := range m.markedBooks() {
+		id := ub.ID
+		prev := ub.Status()
+		ops = append(ops, common.BulkOp{
+			ID:    id,
+			Label: ub.Book.Title,
+			Run: func(ctx context.Context) error {
+				return svc.UpdateUserBookStatus(ctx, id, statusID)
+			},
+			Undo: common.UndoStatus(svc, id, prev).Run,
+		})
+	}
+	return m.startBulk("Change status", ops)
+}
+
+func (m *Model) runPrivacyBulk(privacySettingID int) tea.Cmd {
+	svc := m.svc
+	var ops []common.BulkOp
+	for _, ub := range m.markedBooks() {
+		id := ub.ID
+		ops = append(ops, common.BulkOp{
+			ID:    id,
+			Label: ub.Book.Title,
+			Run: func(ctx context.Context) error {
+				return svc.UpdateUserBookPrivacy(ctx, id, privacySettingID)
+			},
+		})
+	}
+	return m.startBulk("Set privacy", ops)
+}
+
+func (m *Model) runDeleteBulk() tea.Cmd {
+	svc := m.svc
+	var ops []common.BulkOp
+	for _, ub := range m.markedBooks() {
+		id := ub.ID
+		ops = append(ops, common.BulkOp{
+			ID:    id,
+			Label: ub.Book.Title,
+			Run: func(ctx context.Context) error {
+				return svc.DeleteUserBook(ctx, id)
+			},
+			Undo: common.UndoUserBookDelete(svc, ub).Run,
+		})
+	}
+	return m.startBulk("Delete from library", ops)
+}
+
+func (m *Model) runAddToListBulk(l api.List) tea.Cmd {
+	svc := m.svc
+	var ops []common.BulkOp
+	for _, ub := range m.markedBooks() {
+		bookID := ub.BookID
+		ops = append(ops, common.BulkOp{
+			ID:    ub.ID,
+			Label: ub.Book.Title,
+			Run: func(ctx context.Context) error {
+				return svc.InsertListBook(ctx, l.ID, bookID)
+			},
+		})
+	}
+	return m.startBulk("Add to "+l.Name, ops)
+}
+
+// runRemoveFromListBulk removes the marked books found on the list.
+func (m *Model) runRemoveFromListBulk(l api.List, listBooks []api.ListBook) tea.Cmd {
+	svc := m.svc
+	onList := make(map[int]api.ListBook, len(listBooks))
+	for _, lb := range listBooks {
+		onList[lb.BookID] = lb
+	}
+	var ops []common.BulkOp
+	for _, ub := range m.markedBooks() {
+		lb, ok := onList[ub.BookID]
+		if !ok {
+			continue
+		}
+		ops = append(ops, common.BulkOp{
+			ID:    ub.ID,
+			Label: ub.Book.Title,
+			Run: func(ctx context.Context) error {
+				return svc.DeleteListBook(ctx, lb.ID)
+			},
+			Undo: common.UndoListBookRemoval(svc, l.ID, l.Name, ub.BookID).Run,
+		})
+	}
+	if len(ops) == 0 {
+		return common.NotifyCmd(common.NotifyWarning, "None of the marked books are on "+l.Name)
+	}
+	return m.startBulk("Remove from "+l.Name, ops)
+}
+
+// loadBulkLists fetches the user's lists for the list picker.
+func (m *Model) loadBulkLists(action string) tea.Cmd {
+	svc := m.svc
+	userID := m.user.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		lists, err := svc.GetLists(ctx, userID)
+		return bulkListsLoadedMsg{action: action, lists: lists, err: err}
+	}
+}
+
+func (m *Model) loadBulkListBooks(l api.List) tea.Cmd {
+	svc := m.svc
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		books, err := svc.GetListBooks(ctx, l.ID)
+		return bulkListBooksLoadedMsg{list: l, books: books, err: err}
+	}
+}
@@ -0,0 +1,53 @@
+package home
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/common"
+)
+
+// Commands returns the ":" commands for the library screen.
+func (m *Model) Commands() []common.Command {
+	return []common.Command{
+		{
+			Name:     "filter",
+			Usage:    "<status>|all",
+			Help:     "show books with one status",
+			Complete: completeFilter,
+			Run:      m.runFilter,
+		},
+	}
+}
+
+func completeFilter(args []string) []string {
+	if len(args) != 1 {
+		return nil
+	}
+	names := []string{"all"}
+	for _, s := range api.AllStatuses() {
+		names = append(names, s.Slug())
+	}
+	return common.CompletePrefix(args[0], names)
+}
+
+func (m *Model) runFilter(args []string) (tea.Cmd, error) {
+	if len(args) != 1 {
+		return nil, common.ErrUsage
+	}
+	filter := 0
+	if args[0] != "all" {
+		status, ok := api.ParseStatus(args[0])
+		if !ok {
+			return nil, fmt.Errorf("unknown status %q", args[0])
+		}
+		filter = int(status)
+	}
+	m.filter = filter
+	m.page = 0
+	m.filterPending = false
+	m.booksLoading = true
+	return tea.Batch(m.spinner.Tick, m.loadBooksOnly()), nil
+}
@@ -7,7 +7,6 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
-	"github.com/NotMugil/hardcover-tui/internal/api/queries"
 	"github.com/NotMugil/hardcover-tui/internal/common"
 )
 
@@ -18,8 +17,7 @@ func (m *Model) tickClock() tea.Cmd {
 }
 
 func (m *Model) loadInitial() tea.Cmd {
-	client := m.client
-	user := m.user
+	svc := m.svc
 	filter := m.filter
 	page := m.page
 	pageSize := m.pageSize
@@ -32,27 +30,27 @@ func (m *Model) loadInitial() tea.Cmd {
 			s := filter
 			statusID = &s
 		}
-		books, err := queries.GetUserBooks(ctx, client, user.ID, statusID, pageSize, page*pageSize)
-		if err != nil {
-			return booksLoadedMsg{err: err}
-		}
-
-		reading, _ := queries.GetCurrentlyReading(ctx, client, user.ID)
+		d, err := svc.GetDashboard(ctx, statusID, pageSize, page*pageSize, activityLimit)
+		return dashboardLoadedMsg{dashboard: d, err: err}
+	}
+}
 
-		var avatarArt string
-		if user.ImageURL() != "" {
-			art, artErr := common.RenderImage(user.ImageURL(), 28, 14)
-			if artErr == nil {
-				avatarArt = art
-			}
+func (m *Model) loadAvatar() tea.Cmd {
+	url := m.user.ImageURL()
+	if url == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		art, err := common.RenderImage(url, 28, 14)
+		if err != nil {
+			return nil
 		}
-
-		return booksLoadedMsg{books: books, reading: reading, avatarArt: avatarArt}
+		return avatarLoadedMsg{art: art}
 	}
 }
 
 func (m *Model) loadBooksOnly() tea.Cmd {
-	client := m.client
+	svc := m.svc
 	user := m.user
 	filter := m.filter
 	page := m.page
@@ -66,29 +64,44 @@ func (m *Model) loadBooksOnly() tea.Cmd {
 			s := filter
 			statusID = &s
 		}
-		books, err := queries.GetUserBooks(ctx, client, user.ID, statusID, pageSize, page*pageSize)
+		books, err := svc.GetUserBooks(ctx, user.ID, statusID, pageSize, page*pageSize)
 		return booksOnlyLoadedMsg{books: books, err: err}
 	}
 }
 
 func (m *Model) loadActivities() tea.Cmd {
-	client := m.client
+	svc := m.svc
 	user := m.user
 	af := m.activityFilter
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
+		ctx = api.WithPriority(ctx, api.PriorityBackground)
 		var activities []api.Activity
 		var err error
 		if af == activityFilterForYou {
-			activities, err = queries.GetForYouActivities(ctx, client, user.ID, 30)
+			activities, err = svc.GetForYouActivities(ctx, user.ID, activityLimit)
 		} else {
-			activities, err = queries.GetActivities(ctx, client, user.ID, 30)
+			activities, err = svc.GetActivities(ctx, user.ID, activityLimit)
 		}
 		return activitiesLoadedMsg{activities: activities, err: err}
 	}
 }
 
+// loadSeries fetches the series the user has started and not finished,
+// which the dashboard leaves out.
+func (m *Model) loadSeries() tea.Cmd {
+	svc := m.svc
+	user := m.user
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		ctx = api.WithPriority(ctx, api.PriorityBackground)
+		series, err := svc.GetSeriesInProgress(ctx, user.ID, seriesLimit)
+		return seriesLoadedMsg{series: series, err: err}
+	}
+}
+
 // scheduleFilterLoad waits a short delay before triggering the actual load.
 // If the user keeps pressing f/F, only the last filter position loads.
 func (m *Model) scheduleFilterLoad() tea.Cmd {
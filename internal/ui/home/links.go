@@ -0,0 +1,55 @@
+package home
+
+import (
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/common"
+)
+
+// Links returns the pages of the selected activity, currently reading
+// book, series or library book, whichever panel has focus.
+func (m *Model) Links() []common.Link {
+	switch {
+	case m.activityFocused:
+		if m.activityCursor >= len(m.activities) {
+			return nil
+		}
+		act := m.activities[m.activityCursor]
+		username := activityUsername(m.user, act)
+		links := []common.Link{common.ActivityLink(username, act.ID)}
+		if act.Book != nil {
+			links = append(links, common.BookLinks(*act.Book)...)
+		}
+		return append(links, common.UserLink(username))
+	case m.readingFocused:
+		if m.readingCursor >= len(m.reading) {
+			return nil
+		}
+		return common.BookLinks(m.reading[m.readingCursor].Book)
+	case m.seriesFocused:
+		if m.seriesCursor >= len(m.series) {
+			return nil
+		}
+		s := m.series[m.seriesCursor]
+		var links []common.Link
+		if s.Slug != "" {
+			links = append(links, common.SeriesLink(s.Name, s.Slug))
+		}
+		if next, ok := s.Next(); ok {
+			links = append(links, common.BookLinks(next.Book)...)
+		}
+		return links
+	}
+	if item, ok := m.list.SelectedItem().(bookItem); ok {
+		return common.BookLinks(item.userBook.Book)
+	}
+	return nil
+}
+
+// activityUsername returns who an activity belongs to; the user's own
+// activities come without a user.
+func activityUsername(user *api.User, act api.Activity) string {
+	if act.User != nil {
+		return act.User.Username
+	}
+	return user.Username
+}
@@ -1,6 +1,7 @@
 package home
 
 import (
+	"context"
 	"time"
 
 	"github.com/76creates/stickers/flexbox"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
 	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/service"
 )
 
 // NavigateToBookMsg signals the app to navigate to a book's detail view.
@@ -19,13 +21,32 @@ type NavigateToBookMsg struct {
 	UserBook *api.UserBook
 }
 
-type booksLoadedMsg struct {
-	books     []api.UserBook
-	reading   []api.UserBook
-	avatarArt string
+// NavigateToSeriesMsg signals the app to open a series, selecting BookID.
+type NavigateToSeriesMsg struct {
+	SeriesID int
+	Name     string
+	BookID   int
+}
+
+// Page sizes for the startup dashboard query.
+const (
+	pageSize      = 50
+	activityLimit = 30
+)
+
+// seriesLimit caps how many started series the Series in progress panel
+// looks through.
+const seriesLimit = 100
+
+type dashboardLoadedMsg struct {
+	dashboard *api.Dashboard
 	err       error
 }
 
+type avatarLoadedMsg struct {
+	art string
+}
+
 type clockTickMsg time.Time
 
 // filterSettledMsg fires after a short delay to trigger the actual data load.
@@ -38,18 +59,24 @@ type activitiesLoadedMsg struct {
 	err        error
 }
 
+type seriesLoadedMsg struct {
+	series []api.SeriesDetail
+	err    error
+}
+
 // activityFilter selects whose activities to display.
 type activityFilter int
 
 const (
-	activityFilterMe        activityFilter = iota // user's own activity
-	activityFilterForYou                          // "for you" activity feed
+	activityFilterMe     activityFilter = iota // user's own activity
+	activityFilterForYou                       // "for you" activity feed
 )
 
 // bookItem implements list.DefaultItem for the bubbles list.
 type bookItem struct {
 	userBook api.UserBook
 	filterID int // 0 = all, 1-6 = specific status filter
+	marked   bool
 }
 
 func (i bookItem) Title() string {
@@ -59,6 +86,9 @@ func (i bookItem) Title() string {
 		sq := lipgloss.NewStyle().Foreground(statusColor).Bold(true).Render("■")
 		title = sq + " " + title
 	}
+	if i.marked {
+		title = common.MarkPrefix + title
+	}
 	return title
 }
 
@@ -81,29 +111,36 @@ type booksOnlyLoadedMsg struct {
 
 // Model is the library screen model.
 type Model struct {
-	client         *api.Client
-	user           *api.User
-	books          []api.UserBook
-	reading        []api.UserBook
-	avatarArt      string
-	list           list.Model
-	readingFocused bool
-	readingCursor  int // cursor for currently reading items
-	readingScroll  int // scroll offset for currently reading pagination
-	progress       progress.Model
-	filter         int  // 0 = all, 1-6 = status filter
-	filterPending  bool // true while waiting for filter debounce
-	spinner        spinner.Model
-	loading        bool
-	booksLoading   bool // only books are loading (filter change)
-	err            error
-	page           int
-	pageSize       int
-	width          int
-	height         int
-	initialized    bool // profile/reading loaded once
-	currentTime    time.Time
-	flexBox        *flexbox.FlexBox
+	svc             service.HardcoverService
+	user            *api.User
+	books           []api.UserBook
+	reading         []api.UserBook
+	avatarArt       string
+	list            list.Model
+	readingFocused  bool
+	readingCursor   int                // cursor for currently reading items
+	readingScroll   int                // scroll offset for currently reading pagination
+	series          []api.SeriesDetail // series in progress
+	seriesFocused   bool
+	seriesCursor    int
+	seriesScroll    int
+	progress        progress.Model
+	filter          int  // 0 = all, 1-6 = status filter
+	filterPending   bool // true while waiting for filter debounce
+	spinner         spinner.Model
+	loading         bool
+	booksLoading    bool // only books are loading (filter change)
+	err             error
+	page            int
+	pageSize        int
+	width           int
+	height          int
+	initialized     bool // profile/reading loaded once
+	statusCounts    map[api.StatusID]int
+	seeded          bool // built from a dashboard; the first Init skips loading
+	restoreCursor   int  // book to select once books load, from Restore
+	currentTime     time.Time
+	flexBox         *flexbox.FlexBox
 	activities      []api.Activity
 	activityFilter  activityFilter
 	activityLoading bool
@@ -111,12 +148,16 @@ type Model struct {
 	activityCursor  int
 	activityScroll  int
 	activityErr     error
-	confirm    common.ConfirmState
-	confirmURL string
+	confirm         common.ConfirmState
+	confirmURL      string
+	selection       common.Selection // books marked for a bulk action
+	picker          common.PickerState
+	bulk            *common.Bulk // running or finished bulk action
+	bulkLists       []api.List   // lists offered by the list picker
 }
 
 // New creates a new library screen.
-func New(client *api.Client, user *api.User) *Model {
+func New(svc service.HardcoverService, user *api.User) *Model {
 	s := spinner.New(
 		spinner.WithSpinner(spinner.Dot),
 		spinner.WithStyle(common.SpinnerStyle),
@@ -152,13 +193,13 @@ func New(client *api.Client, user *api.User) *Model {
 	fb.AddRows([]*flexbox.Row{row})
 
 	return &Model{
-		client:   client,
+		svc:      svc,
 		user:     user,
 		list:     l,
 		progress: p,
 		spinner:  s,
 		loading:  true,
-		pageSize: 50,
+		pageSize: pageSize,
 		flexBox:  fb,
 	}
 }
@@ -176,12 +217,92 @@ func (m *Model) Loaded() bool {
 
 // InputFocused returns true when the list is in filter mode or activity is focused.
 func (m *Model) InputFocused() bool {
-	return m.list.FilterState() == list.Filtering || m.activityFocused || m.confirm.Active
+	return m.list.FilterState() == list.Filtering || m.activityFocused || m.confirm.Active ||
+		m.picker.Active || m.bulk != nil
+}
+
+// NewFromDashboard creates the library screen already filled with d, so
+// it can show without another round trip.
+func NewFromDashboard(svc service.HardcoverService, d *api.Dashboard) *Model {
+	m := New(svc, d.User)
+	m.applyDashboard(d)
+	m.seeded = true
+	return m
+}
+
+// FetchDashboard loads everything the library screen shows on startup in
+// a single request, with books narrowed to filter (0 for all).
+func FetchDashboard(ctx context.Context, svc service.HardcoverService, filter int) (*api.Dashboard, error) {
+	var statusID *int
+	if filter > 0 {
+		statusID = &filter
+	}
+	return svc.GetDashboard(ctx, statusID, pageSize, 0, activityLimit)
+}
+
+// State is the part of the library screen kept between sessions.
+type State struct {
+	Filter int `json:"filter,omitempty"`
+	Cursor int `json:"cursor,omitempty"`
+}
+
+// State returns the status filter and selected book.
+func (m *Model) State() State {
+	return State{Filter: m.filter, Cursor: m.list.Index()}
+}
+
+// Restore applies a saved filter and selection. Books that are already
+// loaded must have been fetched with the same filter.
+func (m *Model) Restore(s State) {
+	if s.Filter >= 0 && s.Filter < len(filterNames) {
+		m.filter = s.Filter
+	}
+	m.restoreCursor = s.Cursor
+	if !m.loading {
+		m.setBooks(m.books)
+	}
 }
 
 func (m *Model) Init() tea.Cmd {
 	m.currentTime = time.Now()
-	return tea.Batch(m.spinner.Tick, m.loadInitial(), m.tickClock(), m.loadActivities())
+	if m.seeded {
+		m.seeded = false
+		return tea.Batch(m.tickClock(), m.loadAvatar(), m.loadSeries())
+	}
+	return tea.Batch(m.spinner.Tick, m.loadInitial(), m.tickClock(), m.loadAvatar(), m.loadSeries())
+}
+
+// applyDashboard shows the books, currently reading, status counts and
+// activity from d.
+func (m *Model) applyDashboard(d *api.Dashboard) {
+	m.loading = false
+	m.initialized = true
+	m.reading = d.Reading
+	m.statusCounts = d.StatusCounts
+	m.activities = d.Activities
+	m.activityCursor = 0
+	m.activityScroll = 0
+	m.setBooks(d.Books)
+}
+
+// setBooks fills the library list, selecting the restored book if one is
+// waiting. Marks on books no longer listed are dropped.
+func (m *Model) setBooks(books []api.UserBook) {
+	m.books = books
+	ids := make([]int, len(books))
+	for i, ub := range books {
+		ids[i] = ub.ID
+	}
+	m.selection.Keep(ids)
+	items := make([]list.Item, len(books))
+	for i, ub := range books {
+		items[i] = bookItem{userBook: ub, filterID: m.filter, marked: m.selection.Marked(ub.ID)}
+	}
+	m.list.SetItems(items)
+	if m.restoreCursor > 0 && len(items) > 0 {
+		m.list.Select(min(m.restoreCursor, len(items)-1))
+	}
+	m.restoreCursor = 0
 }
 
 var filterNames = []string{
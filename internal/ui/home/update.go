@@ -2,8 +2,6 @@ package home
 
 import (
 	"fmt"
-	"os/exec"
-	"runtime"
 	"strings"
 	"time"
 
@@ -14,46 +12,26 @@ import (
 	"github.com/NotMugil/hardcover-tui/internal/common"
 )
 
-// openBrowser opens the given URL in the system's default browser.
-func openBrowser(url string) {
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "windows":
-		cmd = exec.Command("cmd", "/c", "start", url)
-	case "darwin":
-		cmd = exec.Command("open", url)
-	default:
-		cmd = exec.Command("xdg-open", url)
-	}
-	_ = cmd.Start()
-}
-
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case clockTickMsg:
 		m.currentTime = time.Time(msg)
 		return m, m.tickClock()
 
-	case booksLoadedMsg:
+	case dashboardLoadedMsg:
 		m.loading = false
 		m.filterPending = false
 		m.initialized = true
+		m.booksLoading = false
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
-		m.books = msg.books
-		if msg.reading != nil {
-			m.reading = msg.reading
-		}
-		if msg.avatarArt != "" {
-			m.avatarArt = msg.avatarArt
-		}
-		items := make([]list.Item, len(m.books))
-		for i, ub := range m.books {
-			items[i] = bookItem{userBook: ub, filterID: m.filter}
-		}
-		m.list.SetItems(items)
+		m.applyDashboard(msg.dashboard)
+		return m, nil
+
+	case avatarLoadedMsg:
+		m.avatarArt = msg.art
 		return m, nil
 
 	case booksOnlyLoadedMsg:
@@ -63,12 +41,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.books = msg.books
-		items := make([]list.Item, len(m.books))
-		for i, ub := range m.books {
-			items[i] = bookItem{userBook: ub, filterID: m.filter}
-		}
-		m.list.SetItems(items)
+		m.setBooks(msg.books)
 		return m, nil
 
 	case filterSettledMsg:
@@ -91,6 +64,49 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.activityScroll = 0
 		return m, nil
 
+	case seriesLoadedMsg:
+		// The panel is left out when the series cannot be loaded.
+		if msg.err == nil {
+			m.series = msg.series
+		}
+		m.seriesCursor = min(m.seriesCursor, max(len(m.series)-1, 0))
+		if len(m.series) == 0 {
+			m.seriesFocused = false
+		}
+		return m, nil
+
+	case bulkListsLoadedMsg:
+		if msg.err != nil {
+			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
+		}
+		m.bulkLists = msg.lists
+		names := make([]string, len(msg.lists))
+		for i, l := range msg.lists {
+			names[i] = l.Name
+		}
+		title := "Add to a list"
+		if msg.action == "bulk-remove-list" {
+			title = "Remove from a list"
+		}
+		m.picker = common.NewPicker(title, msg.action, names)
+		return m, nil
+
+	case bulkListBooksLoadedMsg:
+		if msg.err != nil {
+			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
+		}
+		return m, m.runRemoveFromListBulk(msg.list, msg.books)
+
+	case common.BulkStepMsg:
+		if m.bulk == nil {
+			return m, nil
+		}
+		cmd, done := m.bulk.Update(msg)
+		if done {
+			return m, m.finishBulk()
+		}
+		return m, cmd
+
 	case tea.MouseMsg:
 		return m, nil
 
@@ -106,16 +122,34 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.bulk != nil {
+			return m, m.updateBulk(msg.String())
+		}
+		if m.picker.Active {
+			return m, m.updatePicker(msg.String())
+		}
+		if m.confirm.Active && m.confirm.Action == "bulk-delete" {
+			if confirmed, _ := m.confirm.HandleKey(msg.String()); confirmed {
+				return m, m.runDeleteBulk()
+			}
+			return m, nil
+		}
+
 		if m.activityFocused {
 			if m.confirm.Active {
 				confirmed, _ := m.confirm.HandleKey(msg.String())
+				var cmd tea.Cmd
 				if !m.confirm.Active {
 					if confirmed && m.confirmURL != "" {
-						openBrowser(m.confirmURL)
+						cmd = common.OpenBrowserCmd(m.confirmURL)
 					}
 					m.confirmURL = ""
 				}
-				return m, nil
+				return m, cmd
+			}
+
+			if cmd, ok := common.HandleLinkKey(msg, m); ok {
+				return m, cmd
 			}
 
 			k := strings.ToLower(msg.String())
@@ -133,11 +167,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "enter":
 				if m.activityCursor < len(m.activities) {
 					act := m.activities[m.activityCursor]
-					username := m.user.Username
-					if act.User != nil {
-						username = act.User.Username
-					}
-					url := fmt.Sprintf("https://hardcover.app/@%s/activity/%d", username, act.ID)
+					url := common.ActivityLink(activityUsername(m.user, act), act.ID).URL
 					m.confirmURL = url
 					m.confirm = common.NewConfirm(
 						fmt.Sprintf("Open in browser?\n%s", url),
@@ -188,12 +218,45 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.seriesFocused {
+			k := strings.ToLower(msg.String())
+			switch k {
+			case "j", "down":
+				if m.seriesCursor < len(m.series)-1 {
+					m.seriesCursor++
+				}
+				return m, nil
+			case "k", "up":
+				if m.seriesCursor > 0 {
+					m.seriesCursor--
+				}
+				return m, nil
+			case "enter":
+				if m.seriesCursor < len(m.series) {
+					s := m.series[m.seriesCursor]
+					nav := NavigateToSeriesMsg{SeriesID: s.ID, Name: s.Name}
+					if next, ok := s.Next(); ok {
+						nav.BookID = next.Book.ID
+					}
+					return m, func() tea.Msg { return nav }
+				}
+			case "esc", "s":
+				m.seriesFocused = false
+				return m, nil
+			}
+			return m, nil
+		}
+
 		if m.list.FilterState() == list.Filtering {
 			var cmd tea.Cmd
 			m.list, cmd = m.list.Update(msg)
 			return m, cmd
 		}
 
+		if cmd, ok := m.updateSelection(msg.String()); ok {
+			return m, cmd
+		}
+
 		k := strings.ToLower(msg.String())
 		switch k {
 		case "r":
@@ -201,6 +264,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.readingFocused = true
 				return m, nil
 			}
+		case "s":
+			if len(m.series) > 0 {
+				m.seriesFocused = true
+				return m, nil
+			}
 		case "enter":
 			if item, ok := m.list.SelectedItem().(bookItem); ok {
 				ub := item.userBook
@@ -89,10 +89,68 @@ func (m *Model) renderReadingItems(innerW, maxH int) string {
 	return out.String()
 }
 
+// seriesVisible is how many series in progress the Home panel shows at once.
+const seriesVisible = 3
+
+// renderSeriesItems renders the series in progress, each with how far the
+// user has got and the book to read next.
+func (m *Model) renderSeriesItems(innerW int) string {
+	if m.seriesCursor < m.seriesScroll {
+		m.seriesScroll = m.seriesCursor
+	}
+	if m.seriesCursor >= m.seriesScroll+seriesVisible {
+		m.seriesScroll = m.seriesCursor - seriesVisible + 1
+	}
+	end := min(m.seriesScroll+seriesVisible, len(m.series))
+
+	var out strings.Builder
+	for i := m.seriesScroll; i < end; i++ {
+		s := m.series[i]
+		if i > m.seriesScroll {
+			out.WriteString("\n")
+		}
+		count := fmt.Sprintf(" %d/%d", s.Read(), len(s.Entries))
+		name := common.Truncate(s.Name, innerW-2-lipgloss.Width(count))
+		if m.seriesFocused && i == m.seriesCursor {
+			out.WriteString(common.LabelStyle.Render("> " + name))
+		} else {
+			out.WriteString(common.LabelStyle.Render("  " + name))
+		}
+		out.WriteString(common.HelpStyle.Render(count))
+		if next, ok := s.Next(); ok {
+			sq := lipgloss.NewStyle().Foreground(common.StatusColor(next.StatusID)).Bold(true).Render("■")
+			title := common.Truncate(fmt.Sprintf("#%s %s", api.FormatPosition(next.Position), next.Book.Title), innerW-4)
+			out.WriteString("\n  " + sq + " " + common.ValueStyle.Render(title))
+		}
+	}
+	if len(m.series) > seriesVisible {
+		out.WriteString("\n" + common.HelpStyle.Render(
+			fmt.Sprintf("  %d-%d of %d", m.seriesScroll+1, end, len(m.series))))
+	}
+	return out.String()
+}
+
+// filterCount returns how many books filter i matches: one status, or
+// all of them for filter 0.
+func (m *Model) filterCount(i int) int {
+	if i > 0 {
+		return m.statusCounts[api.StatusID(i)]
+	}
+	total := 0
+	for _, n := range m.statusCounts {
+		total += n
+	}
+	return total
+}
+
 func (m *Model) renderFilterBar() string {
 	var parts []string
 	for i, name := range filterNames {
 		if i == m.filter {
+			// Only the active filter shows its count so the bar still fits.
+			if m.statusCounts != nil {
+				name = fmt.Sprintf("%s %d", name, m.filterCount(i))
+			}
 			style := lipgloss.NewStyle().
 				Bold(true).
 				Foreground(filterColors[i]).
@@ -181,7 +239,7 @@ func (m *Model) View() string {
 		userPanelContent = statsContent.String()
 	}
 
-	anyFocused := m.readingFocused || m.activityFocused
+	anyFocused := m.readingFocused || m.activityFocused || m.seriesFocused
 	renderPanel := common.RenderPanel
 	if anyFocused {
 		renderPanel = common.RenderDimPanel
@@ -192,10 +250,24 @@ func (m *Model) View() string {
 	var leftPanels []string
 	leftPanels = append(leftPanels, userPanel)
 
+	var seriesPanel string
+	if len(m.series) > 0 {
+		seriesTitle := "Series in progress"
+		seriesContent := m.renderSeriesItems(panelInnerW(leftW))
+		if m.seriesFocused {
+			seriesPanel = common.RenderActivePanel(seriesTitle, seriesContent, leftW)
+		} else {
+			seriesPanel = renderPanel(seriesTitle, seriesContent, leftW)
+		}
+	}
+
 	if len(m.reading) > 0 {
 		profileH := lipgloss.Height(userPanel)
 		clockH := 4 // 2 content + 2 border
 		readingH := cellH - profileH - clockH
+		if seriesPanel != "" {
+			readingH -= lipgloss.Height(seriesPanel)
+		}
 		if readingH < 8 {
 			readingH = 8
 		}
@@ -214,6 +286,9 @@ func (m *Model) View() string {
 		}
 		leftPanels = append(leftPanels, readingPanel)
 	}
+	if seriesPanel != "" {
+		leftPanels = append(leftPanels, seriesPanel)
+	}
 
 	zone, _ := m.currentTime.Zone()
 	clockStr := m.currentTime.Format("03:04:05 PM")
@@ -263,7 +338,11 @@ func (m *Model) View() string {
 		lib.WriteString(legendStr)
 	}
 
-	centerPanel := renderPanel("Library", lib.String(), centerW, libPanelH)
+	libTitle := "Library"
+	if n := m.selection.Len(); n > 0 {
+		libTitle = fmt.Sprintf("Library (%d marked)", n)
+	}
+	centerPanel := renderPanel(libTitle, lib.String(), centerW, libPanelH)
 	centerCell.SetContent(centerPanel)
 
 	activityPanel := m.renderActivityPanel(rightW, cellH)
@@ -275,6 +354,14 @@ func (m *Model) View() string {
 		fg := common.RenderConfirmOverlay(m.confirm.Message, m.confirm.Cursor, 50)
 		body = overlay.Composite(fg, body, overlay.Center, overlay.Center, 0, 0)
 	}
+	if m.picker.Active {
+		fg := common.RenderPickerOverlay(m.picker, 40)
+		body = overlay.Composite(fg, body, overlay.Center, overlay.Center, 0, 0)
+	}
+	if m.bulk != nil {
+		fg := common.RenderBulkOverlay(m.bulk, m.width-20)
+		body = overlay.Composite(fg, body, overlay.Center, overlay.Center, 0, 0)
+	}
 
 	return common.AppStyle.Render(body)
 }
@@ -547,8 +634,17 @@ func relativeTime(ts string) string {
 
 // HelpBindings returns page-specific keybindings for the global help bar.
 func (m *Model) HelpBindings() []key.Binding {
+	if m.selection.Len() > 0 {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "bulk actions")),
+			key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "mark")),
+			key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "mark range")),
+			key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "clear marks")),
+		}
+	}
 	return []key.Binding{
 		key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reading")),
+		key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "series")),
 		key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter")),
 		key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "activity")),
 		key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "details")),
@@ -562,5 +658,8 @@ func (m *Model) FullHelpBindings() []key.Binding {
 		key.NewBinding(key.WithKeys("shift+f"), key.WithHelp("shift+f", "filter prev")),
 		key.NewBinding(key.WithKeys("]"), key.WithHelp("]", "next page")),
 		key.NewBinding(key.WithKeys("["), key.WithHelp("[", "prev page")),
+		key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "mark")),
+		key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "mark range")),
+		key.NewBinding(key.WithKeys("*"), key.WithHelp("*", "mark all shown")),
 	}
 }
@@ -8,13 +8,11 @@ import (
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
-	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
-	"github.com/NotMugil/hardcover-tui/internal/api/mutations"
-	"github.com/NotMugil/hardcover-tui/internal/api/queries"
 	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/service"
 )
 
 type journalsLoadedMsg struct {
@@ -23,7 +21,13 @@ type journalsLoadedMsg struct {
 }
 
 type journalSavedMsg struct {
-	err error
+	entry string
+	err   error
+}
+
+type journalDeletedMsg struct {
+	undo common.Undo // zero if the entry cannot be restored
+	err  error
 }
 
 type viewMode int
@@ -39,44 +43,33 @@ type journalItem struct {
 }
 
 func (i journalItem) Title() string {
-	date := i.data.ActionAt
-	if len(date) > 10 {
-		date = date[:10]
-	}
-	bookTitle := ""
-	if i.data.Book != nil {
-		bookTitle = " - " + i.data.Book.Title
-	}
-	return fmt.Sprintf("%s [%s]%s", date, i.data.Event, bookTitle)
+	return common.JournalTitle(i.data, true)
 }
 
 func (i journalItem) Description() string {
-	if i.data.Entry != nil && *i.data.Entry != "" {
-		entry := *i.data.Entry
-		if len(entry) > 80 {
-			entry = entry[:77] + "..."
-		}
-		return entry
-	}
-	return ""
+	return common.JournalDescription(i.data)
 }
 
 func (i journalItem) FilterValue() string {
-	title := i.data.Event
+	title := common.JournalKindOf(i.data).String()
 	if i.data.Book != nil {
 		title += " " + i.data.Book.Title
 	}
+	if i.data.Entry != nil {
+		title += " " + *i.data.Entry
+	}
 	return title
 }
 
 // Model is the journal screen model.
 type Model struct {
-	client   *api.Client
+	svc      service.HardcoverService
 	user     *api.User
 	userBook *api.UserBook
 	journals []api.ReadingJournal
 	list     list.Model
-	textarea textarea.Model
+	form     common.JournalForm
+	filter   common.JournalKind
 	spinner  spinner.Model
 	loading  bool
 	err      error
@@ -87,13 +80,7 @@ type Model struct {
 }
 
 // New creates a new journal screen.
-func New(client *api.Client, user *api.User, ub *api.UserBook) *Model {
-	ta := textarea.New()
-	ta.Placeholder = "Write a journal entry..."
-	ta.SetWidth(60)
-	ta.SetHeight(6)
-	ta.Cursor.Style = common.CursorStyle
-
+func New(svc service.HardcoverService, user *api.User, ub *api.UserBook) *Model {
 	s := spinner.New(
 		spinner.WithSpinner(spinner.Dot),
 		spinner.WithStyle(common.SpinnerStyle),
@@ -116,11 +103,12 @@ func New(client *api.Client, user *api.User, ub *api.UserBook) *Model {
 	l.Styles.NoItems = common.ValueStyle
 
 	return &Model{
-		client:   client,
+		svc:      svc,
 		user:     user,
 		userBook: ub,
 		list:     l,
-		textarea: ta,
+		form:     common.NewJournalForm(),
+		filter:   common.JournalAll,
 		spinner:  s,
 		loading:  true,
 	}
@@ -147,12 +135,12 @@ func (m *Model) InputFocused() bool {
 }
 
 func (m *Model) loadJournals() tea.Cmd {
-	client := m.client
+	svc := m.svc
 	user := m.user
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		journals, err := queries.GetReadingJournals(ctx, client, user.ID, 20)
+		journals, err := svc.GetReadingJournals(ctx, user.ID, 20)
 		return journalsLoadedMsg{journals: journals, err: err}
 	}
 }
@@ -166,24 +154,43 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.journals = msg.journals
-		items := make([]list.Item, len(m.journals))
-		for i, j := range m.journals {
-			items[i] = journalItem{data: j}
-		}
-		m.list.SetItems(items)
+		m.applyFilter()
 		return m, nil
 
+	case journalDeletedMsg:
+		if msg.err != nil {
+			m.loading = false
+			m.err = msg.err
+			return m, nil
+		}
+		if msg.undo.Run == nil {
+			return m, m.loadJournals()
+		}
+		return m, tea.Batch(m.loadJournals(), common.NotifyUndoableCmd(common.NotifySuccess, "Journal entry deleted", msg.undo))
+
 	case journalSavedMsg:
 		m.loading = false
-		m.mode = modeList
 		if msg.err != nil {
+			// Stay in write mode with the text intact and keep a copy on
+			// disk so the entry survives leaving the screen.
 			m.err = msg.err
+			common.SaveDraft(m.draftKey(), msg.entry)
 			return m, nil
 		}
+		m.mode = modeList
 		m.success = true
-		m.textarea.SetValue("")
+		m.form.Reset()
+		common.ClearDraft(m.draftKey())
 		return m, m.loadJournals()
 
+	case common.EditorFinishedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.form.Entry.SetValue(msg.Content)
+		return m, nil
+
 	case spinner.TickMsg:
 		if m.loading {
 			var cmd tea.Cmd
@@ -199,22 +206,23 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.mode == modeWrite {
 			switch msg.String() {
 			case "ctrl+s":
-				entry := strings.TrimSpace(m.textarea.Value())
-				if entry == "" {
+				event, entry, meta, err := m.form.Build()
+				if err != nil {
+					m.err = err
 					return m, nil
 				}
 				m.loading = true
 				m.err = nil
 				m.success = false
-				return m, tea.Batch(m.spinner.Tick, m.saveEntry(entry))
+				return m, tea.Batch(m.spinner.Tick, m.saveEntry(event, entry, meta))
+			case "ctrl+o":
+				return m, common.OpenEditor("journal", m.form.Entry.Value(), ".md")
 			case "esc":
 				m.mode = modeList
-				m.textarea.Blur()
+				m.form.Blur()
 				return m, nil
 			}
-			var cmd tea.Cmd
-			m.textarea, cmd = m.textarea.Update(msg)
-			return m, cmd
+			return m, m.form.Update(msg)
 		}
 
 		if m.list.FilterState() == list.Filtering {
@@ -225,14 +233,21 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch msg.String() {
 		case "n":
-			m.mode = modeWrite
-			m.success = false
-			m.textarea.Focus()
-			return m, textarea.Blink
+			return m, m.startEntry(common.JournalNote)
+		case "\"":
+			return m, m.startEntry(common.JournalQuote)
+		case "g":
+			return m, m.startEntry(common.JournalProgress)
+		case "s":
+			return m, m.startEntry(common.JournalStatus)
+		case "t":
+			m.filter = common.NextJournalFilter(m.filter)
+			m.applyFilter()
+			return m, nil
 		case "d":
 			if item, ok := m.list.SelectedItem().(journalItem); ok {
 				m.loading = true
-				return m, tea.Batch(m.spinner.Tick, m.deleteEntry(item.data.ID))
+				return m, tea.Batch(m.spinner.Tick, m.deleteEntry(item.data))
 			}
 		}
 	}
@@ -246,35 +261,75 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *Model) saveEntry(entry string) tea.Cmd {
-	client := m.client
+// startEntry switches to write mode for a new entry of the given kind,
+// restoring any unsaved draft text.
+func (m *Model) startEntry(kind common.JournalKind) tea.Cmd {
+	m.mode = modeWrite
+	m.success = false
+	m.err = nil
+	if m.form.Entry.Value() == "" {
+		if draft, ok := common.LoadDraft(m.draftKey()); ok {
+			m.form.Entry.SetValue(draft)
+		}
+	}
+	pages := 0
+	if m.userBook != nil && m.userBook.Book.Pages != nil {
+		pages = *m.userBook.Book.Pages
+	}
+	return m.form.Start(kind, pages)
+}
+
+// applyFilter rebuilds the list from the loaded journals using the event filter.
+func (m *Model) applyFilter() {
+	journals := common.FilterJournals(m.journals, m.filter)
+	items := make([]list.Item, len(journals))
+	for i, j := range journals {
+		items[i] = journalItem{data: j}
+	}
+	m.list.SetItems(items)
+}
+
+func (m *Model) saveEntry(event, entry string, meta api.JournalMetadata) tea.Cmd {
+	svc := m.svc
 	ub := m.userBook
 	return func() tea.Msg {
 		if ub == nil {
-			return journalSavedMsg{err: fmt.Errorf("no book selected")}
+			return journalSavedMsg{entry: entry, err: fmt.Errorf("no book selected")}
 		}
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		now := time.Now().Format("2006-01-02")
-		err := mutations.InsertReadingJournal(ctx, client, ub.BookID, "note", entry, now)
-		return journalSavedMsg{err: err}
+		err := svc.InsertReadingJournalEvent(ctx, ub.BookID, event, entry, meta, "")
+		return journalSavedMsg{entry: entry, err: err}
 	}
 }
 
-func (m *Model) deleteEntry(id int) tea.Cmd {
-	client := m.client
+// draftKey identifies the unsaved journal entry for this book on disk.
+func (m *Model) draftKey() string {
+	if m.userBook == nil {
+		return "journal"
+	}
+	return fmt.Sprintf("journal-%d", m.userBook.BookID)
+}
+
+func (m *Model) deleteEntry(j api.ReadingJournal) tea.Cmd {
+	svc := m.svc
+	undo, _ := common.UndoJournalDelete(svc, j)
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		err := mutations.DeleteReadingJournal(ctx, client, id)
-		if err != nil {
-			return journalsLoadedMsg{err: err}
-		}
-		journals, err := queries.GetReadingJournals(ctx, client, m.user.ID, 20)
-		return journalsLoadedMsg{journals: journals, err: err}
+		err := svc.DeleteReadingJournal(ctx, j.ID)
+		return journalDeletedMsg{undo: undo, err: err}
 	}
 }
 
+// Links returns the pages of the journal's book.
+func (m *Model) Links() []common.Link {
+	if m.userBook == nil {
+		return nil
+	}
+	return common.BookLinks(m.userBook.Book)
+}
+
 func (m *Model) View() string {
 	if m.loading {
 		return common.AppStyle.Render(
@@ -297,22 +352,25 @@ func (m *Model) View() string {
 
 	if m.mode == modeWrite {
 		var write strings.Builder
-		write.WriteString(common.LabelStyle.Render("New Journal Entry"))
+		write.WriteString(common.LabelStyle.Render("New " + m.form.Kind.String()))
 		if m.userBook != nil {
 			write.WriteString(fmt.Sprintf(" - %s", m.userBook.Book.Title))
 		}
 		write.WriteString("\n\n")
-		write.WriteString(m.textarea.View())
+		write.WriteString(m.form.View())
 		write.WriteString("\n\n")
-		write.WriteString(common.HelpStyle.Render("ctrl+s: save | esc: cancel"))
+		write.WriteString(common.HelpStyle.Render(m.form.HelpText()))
 		b.WriteString(common.PanelActiveStyle.Render(write.String()))
 		return common.AppStyle.Render(b.String())
 	}
 
+	if m.filter != common.JournalAll {
+		b.WriteString(common.LabelStyle.Render("Showing: ") + common.ValueStyle.Render(m.filter.String()) + "\n")
+	}
 	b.WriteString(common.PanelStyle.Render(m.list.View()))
 
 	b.WriteString("\n")
-	b.WriteString(common.HelpStyle.Render("n: new entry | d: delete | j/k: navigate | esc: back"))
+	b.WriteString(common.HelpStyle.Render("n: note | \": quote | g: progress | s: status | t: filter | d: delete | esc: back"))
 
 	return common.AppStyle.Render(b.String())
 }
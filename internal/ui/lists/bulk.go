@@ -0,0 +1,289 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/common"
+)
+
+// Bulk actions offered for the marked books, in menu order.
+var bulkActions = []string{
+	"Change status",
+	"Add to another list",
+	"Remove from this list",
+	"Set privacy",
+}
+
+var errNotInLibrary = errors.New("not in your library")
+
+// updateSelection handles the keys that mark books in the book list. It
+// reports whether the key was one of them.
+func (m *Model) updateSelection(key string) (tea.Cmd, bool) {
+	item, ok := m.bookList.SelectedItem().(bookListItem)
+	switch key {
+	case " ":
+		if ok {
+			m.selection.Toggle(item.data.ID)
+		}
+	case "V":
+		if ok {
+			m.selection.MarkRange(m.listBookIDs(), item.data.ID)
+		}
+	case "*":
+		m.selection.ToggleAll(m.listBookIDs())
+	case "esc":
+		if m.selection.Len() == 0 {
+			return nil, false
+		}
+		m.selection.Clear()
+	case "b":
+		if m.selection.Len() == 0 {
+			return nil, false
+		}
+		m.picker = common.NewPicker(fmt.Sprintf("%d books", m.selection.Len()), "bulk", bulkActions)
+		return nil, true
+	default:
+		return nil, false
+	}
+	return m.refreshMarks(), true
+}
+
+func (m *Model) listBookIDs() []int {
+	ids := make([]int, len(m.listBooks))
+	for i, lb := range m.listBooks {
+		ids[i] = lb.ID
+	}
+	return ids
+}
+
+// refreshMarks redraws the book items whose mark changed.
+func (m *Model) refreshMarks() tea.Cmd {
+	var cmds []tea.Cmd
+	for i, it := range m.bookList.Items() {
+		bi, ok := it.(bookListItem)
+		if !ok || bi.marked == m.selection.Marked(bi.data.ID) {
+			continue
+		}
+		bi.marked = !bi.marked
+		cmds = append(cmds, m.bookList.SetItem(i, bi))
+	}
+	return tea.Batch(cmds...)
+}
+
+// markedBooks returns the marked list books in list order.
+func (m *Model) markedBooks() []api.ListBook {
+	var books []api.ListBook
+	for _, lb := range m.listBooks {
+		if m.selection.Marked(lb.ID) {
+			books = append(books, lb)
+		}
+	}
+	return books
+}
+
+// otherLists returns the user's lists except the one shown.
+func (m *Model) otherLists() []api.List {
+	current := 0
+	if item, ok := m.list.SelectedItem().(listItem); ok {
+		current = item.data.ID
+	}
+	var lists []api.List
+	for _, l := range m.lists {
+		if l.ID != current {
+			lists = append(lists, l)
+		}
+	}
+	return lists
+}
+
+// updatePicker handles a key while the bulk action picker is open.
+func (m *Model) updatePicker(key string) tea.Cmd {
+	choice := m.picker.HandleKey(key)
+	if choice < 0 {
+		return nil
+	}
+	switch m.picker.Action {
+	case "bulk":
+		switch bulkActions[choice] {
+		case "Change status":
+			var names []string
+			for _, s := range api.AllStatuses() {
+				names = append(names, s.String())
+			}
+			m.picker = common.NewPicker("Change status", "bulk-status", names)
+		case "Add to another list":
+			var names []string
+			for _, l := range m.otherLists() {
+				names = append(names, l.Name)
+			}
+			m.picker = common.NewPicker("Add to another list", "bulk-add-list", names)
+		case "Remove from this list":
+			m.confirm = common.NewConfirm(
+				fmt.Sprintf("Remove %d books from this list?", m.selection.Len()),
+				"bulk-remove",
+			)
+			m.mode = modeConfirm
+		case "Set privacy":
+			var names []string
+			for _, p := range api.AllPrivacySettings() {
+				names = append(names, p.String())
+			}
+			m.picker = common.NewPicker("Set privacy", "bulk-privacy", names)
+		}
+	case "bulk-status":
+		return m.runStatusBulk(int(api.AllStatuses()[choice]))
+	case "bulk-privacy":
+		return m.runPrivacyBulk(int(api.AllPrivacySettings()[choice]))
+	case "bulk-add-list":
+		return m.runAddToListBulk(m.otherLists()[choice])
+	}
+	return nil
+}
+
+// updateBulk handles a key while a bulk run or its summary is shown.
+func (m *Model) updateBulk(key string) tea.Cmd {
+	if !m.bulk.Finished() {
+		if key == "esc" {
+			m.bulk.Cancel()
+		}
+		return nil
+	}
+	if key == "enter" || key == "esc" {
+		m.bulk = nil
+	}
+	return nil
+}
+
+// startBulk runs ops over the marked books with a progress overlay.
+func (m *Model) startBulk(title string, ops []common.BulkOp) tea.Cmd {
+	m.bulk = common.NewBulk(title, ops)
+	return m.bulk.Start()
+}
+
+// finishBulk reports a finished run and reloads the lists, whose book
+// counts may have changed. Books that failed stay marked.
+func (m *Model) finishBulk() tea.Cmd {
+	notify := m.bulk.Notify()
+	m.selection.Clear()
+	for _, f := range m.bulk.Failures() {
+		m.selection.Toggle(f.ID)
+	}
+	if len(m.bulk.Failures()) == 0 {
+		m.bulk = nil
+	}
+	if item, ok := m.list.SelectedItem().(listItem); ok {
+		m.restoreListID = item.data.ID
+		m.restoreCursor = m.bookList.Index()
+	}
+	return tea.Batch(m.refreshMarks(), m.loadLists(), notify)
+}
+
+// runStatusBulk sets the status of the marked books, adding those not in
+// the library yet. Undoing takes the added books out again.
+func (m *Model) runStatusBulk(statusID int) tea.Cmd {
+	svc := m.svc
+	userID := m.user.ID
+	var ops []common.BulkOp
+	for _, lb := range m.markedBooks() {
+		bookID := lb.BookID
+		// Set by Run for Undo.
+		var (
+			ubID  int
+			prev  api.StatusID
+			added bool
+		)
+		ops = append(ops, common.BulkOp{
+			ID:    lb.ID,
+			Label: lb.Book.Title,
+			Run: func(ctx context.Context) error {
+				ub, err := svc.GetUserBookByBookID(ctx, userID, bookID)
+				if err != nil {
+					return err
+				}
+				if ub == nil {
+					created, err := svc.InsertUserBook(ctx, bookID, statusID)
+					if err != nil {
+						return err
+					}
+					ubID, added = created.ID, true
+					return nil
+				}
+				ubID, prev = ub.ID, ub.Status()
+				return svc.UpdateUserBookStatus(ctx, ub.ID, statusID)
+			},
+			Undo: func(ctx context.Context) error {
+				if added {
+					return svc.DeleteUserBook(ctx, ubID)
+				}
+				return svc.UpdateUserBookStatus(ctx, ubID, int(prev))
+			},
+		})
+	}
+	return m.startBulk("Change status", ops)
+}
+
+func (m *Model) runPrivacyBulk(privacySettingID int) tea.Cmd {
+	svc := m.svc
+	userID := m.user.ID
+	var ops []common.BulkOp
+	for _, lb := range m.markedBooks() {
+		bookID := lb.BookID
+		ops = append(ops, common.BulkOp{
+			ID:    lb.ID,
+			Label: lb.Book.Title,
+			Run: func(ctx context.Context) error {
+				ub, err := svc.GetUserBookByBookID(ctx, userID, bookID)
+				if err != nil {
+					return err
+				}
+				if ub == nil {
+					return errNotInLibrary
+				}
+				return svc.UpdateUserBookPrivacy(ctx, ub.ID, privacySettingID)
+			},
+		})
+	}
+	return m.startBulk("Set privacy", ops)
+}
+
+func (m *Model) runAddToListBulk(l api.List) tea.Cmd {
+	svc := m.svc
+	var ops []common.BulkOp
+	for _, lb := range m.markedBooks() {
+		bookID := lb.BookID
+		ops = append(ops, common.BulkOp{
+			ID:    lb.ID,
+			Label: lb.Book.Title,
+			Run: func(ctx context.Context) error {
+				return svc.InsertListBook(ctx, l.ID, bookID)
+			},
+		})
+	}
+	return m.startBulk("Add to "+l.Name, ops)
+}
+
+func (m *Model) runRemoveBulk() tea.Cmd {
+	svc := m.svc
+	var l api.List
+	if item, ok := m.list.SelectedItem().(listItem); ok {
+		l = item.data
+	}
+	var ops []common.BulkOp
+	for _, lb := range m.markedBooks() {
+		id := lb.ID
+		ops = append(ops, common.BulkOp{
+			ID:    id,
+			Label: lb.Book.Title,
+			Run: func(ctx context.Context) error {
+				return svc.DeleteListBook(ctx, id)
+			},
+			Undo: common.UndoListBookRemoval(svc, l.ID, l.Name, lb.BookID).Run,
+		})
+	}
+	return m.startBulk("Remove from list", ops)
+}
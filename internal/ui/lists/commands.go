@@ -6,87 +6,118 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
-	"github.com/NotMugil/hardcover-tui/internal/api/mutations"
-	"github.com/NotMugil/hardcover-tui/internal/api/queries"
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/common"
 )
 
 func (m *Model) loadLists() tea.Cmd {
-	client := m.client
+	svc := m.svc
 	user := m.user
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		lists, err := queries.GetLists(ctx, client, user.ID)
+		lists, err := svc.GetLists(ctx, user.ID)
 		return listsLoadedMsg{lists: lists, err: err}
 	}
 }
 
 func (m *Model) loadListBooks(listID int) tea.Cmd {
-	client := m.client
+	svc := m.svc
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		books, err := queries.GetListBooks(ctx, client, listID)
+		books, err := svc.GetListBooks(ctx, listID)
 		return listBooksLoadedMsg{books: books, err: err}
 	}
 }
 
 func (m *Model) createList(name string) tea.Cmd {
-	client := m.client
-	return func() tea.Msg {
+	svc := m.svc
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		list, err := mutations.InsertList(ctx, client, name, "")
-		return listCreatedMsg{list: list, err: err}
+		list, err := svc.InsertList(ctx, name, "")
+		return listCreatedMsg{list: list, retry: cmd, err: err}
 	}
+	return cmd
 }
 
-func (m *Model) deleteList(id int) tea.Cmd {
-	client := m.client
-	return func() tea.Msg {
+// deleteList deletes l, first noting its books so undo can recreate it.
+func (m *Model) deleteList(l api.List) tea.Cmd {
+	svc := m.svc
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		err := mutations.DeleteList(ctx, client, id)
-		return listDeletedMsg{err: err}
+		books, err := svc.GetListBooks(ctx, l.ID)
+		if err != nil {
+			return listDeletedMsg{retry: cmd, err: err}
+		}
+		bookIDs := make([]int, len(books))
+		for i, lb := range books {
+			bookIDs[i] = lb.BookID
+		}
+		if err := svc.DeleteList(ctx, l.ID); err != nil {
+			return listDeletedMsg{retry: cmd, err: err}
+		}
+		return listDeletedMsg{undo: common.UndoListDelete(svc, l, bookIDs)}
 	}
+	return cmd
 }
 
 func (m *Model) doSearchBooks(query string) tea.Cmd {
-	client := m.client
+	svc := m.svc
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		books, err := queries.Search(ctx, client, query)
+		books, err := svc.Search(ctx, query)
 		return searchBooksMsg{books: books, err: err}
 	}
 }
 
 func (m *Model) addBookToSelectedList(listID, bookID int) tea.Cmd {
-	client := m.client
-	return func() tea.Msg {
+	svc := m.svc
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		err := mutations.InsertListBook(ctx, client, listID, bookID)
-		return bookAddedToListMsg{err: err}
+		err := svc.InsertListBook(ctx, listID, bookID)
+		return bookAddedToListMsg{retry: cmd, err: err}
 	}
+	return cmd
 }
 
-func (m *Model) removeBookFromList(listBookID int) tea.Cmd {
-	client := m.client
-	return func() tea.Msg {
+func (m *Model) removeBookFromList(l api.List, lb api.ListBook) tea.Cmd {
+	svc := m.svc
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		err := mutations.DeleteListBook(ctx, client, listBookID)
-		return bookRemovedFromListMsg{err: err}
+		err := svc.DeleteListBook(ctx, lb.ID)
+		return bookRemovedFromListMsg{err: err, retry: cmd, undo: common.UndoListBookRemoval(svc, l.ID, l.Name, lb.BookID)}
 	}
+	return cmd
 }
 
 func (m *Model) updateListPrivacy(listID int, name, description string, privacySettingID int) tea.Cmd {
-	client := m.client
+	svc := m.svc
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		err := svc.UpdateList(ctx, listID, name, description, privacySettingID)
+		return privacyUpdatedMsg{retry: cmd, err: err}
+	}
+	return cmd
+}
+
+func (m *Model) updateListDescription(l api.List, description string) tea.Cmd {
+	svc := m.svc
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		err := mutations.UpdateList(ctx, client, listID, name, description, privacySettingID)
-		return privacyUpdatedMsg{err: err}
+		err := svc.UpdateList(ctx, l.ID, l.Name, description, l.PrivacySettingID)
+		return descriptionUpdatedMsg{listID: l.ID, description: description, err: err}
 	}
 }
@@ -0,0 +1,18 @@
+package lists
+
+import "github.com/NotMugil/hardcover-tui/internal/common"
+
+// Links returns the pages of the selected book, when the book list has
+// focus, and of the selected list.
+func (m *Model) Links() []common.Link {
+	var links []common.Link
+	if m.focusRight {
+		if item, ok := m.bookList.SelectedItem().(bookListItem); ok {
+			links = common.BookLinks(item.data.Book)
+		}
+	}
+	if item, ok := m.list.SelectedItem().(listItem); ok && item.data.Slug != nil && *item.data.Slug != "" {
+		links = append(links, common.ListLink(m.user.Username, item.data.Name, *item.data.Slug))
+	}
+	return links
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
 	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/service"
 )
 
 // NavigateToBookFromListMsg signals the app to navigate to a book's detail from a list.
@@ -45,13 +46,18 @@ type listBooksLoadedMsg struct {
 	err   error
 }
 
+// Messages for changes the user makes carry the command that made them, so
+// a failure can be retried from the notification center.
 type listCreatedMsg struct {
-	list *api.List
-	err  error
+	list  *api.List
+	retry tea.Cmd
+	err   error
 }
 
 type listDeletedMsg struct {
-	err error
+	undo  common.Undo
+	retry tea.Cmd
+	err   error
 }
 
 type searchBooksMsg struct {
@@ -60,15 +66,25 @@ type searchBooksMsg struct {
 }
 
 type bookAddedToListMsg struct {
-	err error
+	retry tea.Cmd
+	err   error
 }
 
 type bookRemovedFromListMsg struct {
-	err error
+	undo  common.Undo
+	retry tea.Cmd
+	err   error
 }
 
 type privacyUpdatedMsg struct {
-	err error
+	retry tea.Cmd
+	err   error
+}
+
+type descriptionUpdatedMsg struct {
+	listID      int
+	description string
+	err         error
 }
 
 type inputMode int
@@ -122,10 +138,14 @@ func (i listItem) FilterValue() string {
 
 // bookListItem implements list.DefaultItem for books in a list.
 type bookListItem struct {
-	data api.ListBook
+	data   api.ListBook
+	marked bool
 }
 
 func (i bookListItem) Title() string {
+	if i.marked {
+		return common.MarkPrefix + i.data.Book.Title
+	}
 	return i.data.Book.Title
 }
 
@@ -143,7 +163,7 @@ func (i bookListItem) FilterValue() string {
 
 // Model is the lists screen model.
 type Model struct {
-	client        *api.Client
+	svc           service.HardcoverService
 	user          *api.User
 	lists         []api.List
 	listBooks     []api.ListBook
@@ -170,10 +190,16 @@ type Model struct {
 	privacyCursor int
 	confirm       common.ConfirmState
 	confirmItemID int // ID of item being confirmed for delete/remove
+	restoreListID int // list to select once lists load, from Restore
+	restoreCursor int // book to select once that list's books load
+
+	selection common.Selection // list books marked for a bulk action
+	picker    common.PickerState
+	bulk      *common.Bulk // running or finished bulk action
 }
 
 // New creates a new lists screen.
-func New(client *api.Client, user *api.User) *Model {
+func New(svc service.HardcoverService, user *api.User) *Model {
 	s := spinner.New(
 		spinner.WithSpinner(spinner.Dot),
 		spinner.WithStyle(common.SpinnerStyle),
@@ -230,7 +256,7 @@ func New(client *api.Client, user *api.User) *Model {
 	st := newSearchResultTable(50, 10)
 
 	return &Model{
-		client:      client,
+		svc:         svc,
 		user:        user,
 		list:        l,
 		bookList:    bl,
@@ -258,9 +284,33 @@ func (m *Model) Init() tea.Cmd {
 	return tea.Batch(m.spinner.Tick, m.loadLists())
 }
 
+// State is the part of the lists screen kept between sessions.
+type State struct {
+	ListID     int `json:"list_id,omitempty"`
+	BookCursor int `json:"book_cursor,omitempty"`
+}
+
+// State returns the selected list and book.
+func (m *Model) State() State {
+	var s State
+	if item, ok := m.list.SelectedItem().(listItem); ok {
+		s.ListID = item.data.ID
+		s.BookCursor = m.bookList.Index()
+	}
+	return s
+}
+
+// Restore selects a saved list and book once they load. If the list no
+// longer exists the first list is shown instead.
+func (m *Model) Restore(s State) {
+	m.restoreListID = s.ListID
+	m.restoreCursor = s.BookCursor
+}
+
 // InputFocused returns true when text input is active.
 func (m *Model) InputFocused() bool {
-	return m.mode == modeCreate || m.mode == modeAddBook || m.mode == modePrivacy || m.mode == modeConfirm || m.list.FilterState() == list.Filtering
+	return m.mode == modeCreate || m.mode == modeAddBook || m.mode == modePrivacy || m.mode == modeConfirm || m.list.FilterState() == list.Filtering ||
+		m.picker.Active || m.bulk != nil
 }
 
 // newSearchResultTable creates a styled table for search results in the add-book flow.
@@ -2,6 +2,7 @@ package lists
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -36,11 +37,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			items[i] = listItem{data: l}
 		}
 		m.list.SetItems(items)
-		if len(m.lists) > 0 {
+		var notifyCmd tea.Cmd
+		if id := m.restoreListID; id != 0 {
+			m.restoreListID = 0
+			idx := slices.IndexFunc(m.lists, func(l api.List) bool { return l.ID == id })
+			if idx >= 0 {
+				m.list.Select(idx)
+			} else {
+				m.restoreCursor = 0
+				notifyCmd = common.NotifyCmd(common.NotifyWarning, "The list you had open no longer exists")
+			}
+		}
+		if item, ok := m.list.SelectedItem().(listItem); ok {
 			m.booksLoading = true
-			return m, m.loadListBooks(m.lists[0].ID)
+			return m, tea.Batch(m.loadListBooks(item.data.ID), notifyCmd)
 		}
-		return m, nil
+		return m, notifyCmd
 
 	case listBooksLoadedMsg:
 		m.booksLoading = false
@@ -49,11 +61,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.listBooks = msg.books
+		m.selection.Keep(m.listBookIDs())
 		items := make([]list.Item, len(m.listBooks))
 		for i, lb := range m.listBooks {
-			items[i] = bookListItem{data: lb}
+			items[i] = bookListItem{data: lb, marked: m.selection.Marked(lb.ID)}
 		}
 		m.bookList.SetItems(items)
+		if m.restoreCursor > 0 && len(items) > 0 {
+			m.bookList.Select(min(m.restoreCursor, len(items)-1))
+		}
+		m.restoreCursor = 0
 		return m, nil
 
 	case listCreatedMsg:
@@ -63,7 +80,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.nameInput.Blur()
 		if msg.err != nil {
 			m.err = msg.err
-			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
+			return m, common.NotifyRetryCmd(common.NotifyError, msg.err.Error(), msg.retry)
 		}
 		return m, tea.Batch(m.loadLists(), common.NotifyCmd(common.NotifySuccess, "List created"))
 
@@ -71,9 +88,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		if msg.err != nil {
 			m.err = msg.err
-			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
+			return m, common.NotifyRetryCmd(common.NotifyError, msg.err.Error(), msg.retry)
 		}
-		return m, tea.Batch(m.loadLists(), common.NotifyCmd(common.NotifySuccess, "List deleted"))
+		return m, tea.Batch(m.loadLists(), common.NotifyUndoableCmd(common.NotifySuccess, "List deleted", msg.undo))
 
 	case searchBooksMsg:
 		m.searching = false
@@ -98,7 +115,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case bookAddedToListMsg:
 		if msg.err != nil {
 			m.addErr = msg.err
-			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
+			return m, common.NotifyRetryCmd(common.NotifyError, msg.err.Error(), msg.retry)
 		}
 		m.addSuccess = true
 		if item, ok := m.list.SelectedItem().(listItem); ok {
@@ -110,21 +127,53 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case bookRemovedFromListMsg:
 		if msg.err != nil {
 			m.err = msg.err
-			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
+			return m, common.NotifyRetryCmd(common.NotifyError, msg.err.Error(), msg.retry)
 		}
+		notify := common.NotifyUndoableCmd(common.NotifySuccess, "Book removed from list", msg.undo)
 		if item, ok := m.list.SelectedItem().(listItem); ok {
 			m.booksLoading = true
-			return m, tea.Batch(m.spinner.Tick, m.loadListBooks(item.data.ID), common.NotifyCmd(common.NotifySuccess, "Book removed from list"))
+			return m, tea.Batch(m.spinner.Tick, m.loadListBooks(item.data.ID), notify)
 		}
-		return m, common.NotifyCmd(common.NotifySuccess, "Book removed from list")
+		return m, notify
 
 	case privacyUpdatedMsg:
 		if msg.err != nil {
 			m.err = msg.err
-			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
+			return m, common.NotifyRetryCmd(common.NotifyError, msg.err.Error(), msg.retry)
 		}
 		return m, tea.Batch(m.loadLists(), common.NotifyCmd(common.NotifySuccess, "Privacy updated"))
 
+	case common.BulkStepMsg:
+		if m.bulk == nil {
+			return m, nil
+		}
+		cmd, done := m.bulk.Update(msg)
+		if done {
+			return m, m.finishBulk()
+		}
+		return m, cmd
+
+	case common.EditorFinishedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, common.NotifyCmd(common.NotifyError, msg.Err.Error())
+		}
+		for _, l := range m.lists {
+			if listDraftKey(l.ID) == msg.ID {
+				return m, m.updateListDescription(l, strings.TrimSpace(msg.Content))
+			}
+		}
+		return m, nil
+
+	case descriptionUpdatedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			common.SaveDraft(listDraftKey(msg.listID), msg.description)
+			return m, common.NotifyCmd(common.NotifyError, msg.err.Error()+" (draft kept)")
+		}
+		common.ClearDraft(listDraftKey(msg.listID))
+		return m, tea.Batch(m.loadLists(), common.NotifyCmd(common.NotifySuccess, "Description updated"))
+
 	case spinner.TickMsg:
 		if m.loading || m.booksLoading {
 			var cmd tea.Cmd
@@ -133,6 +182,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.KeyMsg:
+		if m.bulk != nil {
+			return m, m.updateBulk(msg.String())
+		}
+		if m.picker.Active {
+			return m, m.updatePicker(msg.String())
+		}
+
 		if m.mode == modeCreate {
 			switch msg.String() {
 			case "enter":
@@ -246,10 +302,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if confirmed {
 					switch m.confirm.Action {
 					case "delete-list":
-						m.loading = true
-						return m, tea.Batch(m.spinner.Tick, m.deleteList(m.confirmItemID))
+						if i := slices.IndexFunc(m.lists, func(l api.List) bool { return l.ID == m.confirmItemID }); i >= 0 {
+							m.loading = true
+							return m, tea.Batch(m.spinner.Tick, m.deleteList(m.lists[i]))
+						}
 					case "remove-book":
-						return m, m.removeBookFromList(m.confirmItemID)
+						item, _ := m.list.SelectedItem().(listItem)
+						if i := slices.IndexFunc(m.listBooks, func(lb api.ListBook) bool { return lb.ID == m.confirmItemID }); i >= 0 {
+							return m, m.removeBookFromList(item.data, m.listBooks[i])
+						}
+					case "bulk-remove":
+						return m, m.runRemoveBulk()
 					}
 				}
 			}
@@ -267,6 +330,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if m.focusRight {
+			if cmd, ok := m.updateSelection(msg.String()); ok {
+				return m, cmd
+			}
 			switch strings.ToLower(msg.String()) {
 			case "esc":
 				m.focusRight = false
@@ -336,13 +402,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "d":
 			if item, ok := m.list.SelectedItem().(listItem); ok {
 				m.confirm = common.NewConfirm(
-					fmt.Sprintf("Delete list \"%s\"? This cannot be undone.", item.data.Name),
+					fmt.Sprintf("Delete list \"%s\"?", item.data.Name),
 					"delete-list",
 				)
 				m.confirmItemID = item.data.ID
 				m.mode = modeConfirm
 				return m, nil
 			}
+		case "e":
+			if item, ok := m.list.SelectedItem().(listItem); ok {
+				key := listDraftKey(item.data.ID)
+				desc, ok := common.LoadDraft(key)
+				if !ok && item.data.Description != nil {
+					desc = *item.data.Description
+				}
+				return m, common.OpenEditor(key, desc, ".md")
+			}
 		case "p":
 			if item, ok := m.list.SelectedItem().(listItem); ok {
 				m.mode = modePrivacy
@@ -406,3 +481,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	return m, nil
 }
+
+// listDraftKey identifies a list's description, both as the editor ID and
+// as the key of its unsaved draft.
+func listDraftKey(listID int) string {
+	return fmt.Sprintf("list-%d", listID)
+}
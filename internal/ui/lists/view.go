@@ -48,6 +48,20 @@ func (m *Model) View() string {
 		return common.AppStyle.Render(composed)
 	}
 
+	if m.bulk != nil {
+		body := m.renderNormalView()
+		fg := common.RenderBulkOverlay(m.bulk, m.width-20)
+		composed := overlay.Composite(fg, body, overlay.Center, overlay.Center, 0, 0)
+		return common.AppStyle.Render(composed)
+	}
+
+	if m.picker.Active {
+		body := m.renderNormalView()
+		fg := common.RenderPickerOverlay(m.picker, 40)
+		composed := overlay.Composite(fg, body, overlay.Center, overlay.Center, 0, 0)
+		return common.AppStyle.Render(composed)
+	}
+
 	if m.mode == modeConfirm {
 		body := m.renderNormalView()
 		fg := common.RenderConfirmOverlay(m.confirm.Message, m.confirm.Cursor, 50)
@@ -128,6 +142,9 @@ func (m *Model) renderNormalView() string {
 	if item, ok := m.list.SelectedItem().(listItem); ok {
 		listTitle = item.data.Name
 	}
+	if n := m.selection.Len(); n > 0 {
+		listTitle = fmt.Sprintf("%s (%d marked)", listTitle, n)
+	}
 
 	var rightPanel string
 	if m.focusRight {
@@ -233,16 +250,27 @@ func (m *Model) HelpBindings() []key.Binding {
 			key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
 		}
 	}
+	if m.focusRight && m.selection.Len() > 0 {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "bulk actions")),
+			key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "mark")),
+			key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "mark range")),
+			key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "clear marks")),
+		}
+	}
 	if m.focusRight {
 		return []key.Binding{
 			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "book details")),
 			key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "remove from list")),
+			key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "mark")),
+			key.NewBinding(key.WithKeys("*"), key.WithHelp("*", "mark all")),
 		}
 	}
 	return []key.Binding{
 		key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
 		key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new list")),
 		key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "add book")),
+		key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit description")),
 		key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "privacy")),
 		key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
 	}
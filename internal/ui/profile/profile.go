@@ -11,10 +11,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
-	"github.com/NotMugil/hardcover-tui/internal/api/mutations"
-	"github.com/NotMugil/hardcover-tui/internal/api/queries"
 	"github.com/NotMugil/hardcover-tui/internal/common"
 	"github.com/NotMugil/hardcover-tui/internal/keystore"
+	"github.com/NotMugil/hardcover-tui/internal/service"
 )
 
 type profileLoadedMsg struct {
@@ -39,8 +38,9 @@ const (
 
 // Model is the profile screen model.
 type Model struct {
-	client    *api.Client
+	svc       service.HardcoverService
 	user      *api.User
+	username  string // someone else's profile, looked up by username
 	stats     *api.UserBookAggregate
 	avatarArt string
 	spinner   spinner.Model
@@ -54,7 +54,7 @@ type Model struct {
 }
 
 // New creates a new profile screen.
-func New(client *api.Client, user *api.User) *Model {
+func New(svc service.HardcoverService, user *api.User) *Model {
 	s := spinner.New(
 		spinner.WithSpinner(spinner.Dot),
 		spinner.WithStyle(common.SpinnerStyle),
@@ -76,7 +76,7 @@ func New(client *api.Client, user *api.User) *Model {
 	li.Cursor.Style = common.CursorStyle
 
 	return &Model{
-		client:    client,
+		svc:       svc,
 		user:      user,
 		spinner:   s,
 		loading:   true,
@@ -86,6 +86,32 @@ func New(client *api.Client, user *api.User) *Model {
 	}
 }
 
+// NewForUser creates a profile screen for another user, looked up by
+// username. It cannot be edited.
+func NewForUser(svc service.HardcoverService, viewer *api.User, username string) *Model {
+	m := New(svc, viewer)
+	m.username = username
+	return m
+}
+
+// Loaded reports whether the profile has been fetched.
+func (m *Model) Loaded() bool {
+	return !m.loading
+}
+
+// own reports whether the profile is the signed-in user's.
+func (m *Model) own() bool {
+	return m.username == ""
+}
+
+// Links returns the page of the user shown.
+func (m *Model) Links() []common.Link {
+	if m.loading || m.user == nil {
+		return nil
+	}
+	return []common.Link{common.UserLink(m.user.Username)}
+}
+
 func (m *Model) Init() tea.Cmd {
 	return tea.Batch(m.spinner.Tick, m.loadProfile())
 }
@@ -99,16 +125,24 @@ func (m *Model) InputFocused() bool {
 }
 
 func (m *Model) loadProfile() tea.Cmd {
-	client := m.client
-	user := m.user
+	svc := m.svc
+	username := m.username
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		u, err := queries.GetMe(ctx, client)
+		var (
+			u   *api.User
+			err error
+		)
+		if username != "" {
+			u, err = svc.GetUserByUsername(ctx, username)
+		} else {
+			u, err = svc.GetMe(ctx)
+		}
 		if err != nil {
 			return profileLoadedMsg{err: err}
 		}
-		stats, _ := queries.GetUserBookStats(ctx, client, user.ID)
+		stats, _ := svc.GetUserBookStats(ctx, u.ID)
 
 		var avatarArt string
 		if u.ImageURL() != "" {
@@ -128,6 +162,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		if msg.err != nil {
 			m.err = msg.err
+			if !m.own() {
+				m.user = nil // not the viewer's profile to show instead
+			}
 			return m, nil
 		}
 		m.user = msg.user
@@ -193,7 +230,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
-		if m.loading {
+		if m.loading || !m.own() {
 			return m, nil
 		}
 
@@ -220,11 +257,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) updateProfile(name, bio, location string) tea.Cmd {
-	client := m.client
+	svc := m.svc
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		err := mutations.UpdateUserProfile(ctx, client, name, bio, location)
+		err := svc.UpdateUserProfile(ctx, name, bio, location)
 		return profileUpdatedMsg{err: err}
 	}
 }
@@ -329,8 +366,10 @@ func (m *Model) View() string {
 		b.WriteString(common.PanelStyle.Render(stats.String()))
 	}
 
-	b.WriteString("\n")
-	b.WriteString(common.HelpStyle.Render("e: edit profile | x: logout"))
+	if m.own() {
+		b.WriteString("\n")
+		b.WriteString(common.HelpStyle.Render("e: edit profile | x: logout"))
+	}
 
 	return common.AppStyle.Render(b.String())
 }
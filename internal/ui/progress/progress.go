@@ -14,8 +14,8 @@ import (
 	datepicker "github.com/ethanefung/bubble-datepicker"
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
-	"github.com/NotMugil/hardcover-tui/internal/api/mutations"
 	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/service"
 )
 
 type progressUpdatedMsg struct {
@@ -35,19 +35,19 @@ const (
 
 // Model is the progress update screen model.
 type Model struct {
-	client         *api.Client
-	user           *api.User
-	userBook       *api.UserBook
-	pageInput      textinput.Model
-	startedPicker  datepicker.Model
-	finishedPicker datepicker.Model
-	focus          focusField
-	spinner        spinner.Model
-	loading        bool
-	err            error
-	success        bool
-	width          int
-	height         int
+	svc             service.HardcoverService
+	user            *api.User
+	userBook        *api.UserBook
+	pageInput       textinput.Model
+	startedPicker   datepicker.Model
+	finishedPicker  datepicker.Model
+	focus           focusField
+	spinner         spinner.Model
+	loading         bool
+	err             error
+	success         bool
+	width           int
+	height          int
 	confirming      bool
 	pendingPages    int
 	pendingStarted  *string
@@ -55,7 +55,7 @@ type Model struct {
 }
 
 // New creates a new progress screen.
-func New(client *api.Client, user *api.User, ub *api.UserBook) *Model {
+func New(svc service.HardcoverService, user *api.User, ub *api.UserBook) *Model {
 	ti := textinput.New()
 	ti.Placeholder = "Current page..."
 	ti.Width = 20
@@ -73,7 +73,7 @@ func New(client *api.Client, user *api.User, ub *api.UserBook) *Model {
 	fp.Focused = datepicker.FocusNone
 
 	m := &Model{
-		client:         client,
+		svc:            svc,
 		user:           user,
 		userBook:       ub,
 		pageInput:      ti,
@@ -243,7 +243,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) updateProgress(pages int, startedAt, finishedAt *string) tea.Cmd {
-	client := m.client
+	svc := m.svc
 	ub := m.userBook
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -253,12 +253,12 @@ func (m *Model) updateProgress(pages int, startedAt, finishedAt *string) tea.Cmd
 			return progressUpdatedMsg{err: fmt.Errorf("no active read found")}
 		}
 		read := ub.UserBookReads[0]
-		err := mutations.UpdateUserBookRead(ctx, client, read.ID, &pages)
+		err := svc.UpdateUserBookRead(ctx, read.ID, &pages)
 		if err != nil {
 			return progressUpdatedMsg{err: err}
 		}
 		if startedAt != nil || finishedAt != nil {
-			err = mutations.UpdateUserBookReadDates(ctx, client, read.ID, startedAt, finishedAt)
+			err = svc.UpdateUserBookReadDates(ctx, read.ID, startedAt, finishedAt)
 		}
 		return progressUpdatedMsg{err: err}
 	}
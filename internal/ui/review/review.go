@@ -6,36 +6,56 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
-	"github.com/NotMugil/hardcover-tui/internal/api/mutations"
 	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/service"
 )
 
 type reviewSavedMsg struct {
-	err error
+	review     string
+	reviewHTML string
+	err        error
 }
 
+// splitMinWidth is the terminal width at which the preview moves beside the editor.
+const splitMinWidth = 100
+
 // Model is the review screen model.
 type Model struct {
-	client   *api.Client
-	user     *api.User
-	userBook *api.UserBook
-	textarea textarea.Model
-	spinner  spinner.Model
-	loading  bool
-	err      error
-	success  bool
-	editing  bool // true when textarea is focused
+	svc         service.HardcoverService
+	user        *api.User
+	userBook    *api.UserBook
+	textarea    textarea.Model
+	preview     viewport.Model
+	renderer    common.MarkdownRenderer
+	spinner     spinner.Model
+	loading     bool
+	err         error
+	success     bool
+	editing     bool // true when textarea is focused
+	showPreview bool
+	hasSpoilers bool
+	reveal      bool // show spoiler text in the preview
+	recovered   bool // editor was seeded from an unsaved draft
+	width       int
+	height      int
 }
 
 // New creates a new review screen.
-func New(client *api.Client, user *api.User, ub *api.UserBook) *Model {
+// The review is edited as Markdown, seeded from review_html when available
+// so existing formatting survives a round trip.
+func New(svc service.HardcoverService, user *api.User, ub *api.UserBook) *Model {
 	ta := textarea.New()
-	ta.Placeholder = "Write your review..."
+	ta.Placeholder = "Write your review... (Markdown, ||spoiler||)"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
 	ta.SetWidth(60)
 	ta.SetHeight(10)
 	ta.Cursor.Style = common.CursorStyle
@@ -47,31 +67,98 @@ func New(client *api.Client, user *api.User, ub *api.UserBook) *Model {
 	)
 
 	m := &Model{
-		client:   client,
-		user:     user,
-		userBook: ub,
-		textarea: ta,
-		spinner:  s,
-		editing:  true,
+		svc:         svc,
+		user:        user,
+		userBook:    ub,
+		textarea:    ta,
+		preview:     viewport.New(60, 10),
+		spinner:     s,
+		editing:     true,
+		showPreview: true,
 	}
 
-	if ub != nil && ub.Review != nil {
-		ta.SetValue(*ub.Review)
-		m.textarea = ta
+	if ub != nil {
+		m.hasSpoilers = ub.ReviewHasSpoilers
+		switch {
+		case ub.ReviewHTML != nil && *ub.ReviewHTML != "":
+			m.textarea.SetValue(common.HTMLToMarkdown(*ub.ReviewHTML))
+		case ub.Review != nil:
+			m.textarea.SetValue(*ub.Review)
+		}
+		if draft, ok := common.LoadDraft(m.draftKey()); ok {
+			m.textarea.SetValue(draft)
+			m.recovered = true
+		}
 	}
+	m.refreshPreview()
 
 	return m
 }
 
+// draftKey identifies this review's unsaved draft on disk.
+func (m *Model) draftKey() string {
+	if m.userBook == nil {
+		return "review"
+	}
+	return fmt.Sprintf("review-%d", m.userBook.ID)
+}
+
 func (m *Model) Init() tea.Cmd {
 	return textarea.Blink
 }
 
 // SetSize updates the available terminal dimensions.
 func (m *Model) SetSize(w, h int) {
-	if w > 8 {
-		m.textarea.SetWidth(w - 8)
+	m.width = w
+	m.height = h
+	m.layout()
+}
+
+// layout sizes the editor and preview panes for the current terminal size.
+func (m *Model) layout() {
+	w := m.width - 4
+	if w < 30 {
+		w = 60
+	}
+	h := m.height - 8
+	if h < 6 {
+		h = 10
+	}
+
+	edW, edH := w, h
+	pvW, pvH := w, h
+	if m.showPreview {
+		if m.width >= splitMinWidth {
+			edW = w / 2
+			pvW = w - edW
+		} else {
+			edH = h / 2
+			pvH = h - edH
+		}
+	}
+
+	m.textarea.SetWidth(edW - 4)
+	m.textarea.SetHeight(edH - 2)
+	m.preview.Width = pvW - 4
+	m.preview.Height = pvH - 2
+	m.refreshPreview()
+}
+
+// refreshPreview re-renders the Markdown preview from the editor contents.
+func (m *Model) refreshPreview() {
+	if !m.showPreview {
+		return
 	}
+	text := m.textarea.Value()
+	if strings.TrimSpace(text) == "" {
+		m.preview.SetContent(common.HelpStyle.Render("Nothing to preview yet"))
+		return
+	}
+	rendered, err := m.renderer.Render(text, m.preview.Width, m.reveal)
+	if err != nil {
+		rendered = text
+	}
+	m.preview.SetContent(rendered)
 }
 
 // InputFocused returns true when the textarea is actively being edited.
@@ -85,11 +172,35 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		if msg.err != nil {
 			m.err = msg.err
+			if err := common.SaveDraft(m.draftKey(), msg.review); err == nil {
+				return m, common.NotifyCmd(common.NotifyError, msg.err.Error()+" (draft kept)")
+			}
 			return m, common.NotifyCmd(common.NotifyError, msg.err.Error())
 		}
+		common.ClearDraft(m.draftKey())
+		m.recovered = false
+		if m.userBook != nil {
+			review, reviewHTML := msg.review, msg.reviewHTML
+			m.userBook.Review = &review
+			m.userBook.ReviewHTML = &reviewHTML
+			m.userBook.ReviewHasSpoilers = m.hasSpoilers
+			m.userBook.HasReview = true
+		}
 		m.success = true
 		return m, common.NotifyCmd(common.NotifySuccess, "Review saved")
 
+	case common.EditorFinishedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, common.NotifyCmd(common.NotifyError, msg.Err.Error())
+		}
+		m.textarea.SetValue(msg.Content)
+		if common.HasSpoilers(msg.Content) {
+			m.hasSpoilers = true
+		}
+		m.refreshPreview()
+		return m, nil
+
 	case spinner.TickMsg:
 		if m.loading {
 			var cmd tea.Cmd
@@ -111,9 +222,21 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if review == "" {
 				return m, nil
 			}
+			if common.HasSpoilers(review) {
+				m.hasSpoilers = true
+			}
 			m.loading = true
 			m.err = nil
 			return m, tea.Batch(m.spinner.Tick, m.saveReview(review))
+		case "ctrl+o":
+			return m, common.OpenEditor("review", m.textarea.Value(), ".md")
+		case "ctrl+g":
+			m.hasSpoilers = !m.hasSpoilers
+			return m, nil
+		case "ctrl+l":
+			m.showPreview = !m.showPreview
+			m.layout()
+			return m, nil
 		case "esc":
 			if m.editing {
 				m.editing = false
@@ -121,36 +244,60 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			return m, nil
-		case "i", "enter":
-			if !m.editing {
-				m.editing = true
-				m.textarea.Focus()
-				return m, textarea.Blink
-			}
 		}
 
 		if m.editing {
 			var cmd tea.Cmd
 			m.textarea, cmd = m.textarea.Update(msg)
+			m.refreshPreview()
 			return m, cmd
 		}
 
+		switch msg.String() {
+		case "i", "enter":
+			m.editing = true
+			m.textarea.Focus()
+			return m, textarea.Blink
+		case "o":
+			return m, common.OpenEditor("review", m.textarea.Value(), ".md")
+		case "x":
+			m.hasSpoilers = !m.hasSpoilers
+			return m, nil
+		case "p":
+			m.showPreview = !m.showPreview
+			m.layout()
+			return m, nil
+		case "r":
+			m.reveal = !m.reveal
+			m.refreshPreview()
+			return m, nil
+		}
+
+		if m.showPreview {
+			var cmd tea.Cmd
+			m.preview, cmd = m.preview.Update(msg)
+			return m, cmd
+		}
 		return m, nil
 	}
 	return m, nil
 }
 
 func (m *Model) saveReview(review string) tea.Cmd {
-	client := m.client
+	svc := m.svc
 	ub := m.userBook
+	hasSpoilers := m.hasSpoilers
 	return func() tea.Msg {
 		if ub == nil {
 			return reviewSavedMsg{err: fmt.Errorf("no book to review")}
 		}
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		err := mutations.UpdateUserBookReview(ctx, client, ub.ID, review, false)
-		return reviewSavedMsg{err: err}
+		// Hardcover shows review_html, so ||spoilers|| and the rest of the
+		// Markdown are rendered here rather than sent as typed.
+		reviewHTML := common.MarkdownToHTML(review)
+		err := svc.UpdateUserBookReview(ctx, ub.ID, review, reviewHTML, hasSpoilers)
+		return reviewSavedMsg{review: review, reviewHTML: reviewHTML, err: err}
 	}
 }
 
@@ -168,6 +315,10 @@ func (m *Model) View() string {
 		b.WriteString(common.ErrorStyle.Render("Error: "+m.err.Error()) + "\n\n")
 	}
 
+	if m.recovered {
+		b.WriteString(common.HelpStyle.Render("Recovered unsaved draft from a failed save") + "\n\n")
+	}
+
 	if m.success {
 		b.WriteString(common.PanelStyle.Render(common.SuccessStyle.Render("Review saved!")))
 		b.WriteString("\n\n")
@@ -180,18 +331,77 @@ func (m *Model) View() string {
 		return common.AppStyle.Render(b.String())
 	}
 
+	var meta []string
 	if m.userBook != nil && m.userBook.Rating != nil {
-		b.WriteString(common.LabelStyle.Render("Rating: ") + common.RenderRatingBar(*m.userBook.Rating, 15))
-		b.WriteString("\n\n")
+		meta = append(meta, common.LabelStyle.Render("Rating: ")+common.RenderRatingBar(*m.userBook.Rating, 15))
 	}
-
-	b.WriteString(m.textarea.View())
+	spoilers := common.ValueStyle.Render("off")
+	if m.hasSpoilers {
+		spoilers = lipgloss.NewStyle().Foreground(common.ColorWarning).Bold(true).Render("on")
+	}
+	meta = append(meta, common.LabelStyle.Render("Spoilers: ")+spoilers)
+	b.WriteString(strings.Join(meta, "   "))
 	b.WriteString("\n\n")
+
+	editorPanel := common.RenderPanel
 	if m.editing {
-		b.WriteString(common.HelpStyle.Render("ctrl+s: save | esc: stop editing"))
+		editorPanel = common.RenderActivePanel
+	}
+	editorW := m.textarea.Width() + 4
+	editor := editorPanel("Markdown", m.textarea.View(), editorW)
+
+	if m.showPreview {
+		previewTitle := "Preview"
+		if m.reveal {
+			previewTitle = "Preview (spoilers shown)"
+		}
+		preview := common.RenderPanel(previewTitle, m.preview.View(), m.preview.Width+4, m.preview.Height+2)
+		if m.width >= splitMinWidth {
+			b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, editor, preview))
+		} else {
+			b.WriteString(lipgloss.JoinVertical(lipgloss.Left, editor, preview))
+		}
+	} else {
+		b.WriteString(editor)
+	}
+	b.WriteString("\n")
+	if m.editing {
+		b.WriteString(common.HelpStyle.Render("ctrl+s: save | ctrl+o: $EDITOR | ctrl+g: spoilers | ctrl+l: preview | esc: stop editing"))
 	} else {
-		b.WriteString(common.HelpStyle.Render("i/enter: edit | ctrl+s: save | esc: back"))
+		b.WriteString(common.HelpStyle.Render("i/enter: edit | o: $EDITOR | x: spoilers | p: preview | r: reveal | ctrl+s: save | esc: back"))
 	}
 
 	return common.AppStyle.Render(b.String())
 }
+
+// Links returns the page of the user's review, once published, and of the
+// book.
+func (m *Model) Links() []common.Link {
+	if m.userBook == nil {
+		return nil
+	}
+	var links []common.Link
+	if m.userBook.HasReview && m.user != nil {
+		if l, ok := common.ReviewLink(m.userBook.Book, m.user.Username); ok {
+			links = append(links, l)
+		}
+	}
+	return append(links, common.BookLinks(m.userBook.Book)...)
+}
+
+// HelpBindings returns page-specific keybindings for the global help bar.
+func (m *Model) HelpBindings() []key.Binding {
+	if m.editing {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+			key.NewBinding(key.WithKeys("ctrl+o"), key.WithHelp("ctrl+o", "$EDITOR")),
+			key.NewBinding(key.WithKeys("ctrl+g"), key.WithHelp("ctrl+g", "spoilers")),
+		}
+	}
+	return []key.Binding{
+		key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "edit")),
+		key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "$EDITOR")),
+		key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "spoilers")),
+		key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reveal")),
+	}
+}
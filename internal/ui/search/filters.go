@@ -0,0 +1,209 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/common"
+)
+
+// Filter form fields, in display order.
+const (
+	fieldYearFrom = iota
+	fieldYearTo
+	fieldPagesMin
+	fieldPagesMax
+	fieldMinRating
+	fieldGenre
+	fieldFormat
+	fieldCount
+)
+
+var fieldLabels = [fieldCount]string{
+	"Released from",
+	"Released to",
+	"Pages min",
+	"Pages max",
+	"Min rating",
+	"Genre",
+	"Format",
+}
+
+// filterForm edits api.SearchFilters. Format is cycled with left/right;
+// every other field is a text input.
+type filterForm struct {
+	inputs [fieldFormat]textinput.Model
+	format api.BookFormat
+	focus  int
+	err    error
+}
+
+func newFilterForm(f api.SearchFilters) *filterForm {
+	form := &filterForm{format: f.Format}
+	placeholders := [fieldFormat]string{"year", "year", "pages", "pages", "0-5", "e.g. Fantasy"}
+	values := [fieldFormat]string{
+		intValue(f.YearFrom), intValue(f.YearTo),
+		intValue(f.PagesMin), intValue(f.PagesMax),
+		"", f.Genre,
+	}
+	if f.MinRating > 0 {
+		values[fieldMinRating] = strconv.FormatFloat(f.MinRating, 'f', -1, 64)
+	}
+	for i := range form.inputs {
+		ti := textinput.New()
+		ti.Placeholder = placeholders[i]
+		ti.Width = 16
+		ti.CharLimit = 32
+		ti.Cursor.Style = common.CursorStyle
+		ti.SetValue(values[i])
+		form.inputs[i] = ti
+	}
+	form.inputs[0].Focus()
+	return form
+}
+
+func intValue(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// Update handles a key press. It returns done=true when the form should
+// close; apply reports whether the filters should be applied.
+func (f *filterForm) Update(msg tea.KeyMsg) (cmd tea.Cmd, done, apply bool) {
+	switch msg.String() {
+	case "esc":
+		return nil, true, false
+	case "enter":
+		if _, err := f.Filters(); err != nil {
+			f.err = err
+			return nil, false, false
+		}
+		return nil, true, true
+	case "tab", "down":
+		return f.setFocus((f.focus + 1) % fieldCount), false, false
+	case "shift+tab", "up":
+		return f.setFocus((f.focus + fieldCount - 1) % fieldCount), false, false
+	}
+
+	if f.focus == fieldFormat {
+		switch msg.String() {
+		case "left", "h":
+			f.format = (f.format + 2) % 3
+		case "right", "l", " ":
+			f.format = (f.format + 1) % 3
+		}
+		return nil, false, false
+	}
+
+	var c tea.Cmd
+	f.inputs[f.focus], c = f.inputs[f.focus].Update(msg)
+	return c, false, false
+}
+
+func (f *filterForm) setFocus(i int) tea.Cmd {
+	if f.focus < fieldFormat {
+		f.inputs[f.focus].Blur()
+	}
+	f.focus = i
+	if i < fieldFormat {
+		return f.inputs[i].Focus()
+	}
+	return nil
+}
+
+// Filters parses the form into api.SearchFilters.
+func (f *filterForm) Filters() (api.SearchFilters, error) {
+	var out api.SearchFilters
+	ints := []struct {
+		field int
+		dst   *int
+	}{
+		{fieldYearFrom, &out.YearFrom},
+		{fieldYearTo, &out.YearTo},
+		{fieldPagesMin, &out.PagesMin},
+		{fieldPagesMax, &out.PagesMax},
+	}
+	for _, in := range ints {
+		v := strings.TrimSpace(f.inputs[in.field].Value())
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return out, fmt.Errorf("%s must be a whole number", strings.ToLower(fieldLabels[in.field]))
+		}
+		*in.dst = n
+	}
+	if v := strings.TrimSpace(f.inputs[fieldMinRating].Value()); v != "" {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil || r < 0 || r > 5 {
+			return out, fmt.Errorf("min rating must be between 0 and 5")
+		}
+		out.MinRating = r
+	}
+	out.Genre = strings.TrimSpace(f.inputs[fieldGenre].Value())
+	out.Format = f.format
+	return out, nil
+}
+
+func (f *filterForm) View(w int) string {
+	var b strings.Builder
+	for i := 0; i < fieldCount; i++ {
+		label := fmt.Sprintf("%-14s", fieldLabels[i])
+		if i == f.focus {
+			b.WriteString(common.CursorStyle.Render("> ") + common.LabelStyle.Render(label))
+		} else {
+			b.WriteString("  " + common.ValueStyle.Render(label))
+		}
+		if i == fieldFormat {
+			b.WriteString(common.ValueStyle.Render("< " + f.format.String() + " >"))
+		} else {
+			b.WriteString(f.inputs[i].View())
+		}
+		b.WriteString("\n")
+	}
+	if f.err != nil {
+		b.WriteString("\n" + common.ErrorStyle.Render(f.err.Error()) + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(common.HelpStyle.Render("tab/↑↓: field | ←→: format | enter: apply | esc: cancel"))
+	return common.RenderActivePanel("Filters", b.String(), w)
+}
+
+// filterSummary describes the active filters in one line.
+func filterSummary(f api.SearchFilters) string {
+	var parts []string
+	switch {
+	case f.YearFrom > 0 && f.YearTo > 0:
+		parts = append(parts, fmt.Sprintf("%d-%d", f.YearFrom, f.YearTo))
+	case f.YearFrom > 0:
+		parts = append(parts, fmt.Sprintf("from %d", f.YearFrom))
+	case f.YearTo > 0:
+		parts = append(parts, fmt.Sprintf("until %d", f.YearTo))
+	}
+	switch {
+	case f.PagesMin > 0 && f.PagesMax > 0:
+		parts = append(parts, fmt.Sprintf("%d-%d pages", f.PagesMin, f.PagesMax))
+	case f.PagesMin > 0:
+		parts = append(parts, fmt.Sprintf("%d+ pages", f.PagesMin))
+	case f.PagesMax > 0:
+		parts = append(parts, fmt.Sprintf("≤%d pages", f.PagesMax))
+	}
+	if f.MinRating > 0 {
+		parts = append(parts, fmt.Sprintf("★ %.1f+", f.MinRating))
+	}
+	if f.Format != api.FormatAny {
+		parts = append(parts, f.Format.String())
+	}
+	if f.Genre != "" {
+		parts = append(parts, f.Genre)
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,106 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/storage"
+)
+
+const (
+	historyFile = "search_history.json"
+	maxRecent   = 15 // unpinned searches kept
+)
+
+// historyEntry is a remembered search.
+type historyEntry struct {
+	Query  string         `json:"query"`
+	Type   api.SearchType `json:"type"`
+	Pinned bool           `json:"pinned,omitempty"`
+	At     time.Time      `json:"at"`
+}
+
+// searchHistory holds recent and pinned searches, pinned first and then
+// most recent first.
+type searchHistory struct {
+	Entries []historyEntry `json:"entries"`
+}
+
+func loadHistory() searchHistory {
+	var h searchHistory
+	_ = storage.Load(historyFile, &h)
+	h.sort()
+	return h
+}
+
+func (h *searchHistory) save() {
+	_ = storage.Save(historyFile, h)
+}
+
+func (h *searchHistory) sort() {
+	sort.SliceStable(h.Entries, func(i, j int) bool {
+		a, b := h.Entries[i], h.Entries[j]
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		return a.At.After(b.At)
+	})
+}
+
+// add records a search, moving an existing entry for the same query and
+// type to the top of the recent searches.
+func (h *searchHistory) add(query string, t api.SearchType) {
+	entry := historyEntry{Query: query, Type: t, At: time.Now()}
+	for i, e := range h.Entries {
+		if strings.EqualFold(e.Query, query) && e.Type == t {
+			entry.Pinned = e.Pinned
+			h.Entries = append(h.Entries[:i], h.Entries[i+1:]...)
+			break
+		}
+	}
+	h.Entries = append(h.Entries, entry)
+	h.sort()
+
+	// Trim the oldest unpinned entries.
+	recent := 0
+	kept := h.Entries[:0]
+	for _, e := range h.Entries {
+		if !e.Pinned {
+			recent++
+			if recent > maxRecent {
+				continue
+			}
+		}
+		kept = append(kept, e)
+	}
+	h.Entries = kept
+	h.save()
+}
+
+// togglePin pins or unpins entry i and returns its new index.
+func (h *searchHistory) togglePin(i int) int {
+	if i < 0 || i >= len(h.Entries) {
+		return i
+	}
+	target := h.Entries[i]
+	h.Entries[i].Pinned = !h.Entries[i].Pinned
+	h.sort()
+	h.save()
+	for j, e := range h.Entries {
+		if e.Query == target.Query && e.Type == target.Type {
+			return j
+		}
+	}
+	return 0
+}
+
+// remove deletes entry i.
+func (h *searchHistory) remove(i int) {
+	if i < 0 || i >= len(h.Entries) {
+		return
+	}
+	h.Entries = append(h.Entries[:i], h.Entries[i+1:]...)
+	h.save()
+}
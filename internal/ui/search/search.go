@@ -3,6 +3,7 @@ package search
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -14,8 +15,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
-	"github.com/NotMugil/hardcover-tui/internal/api/queries"
 	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/service"
 )
 
 // NavigateToBookMsg signals the app to navigate to a book's detail view.
@@ -25,19 +26,43 @@ type NavigateToBookMsg struct {
 }
 
 type searchResultsMsg struct {
-	books []api.Book
+	page  *api.SearchPage
+	query string
 	err   error
 }
 
+// searchSettledMsg fires once typing has paused long enough to search.
+type searchSettledMsg struct {
+	query string
+}
+
+// debounceDelay is how long typing must pause before a search is sent.
+const debounceDelay = 300 * time.Millisecond
+
+// minQueryLen is the shortest query searched as you type.
+const minQueryLen = 2
+
 // Model is the search screen model.
 type Model struct {
-	client       *api.Client
+	svc          service.HardcoverService
 	user         *api.User
 	textInput    textinput.Model
+	queryType    api.SearchType
+	query        string // query the loaded pages belong to
+	pages        map[int]*api.SearchPage
+	page         int // page currently shown
 	results      []api.Book
+	others       []api.SearchResult
+	filters      api.SearchFilters
+	filterForm   *filterForm // non-nil while editing filters
 	table        table.Model
 	spinner      spinner.Model
 	searching    bool
+	submitted    bool               // current search was submitted with enter
+	cancel       context.CancelFunc // cancels the in-flight search
+	history      searchHistory
+	historyIdx   int
+	restored     string // query to search for on Init, from Restore
 	inputFocused bool
 	tableFocused bool
 	err          error
@@ -46,7 +71,7 @@ type Model struct {
 }
 
 // New creates a new search screen.
-func New(client *api.Client, user *api.User) *Model {
+func New(svc service.HardcoverService, user *api.User) *Model {
 	ti := textinput.New()
 	ti.Placeholder = "Search books..."
 	ti.Width = 50
@@ -60,9 +85,13 @@ func New(client *api.Client, user *api.User) *Model {
 	t := newSearchTable(80, 15)
 
 	return &Model{
-		client:    client,
+		svc:       svc,
 		user:      user,
 		textInput: ti,
+		queryType: api.SearchBooks,
+		pages:     map[int]*api.SearchPage{},
+		page:      1,
+		history:   loadHistory(),
 		table:     t,
 		spinner:   s,
 	}
@@ -70,7 +99,7 @@ func New(client *api.Client, user *api.User) *Model {
 
 // newSearchTable creates a styled table for search results.
 func newSearchTable(width, height int) table.Model {
-	columns := tableColumns(width)
+	columns := tableColumns(api.SearchBooks, width)
 
 	t := table.New(
 		table.WithColumns(columns),
@@ -97,12 +126,51 @@ func newSearchTable(width, height int) table.Model {
 	return t
 }
 
-// tableColumns returns table column definitions scaled to the given width.
-func tableColumns(width int) []table.Column {
+// tableColumns returns table column definitions for a query type, scaled
+// to the given width.
+func tableColumns(t api.SearchType, width int) []table.Column {
 	usable := width - 12
 	if usable < 40 {
 		usable = 40
 	}
+
+	switch t {
+	case api.SearchAuthors:
+		nameW := usable * 70 / 100
+		return []table.Column{
+			{Title: "Author", Width: nameW},
+			{Title: "Books", Width: usable - nameW},
+		}
+	case api.SearchSeries:
+		nameW := usable * 50 / 100
+		authorW := usable * 35 / 100
+		return []table.Column{
+			{Title: "Series", Width: nameW},
+			{Title: "Author", Width: authorW},
+			{Title: "Books", Width: usable - nameW - authorW},
+		}
+	case api.SearchLists:
+		nameW := usable * 50 / 100
+		ownerW := usable * 25 / 100
+		booksW := usable * 12 / 100
+		return []table.Column{
+			{Title: "List", Width: nameW},
+			{Title: "Owner", Width: ownerW},
+			{Title: "Books", Width: booksW},
+			{Title: "Likes", Width: usable - nameW - ownerW - booksW},
+		}
+	case api.SearchUsers:
+		userW := usable * 35 / 100
+		nameW := usable * 35 / 100
+		booksW := usable * 15 / 100
+		return []table.Column{
+			{Title: "Username", Width: userW},
+			{Title: "Name", Width: nameW},
+			{Title: "Books", Width: booksW},
+			{Title: "Followers", Width: usable - userW - nameW - booksW},
+		}
+	}
+
 	titleW := usable * 30 / 100
 	authorW := usable * 25 / 100
 	formatW := usable * 10 / 100
@@ -125,44 +193,252 @@ func (m *Model) SetSize(w, h int) {
 	m.width = w
 	m.height = h
 	contentW := w - 4
-	contentH := h - 10
+	contentH := h - 12
 	if contentW > 0 && contentH > 0 {
-		m.table.SetColumns(tableColumns(contentW))
+		m.setColumns(contentW)
 		m.table.SetHeight(contentH)
 	}
 }
 
+// setColumns swaps the table columns. Rows from another query type are
+// dropped first so a row never has fewer cells than the new column set.
+func (m *Model) setColumns(width int) {
+	cols := tableColumns(m.queryType, width)
+	if rows := m.table.Rows(); len(rows) > 0 && len(rows[0]) != len(cols) {
+		m.table.SetRows(nil)
+	}
+	m.table.SetColumns(cols)
+}
+
 func (m *Model) Init() tea.Cmd {
+	if q := m.restored; q != "" {
+		m.restored = ""
+		return m.startSearch(q, false)
+	}
 	return nil
 }
 
+// State is the part of the search screen kept between sessions.
+type State struct {
+	Query string         `json:"query,omitempty"`
+	Type  api.SearchType `json:"type,omitempty"`
+}
+
+// State returns the current query and query type.
+func (m *Model) State() State {
+	return State{Query: m.query, Type: m.queryType}
+}
+
+// Restore fills in a saved query and type. The query is searched again
+// when the screen starts.
+func (m *Model) Restore(s State) {
+	if slices.Contains(api.AllSearchTypes(), s.Type) {
+		m.queryType = s.Type
+		m.textInput.Placeholder = fmt.Sprintf("Search %s...", typeLabel(m.queryType))
+	}
+	m.textInput.SetValue(s.Query)
+	m.textInput.CursorEnd()
+	m.restored = strings.TrimSpace(s.Query)
+}
+
 // Loaded returns true immediately — search loads on demand.
 func (m *Model) Loaded() bool {
 	return true
 }
 
 // InputFocused returns true when the screen is handling its own key input
-// (search input focused, or actively navigating the results table).
+// (search input focused, filters open, or navigating the results table).
 func (m *Model) InputFocused() bool {
-	return m.inputFocused || m.tableFocused
+	return m.inputFocused || m.tableFocused || m.filterForm != nil
+}
+
+// currentPage returns the loaded page being shown, if any.
+func (m *Model) currentPage() *api.SearchPage {
+	return m.pages[m.page]
+}
+
+// showPage fills the table from a loaded page, applying book filters.
+func (m *Model) showPage(n int) {
+	m.page = n
+	p := m.pages[n]
+	m.results = nil
+	m.others = nil
+	if p == nil {
+		m.table.SetRows(nil)
+		return
+	}
+	if p.Type == api.SearchBooks {
+		for _, b := range p.Books {
+			if m.filters.Matches(b) {
+				m.results = append(m.results, b)
+			}
+		}
+		m.table.SetRows(booksToRows(m.results))
+	} else {
+		m.others = p.Results
+		m.table.SetRows(resultsToRows(p.Type, m.others))
+	}
+	m.table.SetCursor(0)
+}
+
+// gotoPage shows page n, fetching it first if it has not been loaded.
+func (m *Model) gotoPage(n int) tea.Cmd {
+	if n < 1 || m.query == "" {
+		return nil
+	}
+	if p := m.currentPage(); p != nil && n > p.TotalPages() {
+		return nil
+	}
+	if _, ok := m.pages[n]; ok {
+		m.showPage(n)
+		return nil
+	}
+	m.searching = true
+	m.err = nil
+	return tea.Batch(m.spinner.Tick, m.doSearch(m.query, m.queryType, n))
+}
+
+// startSearch runs a fresh search for query with the current type.
+// submitted is true when the user pressed enter, which also records the
+// search in the history and moves focus to the results.
+func (m *Model) startSearch(query string, submitted bool) tea.Cmd {
+	m.query = query
+	m.pages = map[int]*api.SearchPage{}
+	m.page = 1
+	m.searching = true
+	m.submitted = submitted
+	m.err = nil
+	if submitted {
+		m.history.add(query, m.queryType)
+		m.historyIdx = 0
+	}
+	return tea.Batch(m.spinner.Tick, m.doSearch(query, m.queryType, 1))
+}
+
+// scheduleSearch waits for typing to pause before searching. If the user
+// keeps typing, only the last query is searched.
+func (m *Model) scheduleSearch() tea.Cmd {
+	q := strings.TrimSpace(m.textInput.Value())
+	return tea.Tick(debounceDelay, func(time.Time) tea.Msg {
+		return searchSettledMsg{query: q}
+	})
+}
+
+// clearSearch cancels any in-flight search and returns to the empty screen.
+func (m *Model) clearSearch() {
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+	m.query = ""
+	m.pages = map[int]*api.SearchPage{}
+	m.page = 1
+	m.results = nil
+	m.others = nil
+	m.searching = false
+	m.err = nil
+	m.table.SetRows(nil)
+}
+
+// runHistory re-runs the selected history entry.
+func (m *Model) runHistory() tea.Cmd {
+	if m.historyIdx < 0 || m.historyIdx >= len(m.history.Entries) {
+		return nil
+	}
+	e := m.history.Entries[m.historyIdx]
+	if e.Type != "" && e.Type != m.queryType {
+		m.queryType = e.Type
+		m.textInput.Placeholder = fmt.Sprintf("Search %s...", typeLabel(m.queryType))
+		m.table.SetRows(nil)
+		m.setColumns(m.tableWidth())
+	}
+	m.textInput.SetValue(e.Query)
+	m.textInput.CursorEnd()
+	return m.startSearch(e.Query, true)
+}
+
+// cycleType switches to the next or previous query type and re-runs the
+// current query.
+func (m *Model) cycleType(delta int) tea.Cmd {
+	types := api.AllSearchTypes()
+	idx := 0
+	for i, t := range types {
+		if t == m.queryType {
+			idx = i
+		}
+	}
+	m.queryType = types[(idx+delta+len(types))%len(types)]
+	m.textInput.Placeholder = fmt.Sprintf("Search %s...", typeLabel(m.queryType))
+	m.results = nil
+	m.others = nil
+	m.pages = map[int]*api.SearchPage{}
+	m.table.SetRows(nil)
+	m.setColumns(m.tableWidth())
+	if m.query == "" {
+		return nil
+	}
+	return m.startSearch(m.query, m.submitted)
+}
+
+// typeLabel returns the plural, lower-case label for a query type.
+func typeLabel(t api.SearchType) string {
+	switch t {
+	case api.SearchAuthors:
+		return "authors"
+	case api.SearchSeries:
+		return "series"
+	case api.SearchLists:
+		return "lists"
+	case api.SearchUsers:
+		return "users"
+	default:
+		return "books"
+	}
+}
+
+func (m *Model) rowCount() int {
+	if m.queryType == api.SearchBooks {
+		return len(m.results)
+	}
+	return len(m.others)
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case searchResultsMsg:
+		// Ignore responses for a query or type that has since changed.
+		if msg.query != m.query || (msg.page != nil && msg.page.Type != m.queryType) {
+			return m, nil
+		}
 		m.searching = false
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
-		m.results = msg.books
-		m.table.SetRows(booksToRows(m.results))
-		m.inputFocused = false
-		m.tableFocused = true
-		m.textInput.Blur()
-		m.table.Focus()
+		m.pages[msg.page.Page] = msg.page
+		m.showPage(msg.page.Page)
+		// Results for a query still being typed keep focus in the input.
+		if m.submitted || !m.inputFocused {
+			m.inputFocused = false
+			m.tableFocused = true
+			m.textInput.Blur()
+			m.table.Focus()
+		}
 		return m, nil
 
+	case searchSettledMsg:
+		if msg.query != strings.TrimSpace(m.textInput.Value()) {
+			return m, nil
+		}
+		if msg.query == "" {
+			m.clearSearch()
+			return m, nil
+		}
+		if len([]rune(msg.query)) < minQueryLen || msg.query == m.query {
+			return m, nil
+		}
+		return m, m.startSearch(msg.query, false)
+
 	case spinner.TickMsg:
 		if m.searching {
 			var cmd tea.Cmd
@@ -171,47 +447,125 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.KeyMsg:
+		if m.filterForm != nil {
+			cmd, done, apply := m.filterForm.Update(msg)
+			if done {
+				if apply {
+					m.filters, _ = m.filterForm.Filters()
+					m.showPage(m.page)
+				}
+				m.filterForm = nil
+			}
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "tab":
+			return m, m.cycleType(1)
+		case "shift+tab":
+			return m, m.cycleType(-1)
+		}
+
 		if m.inputFocused {
+			empty := strings.TrimSpace(m.textInput.Value()) == ""
 			switch msg.String() {
 			case "enter":
+				if empty {
+					return m, m.runHistory()
+				}
 				query := strings.TrimSpace(m.textInput.Value())
-				if query == "" {
+				if query == m.query && !m.searching && m.currentPage() != nil {
+					// Already showing results for this query; just record it.
+					m.history.add(query, m.queryType)
+					m.submitted = true
+					m.inputFocused = false
+					m.tableFocused = true
+					m.textInput.Blur()
+					m.table.Focus()
+					return m, nil
+				}
+				return m, m.startSearch(query, true)
+			case "up", "down":
+				if empty {
+					m.moveHistory(msg.String() == "down")
 					return m, nil
 				}
-				m.searching = true
-				m.err = nil
-				return m, tea.Batch(m.spinner.Tick, m.doSearch(query))
 			case "esc":
 				m.inputFocused = false
 				m.textInput.Blur()
-				if len(m.results) > 0 {
+				if m.rowCount() > 0 {
 					m.table.Focus()
 				}
 				return m, nil
 			}
+			prev := m.textInput.Value()
 			var cmd tea.Cmd
 			m.textInput, cmd = m.textInput.Update(msg)
+			if m.textInput.Value() != prev {
+				return m, tea.Batch(cmd, m.scheduleSearch())
+			}
 			return m, cmd
 		}
 
-		switch strings.ToLower(msg.String()) {
-		case "enter":
-			row := m.table.SelectedRow()
-			if row != nil {
-				idx := m.table.Cursor()
-				if idx >= 0 && idx < len(m.results) {
-					book := m.results[idx]
-					return m, func() tea.Msg {
-						return NavigateToBookMsg{BookID: book.ID, Genres: book.Genres}
-					}
+		if m.searching {
+			return m, nil
+		}
+
+		if m.query == "" && len(m.history.Entries) > 0 {
+			switch strings.ToLower(msg.String()) {
+			case "j", "down":
+				m.moveHistory(true)
+				return m, nil
+			case "k", "up":
+				m.moveHistory(false)
+				return m, nil
+			case "enter":
+				return m, m.runHistory()
+			case "s":
+				m.historyIdx = m.history.togglePin(m.historyIdx)
+				return m, nil
+			case "x":
+				m.history.remove(m.historyIdx)
+				if m.historyIdx >= len(m.history.Entries) {
+					m.historyIdx = max(len(m.history.Entries)-1, 0)
 				}
+				return m, nil
 			}
+		}
+
+		if cmd, ok := common.HandleLinkKey(msg, m); ok {
+			return m, cmd
+		}
+
+		switch strings.ToLower(msg.String()) {
+		case "enter":
+			return m, m.openSelected()
 		case "/":
 			m.inputFocused = true
 			m.tableFocused = false
 			m.textInput.Focus()
 			m.table.Blur()
 			return m, textinput.Blink
+		case "n", "pgdown":
+			return m, m.gotoPage(m.page + 1)
+		case "p", "pgup":
+			return m, m.gotoPage(m.page - 1)
+		case "down", "j":
+			// Scrolling past the last row loads the next page.
+			if m.rowCount() == 0 || m.table.Cursor() >= m.rowCount()-1 {
+				return m, m.gotoPage(m.page + 1)
+			}
+		case "f":
+			if m.queryType == api.SearchBooks {
+				m.filterForm = newFilterForm(m.filters)
+				return m, textinput.Blink
+			}
+		case "c":
+			if m.filters.Active() {
+				m.filters = api.SearchFilters{}
+				m.showPage(m.page)
+				return m, nil
+			}
 		case "esc":
 			m.tableFocused = false
 			m.table.Blur()
@@ -226,13 +580,102 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *Model) doSearch(query string) tea.Cmd {
-	client := m.client
+// openSelected opens the selected result. Books open their detail view;
+// authors and series run a book search for their name; lists and users
+// offer to open their page on the website.
+func (m *Model) openSelected() tea.Cmd {
+	idx := m.table.Cursor()
+	if m.queryType == api.SearchBooks {
+		if idx < 0 || idx >= len(m.results) {
+			return nil
+		}
+		book := m.results[idx]
+		return func() tea.Msg {
+			return NavigateToBookMsg{BookID: book.ID, Genres: book.Genres}
+		}
+	}
+	if idx < 0 || idx >= len(m.others) {
+		return nil
+	}
+	r := m.others[idx]
+	switch m.queryType {
+	case api.SearchAuthors, api.SearchSeries:
+		m.queryType = api.SearchBooks
+		m.textInput.Placeholder = "Search books..."
+		m.textInput.SetValue(r.Name)
+		m.results = nil
+		m.others = nil
+		m.table.SetRows(nil)
+		m.setColumns(m.tableWidth())
+		return m.startSearch(r.Name, true)
+	case api.SearchLists, api.SearchUsers:
+		if links := m.Links(); len(links) > 0 {
+			return common.LinkCmd(links[:1], false)
+		}
+	}
+	return nil
+}
+
+// Links returns the page of the selected result, and for books and lists
+// those of their authors and owner.
+func (m *Model) Links() []common.Link {
+	idx := m.table.Cursor()
+	if m.queryType == api.SearchBooks {
+		if idx < 0 || idx >= len(m.results) {
+			return nil
+		}
+		return common.BookLinks(m.results[idx])
+	}
+	if idx < 0 || idx >= len(m.others) || m.others[idx].Slug == "" {
+		return nil
+	}
+	r := m.others[idx]
+	switch m.queryType {
+	case api.SearchAuthors:
+		return []common.Link{common.AuthorLink(r.Name, r.Slug)}
+	case api.SearchSeries:
+		return []common.Link{common.SeriesLink(r.Name, r.Slug)}
+	case api.SearchLists:
+		owner := strings.TrimPrefix(r.Detail, "@")
+		if owner == "" {
+			return nil
+		}
+		return []common.Link{common.ListLink(owner, r.Name, r.Slug), common.UserLink(owner)}
+	case api.SearchUsers:
+		return []common.Link{common.UserLink(r.Slug)}
+	}
+	return nil
+}
+
+// moveHistory moves the history selection down or up, wrapping around.
+func (m *Model) moveHistory(down bool) {
+	n := len(m.history.Entries)
+	if n == 0 {
+		return
+	}
+	if down {
+		m.historyIdx = (m.historyIdx + 1) % n
+	} else {
+		m.historyIdx = (m.historyIdx - 1 + n) % n
+	}
+}
+
+// doSearch fetches a page of results. Any search still in flight is
+// cancelled first so a slow response can't replace newer results.
+func (m *Model) doSearch(query string, queryType api.SearchType, page int) tea.Cmd {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	m.cancel = cancel
+	svc := m.svc
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		books, err := queries.Search(ctx, client, query)
-		return searchResultsMsg{books: books, err: err}
+		result, err := svc.SearchPage(ctx, query, queryType, page)
+		if ctx.Err() == context.Canceled {
+			return nil
+		}
+		return searchResultsMsg{page: result, query: query, err: err}
 	}
 }
 
@@ -257,9 +700,75 @@ func booksToRows(books []api.Book) []table.Row {
 	return rows
 }
 
+// resultsToRows converts non-book results to table rows for their type.
+func resultsToRows(t api.SearchType, results []api.SearchResult) []table.Row {
+	rows := make([]table.Row, len(results))
+	for i, r := range results {
+		books := fmt.Sprintf("%d", r.BooksCount)
+		switch t {
+		case api.SearchAuthors:
+			rows[i] = table.Row{r.Name, books}
+		case api.SearchSeries:
+			rows[i] = table.Row{r.Name, r.Detail, books}
+		case api.SearchLists:
+			rows[i] = table.Row{r.Name, r.Detail, books, fmt.Sprintf("%d", r.Count)}
+		case api.SearchUsers:
+			rows[i] = table.Row{"@" + r.Name, r.Detail, books, fmt.Sprintf("%d", r.Count)}
+		}
+	}
+	return rows
+}
+
+func (m *Model) tableWidth() int {
+	tableW := m.panelWidth() - 4
+	if tableW < 40 {
+		tableW = 40
+	}
+	return tableW
+}
+
+func (m *Model) panelWidth() int {
+	panelW := m.width - 2
+	if panelW < 40 {
+		panelW = 80
+	}
+	return panelW
+}
+
+// renderTypeTabs renders the query type selector.
+func (m *Model) renderTypeTabs() string {
+	var tabs []string
+	for _, t := range api.AllSearchTypes() {
+		label := string(t)
+		if t == m.queryType {
+			tabs = append(tabs, common.ActiveTabStyle.Render(label))
+		} else {
+			tabs = append(tabs, common.InactiveTabStyle.Render(label))
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
+
+// summary describes the result count, page and filter state.
+func (m *Model) summary() string {
+	p := m.currentPage()
+	if p == nil {
+		return ""
+	}
+	parts := []string{fmt.Sprintf("%d %s found", p.Found, typeLabel(p.Type))}
+	parts = append(parts, fmt.Sprintf("page %d of %d", p.Page, p.TotalPages()))
+	if p.Type == api.SearchBooks && m.filters.Active() {
+		parts = append(parts, fmt.Sprintf("%d of %d shown (%s)", len(m.results), len(p.Books), filterSummary(m.filters)))
+	}
+	return strings.Join(parts, " · ")
+}
+
 func (m *Model) View() string {
 	var b strings.Builder
 
+	b.WriteString(m.renderTypeTabs())
+	b.WriteString("\n\n")
+
 	if m.inputFocused {
 		b.WriteString(common.FocusedBorderStyle.Render(m.textInput.View()))
 	} else {
@@ -267,63 +776,113 @@ func (m *Model) View() string {
 	}
 	b.WriteString("\n\n")
 
-	if m.searching {
-		b.WriteString(fmt.Sprintf("  %s Searching...\n", m.spinner.View()))
-		return common.AppStyle.Render(b.String())
-	}
-
 	if m.err != nil {
 		b.WriteString(common.ErrorStyle.Render("Error: " + m.err.Error()))
 		b.WriteString("\n\n")
 	}
 
-	if len(m.results) > 0 {
-		panelW := m.width - 2
-		if panelW < 40 {
-			panelW = 80
-		}
-		tableW := panelW - 4
-		if tableW < 40 {
-			tableW = 40
+	panelW := m.panelWidth()
+	tableW := m.tableWidth()
+
+	if m.filterForm != nil {
+		b.WriteString(m.filterForm.View(min(panelW, 60)))
+		return common.AppStyle.Render(b.String())
+	}
+
+	if m.query == "" && !m.searching {
+		b.WriteString(m.renderHistory(panelW))
+		return common.AppStyle.Render(b.String())
+	}
+
+	m.setColumns(tableW)
+	m.table.SetWidth(tableW)
+	tableView := lipgloss.NewStyle().Width(tableW).Render(m.table.View())
+
+	title := "Results"
+	if m.currentPage() != nil {
+		title = fmt.Sprintf("Results (%d)", m.rowCount())
+	}
+	if m.searching {
+		title += " " + m.spinner.View()
+	}
+	content := tableView
+	if s := m.summary(); s != "" {
+		content += "\n" + common.HelpStyle.Render(s)
+	}
+	if m.currentPage() != nil && m.rowCount() == 0 && m.filters.Active() {
+		content += "\n" + common.ValueStyle.Render("No results on this page match the filters (n: next page, c: clear)")
+	}
+	b.WriteString(common.RenderPanel(title, content, panelW))
+
+	return common.AppStyle.Render(b.String())
+}
+
+// renderHistory renders the recent and pinned searches shown before a
+// query is typed.
+func (m *Model) renderHistory(w int) string {
+	if len(m.history.Entries) == 0 {
+		return common.RenderPanel("Recent searches",
+			common.HelpStyle.Render("Type to search. Searches you submit are remembered here."), w)
+	}
+	var b strings.Builder
+	for i, e := range m.history.Entries {
+		mark := "  "
+		if e.Pinned {
+			mark = "★ "
 		}
-		m.table.SetColumns(tableColumns(tableW))
-		m.table.SetWidth(tableW)
-		tableView := lipgloss.NewStyle().Width(tableW).Render(m.table.View())
-		b.WriteString(common.RenderPanel(
-			fmt.Sprintf("Results (%d)", len(m.results)),
-			tableView, panelW))
-	} else {
-		panelW := m.width - 2
-		if panelW < 40 {
-			panelW = 80
+		line := mark + e.Query
+		if e.Type != "" && e.Type != api.SearchBooks {
+			line += common.HelpStyle.Render(" · " + typeLabel(e.Type))
 		}
-		tableW := panelW - 4
-		if tableW < 40 {
-			tableW = 40
+		if i == m.historyIdx {
+			b.WriteString(common.CursorStyle.Render("> ") + common.LabelStyle.Render(line))
+		} else {
+			b.WriteString("  " + common.ValueStyle.Render(line))
 		}
-		m.table.SetColumns(tableColumns(tableW))
-		m.table.SetWidth(tableW)
-		m.table.SetRows([]table.Row{})
-		tableView := lipgloss.NewStyle().Width(tableW).Render(m.table.View())
-		b.WriteString(common.RenderPanel("Results", tableView, panelW))
+		b.WriteString("\n")
 	}
-
-	return common.AppStyle.Render(b.String())
+	b.WriteString("\n" + common.HelpStyle.Render("enter: run | s: pin | x: remove"))
+	return common.RenderPanel("Recent searches", b.String(), w)
 }
 
 // HelpBindings returns page-specific keybindings for the global help bar.
 func (m *Model) HelpBindings() []key.Binding {
+	if m.filterForm != nil {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "apply")),
+			key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		}
+	}
 	if m.inputFocused {
 		return []key.Binding{
 			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "search")),
+			key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "type")),
 		}
 	}
 	bindings := []key.Binding{
 		key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "focus input")),
+		key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "type")),
+	}
+	if m.query == "" && len(m.history.Entries) > 0 {
+		bindings = append(bindings,
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "run")),
+			key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "pin")),
+			key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "remove")),
+		)
+	}
+	if m.query != "" {
+		bindings = append(bindings,
+			key.NewBinding(key.WithKeys("n"), key.WithHelp("n/p", "page")),
+		)
+	}
+	if m.queryType == api.SearchBooks {
+		bindings = append(bindings,
+			key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filters")),
+		)
 	}
-	if len(m.results) > 0 {
+	if m.rowCount() > 0 {
 		bindings = append(bindings,
-			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open book")),
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open")),
 		)
 	}
 	return bindings
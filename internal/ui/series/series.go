@@ -0,0 +1,307 @@
+package series
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/service"
+)
+
+// NavigateToBookMsg signals the app to navigate to a book's detail view.
+type NavigateToBookMsg struct {
+	BookID int
+	Title  string
+}
+
+type seriesLoadedMsg struct {
+	series *api.SeriesDetail
+	err    error
+}
+
+// Model is the series screen. It lists a series' numbered books in order
+// with the user's status for each and marks the next one to read.
+type Model struct {
+	svc      service.HardcoverService
+	user     *api.User
+	seriesID int
+	bookID   int // book to select once loaded, 0 for the next to read
+	series   *api.SeriesDetail
+	cursor   int
+	top      int // first visible line
+	spinner  spinner.Model
+	loading  bool
+	err      error
+	width    int
+	height   int
+}
+
+// New creates a series screen that selects bookID, or the next book to
+// read when bookID is 0 or not in the series.
+func New(svc service.HardcoverService, user *api.User, seriesID, bookID int) *Model {
+	s := spinner.New(
+		spinner.WithSpinner(spinner.Dot),
+		spinner.WithStyle(common.SpinnerStyle),
+	)
+	return &Model{
+		svc:      svc,
+		user:     user,
+		seriesID: seriesID,
+		bookID:   bookID,
+		spinner:  s,
+		loading:  true,
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	m.loading = true
+	m.err = nil
+	return tea.Batch(m.spinner.Tick, m.loadSeries())
+}
+
+// SetSize updates the available terminal dimensions.
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Loaded reports whether the series has arrived.
+func (m *Model) Loaded() bool {
+	return !m.loading
+}
+
+// InputFocused always returns false; the screen has no text input.
+func (m *Model) InputFocused() bool {
+	return false
+}
+
+func (m *Model) loadSeries() tea.Cmd {
+	svc := m.svc
+	userID := m.user.ID
+	seriesID := m.seriesID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		s, err := svc.GetSeries(ctx, seriesID, userID)
+		return seriesLoadedMsg{series: s, err: err}
+	}
+}
+
+// selectBook moves the cursor to the book the screen was opened for, or
+// to the next book to read. A reload keeps the book under the cursor.
+func (m *Model) selectBook(prev *api.SeriesDetail) {
+	bookID := m.bookID
+	if prev != nil && m.cursor < len(prev.Entries) {
+		bookID = prev.Entries[m.cursor].Book.ID
+	}
+	m.cursor = 0
+	if i := m.indexOf(bookID); i >= 0 {
+		m.cursor = i
+	} else if next, ok := m.series.Next(); ok {
+		m.cursor = max(m.indexOf(next.Book.ID), 0)
+	}
+}
+
+// indexOf returns the index of bookID among the series' books, or -1.
+func (m *Model) indexOf(bookID int) int {
+	for i, e := range m.series.Entries {
+		if e.Book.ID == bookID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case seriesLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		prev := m.series
+		m.series = msg.series
+		m.selectBook(prev)
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+		switch msg.String() {
+		case "r":
+			return m, m.Init()
+		}
+		if m.series == nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "j", "down":
+			if m.cursor < len(m.series.Entries)-1 {
+				m.cursor++
+			}
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "home", "g":
+			m.cursor = 0
+		case "end", "G":
+			m.cursor = max(len(m.series.Entries)-1, 0)
+		case "enter":
+			if m.cursor < len(m.series.Entries) {
+				b := m.series.Entries[m.cursor].Book
+				return m, func() tea.Msg {
+					return NavigateToBookMsg{BookID: b.ID, Title: b.Title}
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// statusLabel names the user's status for a book of the series.
+func statusLabel(statusID int) string {
+	if statusID == 0 {
+		return "Not in library"
+	}
+	return api.StatusID(statusID).String()
+}
+
+// renderLines renders every book of the series, two lines each, and
+// returns the lines together with the first line of the selected book.
+func (m *Model) renderLines(width int) (lines []string, curStart int) {
+	selected := lipgloss.NewStyle().Foreground(common.ColorPrimary).Bold(true)
+	next, hasNext := m.series.Next()
+	for i, e := range m.series.Entries {
+		sq := lipgloss.NewStyle().Foreground(common.StatusColor(e.StatusID)).Bold(true).Render("■")
+		title := common.LabelStyle.Render(fmt.Sprintf("#%-4s", api.FormatPosition(e.Position))) +
+			sq + " " + common.ValueStyle.Render(e.Book.Title)
+		if hasNext && e.Book.ID == next.Book.ID {
+			title += common.SuccessStyle.Render("  next")
+		}
+		title = lipgloss.NewStyle().MaxWidth(width - 2).Render(title)
+
+		if i == m.cursor {
+			curStart = len(lines)
+			lines = append(lines, selected.Render("▌ ")+title)
+		} else {
+			lines = append(lines, "  "+title)
+		}
+
+		desc := []string{statusLabel(e.StatusID)}
+		if a := e.Book.Authors(); a != "" {
+			desc = append(desc, "by "+a)
+		}
+		if e.Book.ReleaseYear != nil {
+			desc = append(desc, fmt.Sprint(*e.Book.ReleaseYear))
+		}
+		d := lipgloss.NewStyle().MaxWidth(width - 8).Render(strings.Join(desc, " · "))
+		lines = append(lines, "        "+common.HelpStyle.Render(d))
+	}
+	return lines, curStart
+}
+
+func (m *Model) View() string {
+	if m.loading {
+		return common.AppStyle.Render(
+			fmt.Sprintf("\n  %s Loading series...\n", m.spinner.View()),
+		)
+	}
+	if m.series == nil {
+		msg := "Series not found"
+		if m.err != nil {
+			msg = "Error: " + m.err.Error()
+		}
+		return common.AppStyle.Render(common.ErrorStyle.Render(msg))
+	}
+
+	w := m.width - 8
+	if w < 40 {
+		w = 76
+	}
+
+	var b strings.Builder
+	b.WriteString(common.TitleStyle.Render(m.series.Name))
+	b.WriteString("\n")
+	read, count := m.series.Read(), len(m.series.Entries)
+	summary := fmt.Sprintf("%d of %d read", read, count)
+	if total := m.series.Total(); total > count {
+		summary += fmt.Sprintf(" · %d books in the series", total)
+	}
+	pct := 0.0
+	if count > 0 {
+		pct = float64(read) / float64(count)
+	}
+	b.WriteString(common.RenderBar(pct, min(w/3, 30), summary))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString(common.ErrorStyle.Render("Error: "+m.err.Error()) + "\n\n")
+	}
+
+	var body string
+	if len(m.series.Entries) == 0 {
+		body = common.ValueStyle.Render("This series has no numbered books.")
+	} else {
+		lines, curStart := m.renderLines(w)
+		visible := m.height - lipgloss.Height(b.String()) - 6
+		if visible < 4 {
+			visible = 4
+		}
+		if curStart < m.top {
+			m.top = curStart
+		}
+		if curStart+1 >= m.top+visible {
+			m.top = curStart + 2 - visible
+		}
+		if m.top > len(lines)-visible {
+			m.top = max(len(lines)-visible, 0)
+		}
+		end := min(m.top+visible, len(lines))
+		body = strings.Join(lines[m.top:end], "\n")
+	}
+
+	b.WriteString(common.RenderActivePanel("Books", body, w+4))
+	return common.AppStyle.Render(b.String())
+}
+
+// Links returns the pages of the selected book and its authors, then of
+// the series.
+func (m *Model) Links() []common.Link {
+	if m.series == nil {
+		return nil
+	}
+	var links []common.Link
+	if m.cursor < len(m.series.Entries) {
+		links = common.BookLinks(m.series.Entries[m.cursor].Book)
+	}
+	if m.series.Slug != "" {
+		links = append(links, common.SeriesLink(m.series.Name, m.series.Slug))
+	}
+	return links
+}
+
+// HelpBindings returns page-specific keybindings for the global help bar.
+func (m *Model) HelpBindings() []key.Binding {
+	return []key.Binding{
+		key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open book")),
+		key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+	}
+}
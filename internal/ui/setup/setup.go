@@ -12,9 +12,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
-	"github.com/NotMugil/hardcover-tui/internal/api/queries"
 	"github.com/NotMugil/hardcover-tui/internal/common"
 	"github.com/NotMugil/hardcover-tui/internal/keystore"
+	"github.com/NotMugil/hardcover-tui/internal/service"
 )
 
 // SetupCompleteMsg is sent when the user has successfully authenticated.
@@ -22,6 +22,10 @@ type SetupCompleteMsg struct {
 	Token string
 }
 
+// ReauthCancelledMsg is sent when the user dismisses the re-authentication
+// prompt without entering a new token.
+type ReauthCancelledMsg struct{}
+
 type state int
 
 const (
@@ -42,6 +46,8 @@ type Model struct {
 	state     state
 	err       error
 	token     string
+	reauth    bool // prompting for a new token after the old one stopped working
+	noSave    bool // keep the token out of the keyring
 	width     int
 	height    int
 }
@@ -69,6 +75,22 @@ func New() *Model {
 	}
 }
 
+// NewReauth creates a setup screen that asks for a new token after the
+// saved one was rejected. It renders as a compact panel for use in an
+// overlay, and esc dismisses it.
+func NewReauth() *Model {
+	m := New()
+	m.reauth = true
+	return m
+}
+
+// WithoutSaving makes the screen hand back the token without writing it
+// to the keyring, for sessions started with a token of their own.
+func (m *Model) WithoutSaving() *Model {
+	m.noSave = true
+	return m
+}
+
 func (m *Model) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -90,6 +112,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "ctrl+c":
 			return m, tea.Quit
+		case "esc":
+			if m.reauth && m.state != stateValidating {
+				return m, func() tea.Msg { return ReauthCancelledMsg{} }
+			}
 		case "enter":
 			if m.state == stateInput {
 				token := strings.TrimSpace(m.textInput.Value())
@@ -115,10 +141,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		if err := keystore.Save(m.token); err != nil {
-			m.state = stateError
-			m.err = fmt.Errorf("failed to save key: %w", err)
-			return m, nil
+		if !m.noSave {
+			if err := keystore.Save(m.token); err != nil {
+				m.state = stateError
+				m.err = fmt.Errorf("failed to save key: %w", err)
+				return m, nil
+			}
 		}
 		return m, func() tea.Msg {
 			return SetupCompleteMsg{Token: m.token}
@@ -144,15 +172,19 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *Model) validateToken(token string) tea.Cmd {
 	return func() tea.Msg {
-		client := api.NewClient(token)
+		svc := service.NewGraphQL(api.NewClient(token))
 		ctx, cancel := makeContext()
 		defer cancel()
-		_, err := queries.GetMe(ctx, client)
+		_, err := svc.GetMe(ctx)
 		return validateMsg{err: err}
 	}
 }
 
 func (m *Model) View() string {
+	if m.reauth {
+		return m.reauthView()
+	}
+
 	w := m.width
 	if w <= 0 {
 		w = 80
@@ -211,3 +243,40 @@ func (m *Model) View() string {
 	content := lipgloss.JoinVertical(lipgloss.Center, sections...)
 	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, content)
 }
+
+// reauthView renders the compact prompt shown over the app when the saved
+// token stops working.
+func (m *Model) reauthView() string {
+	var sections []string
+	switch m.state {
+	case stateInput:
+		sections = append(sections,
+			common.ValueStyle.Render("Hardcover rejected your API token. It may have"),
+			common.ValueStyle.Render("expired or been revoked. Enter a new one to continue."),
+			"",
+			common.FocusedBorderStyle.Render(m.textInput.View()),
+			"",
+			common.HelpStyle.Render("Get your token from https://hardcover.app/account/api"),
+			"",
+			m.help.ShortHelpView([]key.Binding{
+				key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "continue")),
+				key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "later")),
+			}),
+		)
+	case stateValidating:
+		sections = append(sections, fmt.Sprintf("%s Validating token...", m.spinner.View()))
+	case stateError:
+		sections = append(sections, common.ErrorStyle.Render("Authentication failed"), "")
+		if m.err != nil {
+			sections = append(sections, common.ValueStyle.Render(m.err.Error()), "")
+		}
+		sections = append(sections,
+			m.help.ShortHelpView([]key.Binding{
+				key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "try again")),
+				key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "later")),
+			}),
+		)
+	}
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
+	return common.RenderActivePanel("Session expired", content, 72)
+}
@@ -6,25 +6,26 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
-	"github.com/NotMugil/hardcover-tui/internal/api/queries"
+	"github.com/NotMugil/hardcover-tui/internal/api"
 )
 
 func (m *Model) loadStats() tea.Cmd {
-	client := m.client
+	svc := m.svc
 	user := m.user
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		goals, _ := queries.GetGoals(ctx, client, user.ID)
-		counts, err := queries.GetUserBookStatusCounts(ctx, client, user.ID)
+		ctx = api.WithPriority(ctx, api.PriorityBackground)
+		goals, _ := svc.GetGoals(ctx, user.ID)
+		counts, err := svc.GetUserBookStatusCounts(ctx, user.ID)
 		if err != nil {
 			return statsLoadedMsg{err: err}
 		}
-		userBooks, err := queries.GetUserBooksForStats(ctx, client, user.ID)
+		userBooks, err := svc.GetUserBooksForStats(ctx, user.ID)
 		if err != nil {
 			return statsLoadedMsg{err: err}
 		}
-		readingHistory, _ := queries.GetReadingHistory(ctx, client, user.ID)
+		readingHistory, _ := svc.GetReadingHistory(ctx, user.ID)
 		return statsLoadedMsg{goals: goals, counts: counts, userBooks: userBooks, readingHistory: readingHistory, err: err}
 	}
 }
@@ -0,0 +1,11 @@
+package stats
+
+import "github.com/NotMugil/hardcover-tui/internal/common"
+
+// Links returns the user's profile, where the same reading is shown.
+func (m *Model) Links() []common.Link {
+	if m.user == nil || m.user.Username == "" {
+		return nil
+	}
+	return []common.Link{common.UserLink(m.user.Username)}
+}
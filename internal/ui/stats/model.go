@@ -15,6 +15,7 @@ import (
 
 	"github.com/NotMugil/hardcover-tui/internal/api"
 	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/service"
 )
 
 type statsLoadedMsg struct {
@@ -35,11 +36,11 @@ type chartLabel struct {
 
 // Model is the stats screen model.
 type Model struct {
-	client    *api.Client
-	user      *api.User
-	goals     []api.Goal
-	counts    map[api.StatusID]int
-	userBooks []api.StatsUserBook
+	svc             service.HardcoverService
+	user            *api.User
+	goals           []api.Goal
+	counts          map[api.StatusID]int
+	userBooks       []api.StatsUserBook
 	fictionCount    int
 	nonfictionCount int
 	unknownLitCount int
@@ -48,26 +49,26 @@ type Model struct {
 	audiobookCount  int
 	unknownFmtCount int
 	genreCounts     map[string]int
-	genreChart     barchart.Model
-	genreLabels    []chartLabel
-	timeChart      tslc.Model
-	timeChartReady bool
-	readingHistory []api.ReadingHistoryEntry
-	goalProgress progress.Model
-	vp            viewport.Model
-	vpReady       bool
-	lastVpContent string // cache to avoid resetting scroll on identical content
-	spinner       spinner.Model
-	loading       bool
-	err           error
-	width         int
-	height        int
-	flexBox       *flexbox.FlexBox
-	lastChartW    int // track last width charts were built for
+	genreChart      barchart.Model
+	genreLabels     []chartLabel
+	timeChart       tslc.Model
+	timeChartReady  bool
+	readingHistory  []api.ReadingHistoryEntry
+	goalProgress    progress.Model
+	vp              viewport.Model
+	vpReady         bool
+	lastVpContent   string // cache to avoid resetting scroll on identical content
+	spinner         spinner.Model
+	loading         bool
+	err             error
+	width           int
+	height          int
+	flexBox         *flexbox.FlexBox
+	lastChartW      int // track last width charts were built for
 }
 
 // New creates a new stats screen.
-func New(client *api.Client, user *api.User) *Model {
+func New(svc service.HardcoverService, user *api.User) *Model {
 	s := spinner.New(
 		spinner.WithSpinner(spinner.Dot),
 		spinner.WithStyle(common.SpinnerStyle),
@@ -84,7 +85,7 @@ func New(client *api.Client, user *api.User) *Model {
 	)
 
 	return &Model{
-		client:       client,
+		svc:          svc,
 		user:         user,
 		spinner:      s,
 		loading:      true,
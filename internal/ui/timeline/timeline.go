@@ -0,0 +1,437 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/NotMugil/hardcover-tui/internal/api"
+	"github.com/NotMugil/hardcover-tui/internal/common"
+	"github.com/NotMugil/hardcover-tui/internal/service"
+)
+
+// pageSize is the number of entries fetched per page.
+const pageSize = 25
+
+// loadAhead is how close to the end the cursor gets before the next page loads.
+const loadAhead = 5
+
+// NavigateToBookMsg signals the app to navigate to a book's detail view.
+type NavigateToBookMsg struct {
+	BookID int
+}
+
+type pageLoadedMsg struct {
+	journals []api.ReadingJournal
+	offset   int
+	query    string
+	err      error
+}
+
+// Model is the global journal timeline screen. It lists every journal
+// entry across books, newest first, loading further pages on demand.
+type Model struct {
+	svc         service.HardcoverService
+	user        *api.User
+	entries     []api.ReadingJournal
+	cursor      int
+	top         int // first visible line
+	spinner     spinner.Model
+	loading     bool
+	loadingMore bool
+	hasMore     bool
+	err         error
+	search      textinput.Model
+	searching   bool   // search input focused
+	query       string // applied search query
+	grouped     bool   // group entries under day headers
+	width       int
+	height      int
+}
+
+// New creates a new journal timeline screen.
+func New(svc service.HardcoverService, user *api.User) *Model {
+	ti := textinput.New()
+	ti.Placeholder = "Search entries..."
+	ti.Width = 40
+	ti.Cursor.Style = common.CursorStyle
+
+	s := spinner.New(
+		spinner.WithSpinner(spinner.Dot),
+		spinner.WithStyle(common.SpinnerStyle),
+	)
+
+	return &Model{
+		svc:     svc,
+		user:    user,
+		spinner: s,
+		search:  ti,
+		loading: true,
+		grouped: true,
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadPage(0))
+}
+
+// SetSize updates the available terminal dimensions.
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.scroll()
+}
+
+// Loaded reports whether the first page has arrived.
+func (m *Model) Loaded() bool {
+	return !m.loading
+}
+
+// InputFocused returns true while typing a search query.
+func (m *Model) InputFocused() bool {
+	return m.searching
+}
+
+func (m *Model) loadPage(offset int) tea.Cmd {
+	svc := m.svc
+	user := m.user
+	query := m.query
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		journals, err := svc.GetReadingJournalsPage(ctx, user.ID, query, pageSize, offset)
+		return pageLoadedMsg{journals: journals, offset: offset, query: query, err: err}
+	}
+}
+
+// reload discards loaded entries and fetches the first page again.
+func (m *Model) reload() tea.Cmd {
+	m.entries = nil
+	m.cursor = 0
+	m.top = 0
+	m.hasMore = false
+	m.loading = true
+	m.err = nil
+	return tea.Batch(m.spinner.Tick, m.loadPage(0))
+}
+
+// maybeLoadMore fetches the next page when the cursor nears the end.
+func (m *Model) maybeLoadMore() tea.Cmd {
+	if !m.hasMore || m.loadingMore || m.loading {
+		return nil
+	}
+	if m.cursor < len(m.entries)-loadAhead {
+		return nil
+	}
+	m.loadingMore = true
+	return tea.Batch(m.spinner.Tick, m.loadPage(len(m.entries)))
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case pageLoadedMsg:
+		// Drop pages for a previous query or a page we already replaced.
+		if msg.query != m.query || msg.offset != len(m.entries) {
+			return m, nil
+		}
+		m.loading = false
+		m.loadingMore = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.entries = append(m.entries, msg.journals...)
+		m.hasMore = len(msg.journals) == pageSize
+		m.scroll()
+		return m, m.maybeLoadMore()
+
+	case spinner.TickMsg:
+		if m.loading || m.loadingMore {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+
+	case tea.KeyMsg:
+		if m.searching {
+			switch msg.String() {
+			case "enter":
+				m.searching = false
+				m.search.Blur()
+				m.query = strings.TrimSpace(m.search.Value())
+				return m, m.reload()
+			case "esc":
+				m.searching = false
+				m.search.Blur()
+				m.search.SetValue(m.query)
+				m.scroll()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Update(msg)
+			return m, cmd
+		}
+
+		if m.loading {
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "j", "down":
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+			m.scroll()
+			return m, m.maybeLoadMore()
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			m.scroll()
+			return m, nil
+		case "ctrl+d", "pgdown":
+			m.cursor = min(m.cursor+10, max(len(m.entries)-1, 0))
+			m.scroll()
+			return m, m.maybeLoadMore()
+		case "ctrl+u", "pgup":
+			m.cursor = max(m.cursor-10, 0)
+			m.scroll()
+			return m, nil
+		case "home":
+			m.cursor = 0
+			m.scroll()
+			return m, nil
+		case "end", "G":
+			m.cursor = max(len(m.entries)-1, 0)
+			m.scroll()
+			return m, m.maybeLoadMore()
+		case "g":
+			m.grouped = !m.grouped
+			m.scroll()
+			return m, nil
+		case "/":
+			m.searching = true
+			m.scroll()
+			return m, m.search.Focus()
+		case "esc":
+			if m.query != "" {
+				m.query = ""
+				m.search.SetValue("")
+				return m, m.reload()
+			}
+		case "r":
+			return m, m.reload()
+		case "enter":
+			if m.cursor < len(m.entries) {
+				e := m.entries[m.cursor]
+				var bookID int
+				switch {
+				case e.BookID != nil:
+					bookID = *e.BookID
+				case e.Book != nil:
+					bookID = e.Book.ID
+				}
+				if bookID > 0 {
+					return m, func() tea.Msg {
+						return NavigateToBookMsg{BookID: bookID}
+					}
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// entryDay parses the calendar day of an entry's action date.
+func entryDay(j api.ReadingJournal) (time.Time, bool) {
+	s := j.ActionAt
+	if len(s) > 10 {
+		s = s[:10]
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	return t, err == nil
+}
+
+// dayHeading formats a day header relative to today.
+func dayHeading(day time.Time) string {
+	today := time.Now()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+	switch {
+	case day.Equal(today):
+		return "Today"
+	case day.Equal(today.AddDate(0, 0, -1)):
+		return "Yesterday"
+	case day.Year() == today.Year():
+		return day.Format("Monday, January 2")
+	default:
+		return day.Format("Monday, January 2, 2006")
+	}
+}
+
+// renderLines renders every loaded entry and returns the lines together
+// with the first and last line index of the entry under the cursor.
+func (m *Model) renderLines(width int) (lines []string, curStart, curEnd int) {
+	var lastDay time.Time
+	selected := lipgloss.NewStyle().Foreground(common.ColorPrimary).Bold(true)
+	for i, e := range m.entries {
+		if m.grouped {
+			if day, ok := entryDay(e); ok && !day.Equal(lastDay) {
+				if i > 0 {
+					lines = append(lines, "")
+				}
+				lines = append(lines, common.LabelStyle.Render(dayHeading(day)))
+				lastDay = day
+			}
+		}
+
+		title := common.JournalLabel(e)
+		if !m.grouped {
+			title = common.JournalTitle(e, false)
+		}
+		if e.Book != nil {
+			title += common.HelpStyle.Render(" - ") + common.ValueStyle.Render(e.Book.Title)
+		}
+		title = lipgloss.NewStyle().MaxWidth(width - 2).Render(title)
+
+		if i == m.cursor {
+			curStart = len(lines)
+			lines = append(lines, selected.Render("▌ ")+title)
+		} else {
+			lines = append(lines, "  "+title)
+		}
+		if desc := common.JournalDescription(e); desc != "" {
+			desc = lipgloss.NewStyle().MaxWidth(width - 4).Render(desc)
+			lines = append(lines, "    "+common.HelpStyle.Render(desc))
+		}
+		if i == m.cursor {
+			curEnd = len(lines) - 1
+		}
+	}
+	return lines, curStart, curEnd
+}
+
+// header renders everything above the entries panel.
+func (m *Model) header() string {
+	var b strings.Builder
+	b.WriteString(common.TitleStyle.Render("Journal Timeline"))
+	b.WriteString("\n\n")
+
+	if m.searching {
+		b.WriteString(common.FocusedBorderStyle.Render(m.search.View()))
+		b.WriteString("\n")
+	} else if m.query != "" {
+		b.WriteString(common.LabelStyle.Render("Search: ") + common.ValueStyle.Render(m.query))
+		b.WriteString(common.HelpStyle.Render("  (esc to clear)"))
+		b.WriteString("\n\n")
+	}
+
+	if m.err != nil {
+		b.WriteString(common.ErrorStyle.Render("Error: "+m.err.Error()) + "\n\n")
+	}
+	return b.String()
+}
+
+// entriesWidth returns the width entries are rendered at.
+func (m *Model) entriesWidth() int {
+	if w := m.width - 8; w >= 40 {
+		return w
+	}
+	return 76
+}
+
+// visibleLines returns how many entry lines fit below the header.
+func (m *Model) visibleLines() int {
+	return max(m.height-lipgloss.Height(m.header())-6, 5)
+}
+
+// scroll moves the window so the selected entry, and its day header when
+// it is the first entry of the day, stays visible.
+func (m *Model) scroll() {
+	if len(m.entries) == 0 {
+		m.top = 0
+		return
+	}
+	lines, curStart, curEnd := m.renderLines(m.entriesWidth())
+	visible := m.visibleLines()
+	if curStart > 0 && m.grouped && curStart-1 < m.top {
+		curStart--
+	}
+	if curStart < m.top {
+		m.top = curStart
+	}
+	if curEnd >= m.top+visible {
+		m.top = curEnd - visible + 1
+	}
+	if m.top > len(lines)-visible {
+		m.top = max(len(lines)-visible, 0)
+	}
+}
+
+func (m *Model) View() string {
+	if m.loading {
+		return common.AppStyle.Render(
+			fmt.Sprintf("\n  %s Loading journal...\n", m.spinner.View()),
+		)
+	}
+
+	var b strings.Builder
+	b.WriteString(m.header())
+
+	w := m.entriesWidth()
+	var body string
+	if len(m.entries) == 0 {
+		if m.query != "" {
+			body = common.ValueStyle.Render("No entries match your search.")
+		} else {
+			body = common.ValueStyle.Render("No journal entries yet.")
+		}
+	} else {
+		lines, _, _ := m.renderLines(w)
+		top := min(m.top, len(lines))
+		end := min(top+m.visibleLines(), len(lines))
+		body = strings.Join(lines[top:end], "\n")
+	}
+
+	status := fmt.Sprintf("%d entries", len(m.entries))
+	if m.hasMore {
+		status += "+"
+	}
+	if m.loadingMore {
+		status += " " + m.spinner.View() + " loading more..."
+	}
+	body += "\n\n" + common.HelpStyle.Render(status)
+
+	b.WriteString(common.RenderActivePanel("Entries", body, w+4))
+	return common.AppStyle.Render(b.String())
+}
+
+// Links returns the pages of the selected entry's book.
+func (m *Model) Links() []common.Link {
+	if m.cursor >= len(m.entries) || m.entries[m.cursor].Book == nil {
+		return nil
+	}
+	return common.BookLinks(*m.entries[m.cursor].Book)
+}
+
+// HelpBindings returns page-specific keybindings for the global help bar.
+func (m *Model) HelpBindings() []key.Binding {
+	if m.searching {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "search")),
+			key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		}
+	}
+	return []key.Binding{
+		key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open book")),
+		key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+		key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "group by day")),
+		key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+	}
+}